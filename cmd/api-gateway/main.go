@@ -1,15 +1,28 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"go-music-shop/internal/config"
 	"go-music-shop/internal/delivery/handlers"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/monitoring"
 	"go-music-shop/internal/repository"
 	"go-music-shop/internal/service"
+	"go-music-shop/internal/startup"
+	"go-music-shop/pkg/alert"
+	"go-music-shop/pkg/cdn"
 	"go-music-shop/pkg/database"
+	"go-music-shop/pkg/discogs"
+	"go-music-shop/pkg/fx"
+	"go-music-shop/pkg/hooks"
+	"go-music-shop/pkg/httpserver"
+	"go-music-shop/pkg/listener"
 	"go-music-shop/pkg/redis"
+	"go-music-shop/pkg/storage"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +33,15 @@ func main() {
 	// Пример: ServerPort="8080", Database{Host:"localhost", Port:"5432", ...}
 	cfg := config.Load()
 
+	// Маскирование персональных данных и секретов во всех логах: стандартный log и вывод gin
+	var logMasker *monitoring.Masker
+	if cfg.LogMasking.Enabled {
+		logMasker = monitoring.NewMasker(cfg.LogMasking.Fields)
+	}
+	log.SetOutput(logMasker.Writer(os.Stderr))
+	gin.DefaultWriter = logMasker.Writer(os.Stdout)
+	gin.DefaultErrorWriter = logMasker.Writer(os.Stderr)
+
 	var db *sql.DB
 	var err error
 
@@ -51,39 +73,362 @@ func main() {
 	}
 	defer redisClient.Close()
 
+	// Проверяем окружение (схема БД, Redis, конфигурация, часы) до приема запросов
+	startup.Run("api-gateway", cfg, db, redisClient)
+
 
 	// Создаем цепочку зависимостей:
 
 	// 1. Репозиторий - работает непосредственно с базой данных
 	// Выполняет SQL запросы: SELECT, INSERT, UPDATE, DELETE
 	postgresRepo := repository.NewPostgresAlbumRepository(db)
+	if err := repository.SetIDStrategy(cfg.IDStrategy); err != nil {
+		log.Fatalf("invalid ID strategy: %v", err)
+	}
+
+	cacheMetrics := monitoring.NewCacheMetrics()
+	cachedRepo := repository.NewCachedAlbumRepository(postgresRepo, redisClient, cacheMetrics)
+	cachedRepo.SetNamespace(cfg.Redis.CacheNamespace)
+	cachedRepo.SetSchemaVersion(cfg.Redis.CacheSchemaVersion)
 
-	cachedRepo := repository.NewCachedAlbumRepository(postgresRepo, redisClient)
+	// Оценка памяти кэша и сброс при превышении лимита
+	if cfg.Redis.CacheQuotaCheckInterval > 0 {
+		cachedRepo.StartQuotaEnforcer(context.Background(),
+			time.Duration(cfg.Redis.CacheQuotaCheckInterval)*time.Second, cfg.Redis.CacheMaxBytes)
+	}
+
+	// Сверка кэша с базой: показывает, насколько кэш расходится с данными после ошибок инвалидации
+	if cfg.Redis.CacheReconcileInterval > 0 {
+		cachedRepo.StartReconciler(context.Background(),
+			time.Duration(cfg.Redis.CacheReconcileInterval)*time.Second,
+			cfg.Redis.CacheReconcileSample, cfg.Redis.CacheReconcileHeal)
+	}
 
 	// 2. Сервис - содержит бизнес-логику приложения
 	// Выполняет валидацию, проверки, бизнес-правила
 	// Не знает о том, как хранятся данные (в памяти, в БД, в файле)
-	albumService := service.NewAlbumService(cachedRepo)
+	// Purger очищает кэш CDN после изменений каталога (если CDN настроен)
+	purger := cdn.NewPurger(cfg)
+	albumService := service.NewAlbumService(cachedRepo, purger)
+
+	// Подозрительные цены (опечатки вроде $5699 вместо $56.99) требуют подтверждения и пишутся в аудит
+	auditRepo := repository.NewPostgresAuditRepository(db)
+	albumService.SetPriceGuard(service.NewPriceGuard(cfg.PriceGuard, auditRepo))
+	albumService.SetAuditLog(auditRepo) // Приходы и списания со склада
+	albumService.SetTombstoneReader(repository.NewPostgresSyncRepository(db)) // Повторный DELETE не считается ошибкой
+	albumService.SetVariantRepository(repository.NewPostgresVariantRepository(db)) // Цена и количество по изданиям
+	albumService.SetArchiveRepository(repository.NewPostgresArchiveRepository(db)) // Массовое архивирование из админки
+	auditHandler := handlers.NewAuditHandler(auditRepo)
+
+	// Операционные оповещения в Slack/Discord (если настроены webhook)
+	alerts := alert.NewNotifier(cfg.Alerts)
+
+	// Курсы валют для показа цен в валюте покупателя (?currency=EUR или Accept-Currency)
+	fxProvider, err := fx.NewProvider(cfg.FX)
+	if err != nil {
+		log.Fatalf("invalid FX configuration: %v", err)
+	}
+	fxService := service.NewFXService(fxProvider, redisClient, cfg.FX)
+	fxService.SetNotifier(alerts)
+	fxService.Start(context.Background())
+	fxHandler := handlers.NewFXHandler(fxService)
 
 	// 3. Обработчик - работает с HTTP запросами и ответами
 	// Принимает JSON, возвращает JSON с правильными HTTP статусами
-	albumHandler := handlers.NewAlbumHandler(albumService)
+	albumHandler := handlers.NewAlbumHandler(albumService, fxService)
+
+	// Подсказки для строки поиска: индекс в Redis обновляется при изменении каталога
+	// и перестраивается целиком при старте (на случай пропущенных изменений)
+	suggestService := service.NewSuggestService(redisClient)
+	albumService.Subscribe(suggestService)
+	albumService.Subscribe(hooks.Default) // Хуки кода, встраивающего сервис (hooks.OnAlbumChanged)
+	go func() {
+		if err := suggestService.Rebuild(context.Background(), albumService.StreamAllAlbums()); err != nil {
+			log.Printf("rebuilding suggest index error: %v", err)
+		}
+	}()
+	suggestHandler := handlers.NewSuggestHandler(suggestService)
+
+	// Закупочные цены и маржа - только для админки
+	costRepo := repository.NewPostgresCostRepository(db)
+	costHandler := handlers.NewCostHandler(service.NewCostService(costRepo))
+
+	// Снимки каталога в файловом хранилище и восстановление из них
+	objectStorage, err := storage.NewFileStorage(cfg.StorageDir)
+	if err != nil {
+		log.Fatalf("opening storage error: %v", err)
+	}
+	snapshotService := service.NewSnapshotService(
+		costRepo,
+		repository.NewPostgresSnapshotRepository(db),
+		objectStorage,
+		func() {
+			// Каталог изменен в обход сервиса - сбрасываем кэш и перестраиваем подсказки
+			cachedRepo.InvalidateAll()
+			if err := suggestService.Rebuild(context.Background(), albumService.StreamAllAlbums()); err != nil {
+				log.Printf("rebuilding suggest index error: %v", err)
+			}
+		},
+	)
+	snapshotService.SetAuditLog(auditRepo)
+	snapshotHandler := handlers.NewSnapshotHandler(snapshotService)
+
+	// Тяжелые операции в фоне: запрос сразу получает id задачи, результат скачивается по ссылке
+	jobHandler := handlers.NewJobHandler(
+		service.NewJobService(objectStorage),
+		service.CatalogExportJob(albumService, objectStorage),
+		service.SuggestReindexJob(albumService, suggestService),
+	)
+
+	// Загрузка альбомов из таблиц сотрудников: предпросмотр с ошибками по строкам, затем загрузка
+	importHandler := handlers.NewImportHandler(service.NewImportService(albumService, objectStorage, cfg.Import))
+
+	// Места хранения пластинок на складе
+	binHandler := handlers.NewBinHandler(
+		service.NewBinService(repository.NewPostgresBinRepository(db)),
+	)
+
+	// Даты выхода альбомов и календарь релизов для подписки (iCalendar)
+	releaseHandler := handlers.NewReleaseHandler(
+		service.NewReleaseService(repository.NewPostgresReleaseRepository(db)),
+	)
+
+	// Внешние id альбомов (Discogs, MusicBrainz, Shopify, касса) для интеграций
+	externalIDService := service.NewExternalIDService(repository.NewPostgresExternalIDRepository(db))
+	externalIDHandler := handlers.NewExternalIDHandler(externalIDService)
+
+	// Приемка на склад сканером штрихкодов; неизвестные штрихкоды ищутся в Discogs в фоне
+	inventoryService := service.NewInventoryService(albumService, externalIDService,
+		repository.NewPostgresUnknownBarcodeRepository(db))
+	if cfg.Discogs.Token != "" && !cfg.ReadOnly.Enabled {
+		inventoryService.SetBarcodeLookup(discogs.NewClient(cfg.Discogs.Token))
+		inventoryService.StartEnrichment(context.Background(),
+			time.Duration(cfg.Discogs.EnrichInterval)*time.Second, cfg.Discogs.EnrichBatch)
+	}
+	inventoryHandler := handlers.NewInventoryHandler(inventoryService)
+
+	// Пакетная проверка цен и наличия для партнеров-маркетплейсов (по id или штрихкоду)
+	partnerHandler := handlers.NewPartnerHandler(albumService, externalIDService, fxService, cfg.Partner.MaxItems)
+
+	// Ценники на полки (PDF со штрихкодом id альбома)
+	shelfLabelHandler := handlers.NewShelfLabelHandler(albumService, fxService, cfg.ShelfLabels)
+
+	// Внутренние заметки сотрудников к альбомам; упоминания @имя уходят в чат команды
+	noteService := service.NewNoteService(repository.NewPostgresNoteRepository(db))
+	noteService.SetNotifier(alerts)
+	noteHandler := handlers.NewNoteHandler(noteService)
+
+	// Отзывы покупателей; рейтинг хранится в альбоме, поэтому после изменения отзывов сбрасываем его кэш
+	reviewService := service.NewReviewService(repository.NewPostgresReviewRepository(db))
+	reviewService.SetCache(cachedRepo)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+
+	// Треки альбомов: отдельно и в GET /albums/:id?include=tracks
+	trackService := service.NewTrackService(repository.NewPostgresTrackRepository(db))
+	albumHandler.SetTrackService(trackService)
+	trackHandler := handlers.NewTrackHandler(trackService)
+
+	// Названия и примечания альбомов на разных языках: по Accept-Language и в поиске
+	localizationService := service.NewLocalizationService(repository.NewPostgresLocalizationRepository(db),
+		cfg.Localization.DefaultLanguage)
+	localizationService.SetCache(cachedRepo)
+	albumHandler.SetLocalizationService(localizationService)
+	localizationHandler := handlers.NewLocalizationHandler(localizationService)
+
+	// Обложки альбомов в S3/MinIO; без S3_ENDPOINT загрузка обложек выключена
+	var coverHandler *handlers.CoverHandler
+	if cfg.S3.Endpoint != "" {
+		coverStorage, err := storage.NewS3Storage(cfg.S3)
+		if err != nil {
+			log.Fatalf("opening cover storage error: %v", err)
+		}
+		coverService := service.NewCoverService(coverStorage, repository.NewPostgresCoverRepository(db), cfg.Covers.MaxBytes)
+		coverService.SetCache(cachedRepo)
+		coverService.SetPurger(purger)
+		coverHandler = handlers.NewCoverHandler(coverService)
+	}
+
+	// Издания альбомов (LP, переиздания, CD) со своими ценой и количеством
+	variantHandler := handlers.NewVariantHandler(albumService)
+
+	// Качество карточек каталога: незаполненные поля, нулевые цены, подозрения на дубли
+	qualityService := service.NewQualityService(albumService, cfg.Quality)
+	qualityService.SetNotifier(alerts)
+	if cfg.Quality.WeeklyDigest && !cfg.ReadOnly.Enabled {
+		qualityService.StartDigest(context.Background())
+	}
+	qualityHandler := handlers.NewQualityHandler(qualityService)
+
+	// Лейблы звукозаписи и просмотр каталога по лейблам
+	labelService := service.NewLabelService(repository.NewPostgresLabelRepository(db))
+	labelService.SetCache(cachedRepo)
+	labelHandler := handlers.NewLabelHandler(labelService, fxService)
+
+	// Синхронизация каталога для офлайн-режима мобильного приложения
+	syncHandler := handlers.NewSyncHandler(
+		service.NewSyncService(repository.NewPostgresSyncRepository(db)),
+	)
+
+	// Поиск по каталогу с подсказками при опечатках (pg_trgm)
+	// Поиски без результатов пишутся в журнал для закупок (на репликах только для чтения - нет)
+	searchService := service.NewSearchService(repository.NewPostgresSearchRepository(db))
+	if !cfg.ReadOnly.Enabled {
+		searchService.SetZeroResultLog(repository.NewPostgresZeroResultRepository(db))
+	}
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	// Статистика каталога читается из материализованного представления,
+	// которое пересчитывается в фоне по расписанию
+	statsService := service.NewStatsService(
+		repository.NewPostgresStatsRepository(db),
+		time.Duration(cfg.StatsRefreshInterval)*time.Second,
+	)
+	if !cfg.ReadOnly.Enabled {
+		statsService.StartRefresher(context.Background())
+	}
+	statsHandler := handlers.NewStatsHandler(statsService)
+
+	// Просмотры альбомов копятся в Redis и пачками сбрасываются в БД
+	viewService := service.NewViewService(
+		repository.NewPostgresViewRepository(db),
+		redisClient,
+		time.Duration(cfg.ViewsFlushInterval)*time.Second,
+	)
+	if !cfg.ReadOnly.Enabled {
+		viewService.StartFlusher(context.Background())
+	}
+	viewHandler := handlers.NewViewHandler(viewService)
+
+	// Лимит частоты публичных запросов с одного IP и счетчики трафика
+	trafficMetrics := monitoring.NewTrafficMetrics()
+	rateLimiter := middleware.NewIPRateLimiter(cfg.RateLimit, trafficMetrics)
+
+	// Распродажа: на время окна кэш наличия живет меньше, лимит частоты строже,
+	// популярные альбомы заранее загружаются в кэш; окно общее для всех экземпляров (в Redis)
+	flashSaleService := service.NewFlashSaleService(
+		redisClient, cfg.FlashSale, cachedRepo, rateLimiter, albumService, viewService, trafficMetrics,
+	)
+	flashSaleService.SetNamespace(cfg.Redis.CacheNamespace)
+	flashSaleService.SetNotifier(alerts)
+	flashSaleService.StartWatcher(context.Background())
+	flashSaleHandler := handlers.NewFlashSaleHandler(flashSaleService, trafficMetrics)
+
+	// Мониторинг пулов подключений: предупреждает в логах и чатах команды об исчерпании пулов
+	poolMonitor := monitoring.NewPoolMonitor(db, redisClient, cfg.Monitoring)
+	poolMonitor.SetNotifier(alerts)
+	poolMonitor.Start(context.Background())
+	internalHandler := handlers.NewInternalHandler(poolMonitor, cacheMetrics)
+
+	// Отладочные записи запросов: выборка по DEBUG_CAPTURE_SAMPLE_PERCENT или по заголовку X-Debug-Capture от сотрудников
+	captureBuffer := monitoring.NewCaptureBuffer(cfg.DebugCapture.BufferSize)
+	debugCaptureHandler := handlers.NewDebugCaptureHandler(captureBuffer)
 
 	router := gin.Default()
 
+	// Доверяем X-Forwarded-For только от своих балансировщиков,
+	// иначе клиент может подменить свой IP (по умолчанию gin доверяет всем)
+	if err := router.SetTrustedProxies(cfg.HTTPServer.TrustedProxies); err != nil {
+		log.Fatalf("invalid trusted proxies: %v", err)
+	}
+	router.Use(middleware.RequestID())
+	// Обложке нужен свой лимит: файл обычно больше JSON, плюс запас на заголовки multipart
+	router.Use(middleware.MaxBodySize(cfg.HTTPServer.MaxBodyBytes, map[string]int64{
+		"/albums/:id/cover": cfg.Covers.MaxBytes + 64<<10,
+	}))
+	if cfg.DebugCapture.Enabled {
+		router.Use(middleware.DebugCapture(cfg.DebugCapture, cfg.Auth, captureBuffer, logMasker))
+	}
+
+	// Компактный JSON по умолчанию; отступы - только в отладке (PRETTY_JSON) или с ?pretty=1
+	router.Use(middleware.PrettyJSON(cfg.PrettyJSON))
+
+	// Неизвестные поля в телах запросов отклоняются (JSON_STRICT_BINDING) или игнорируются
+	router.Use(middleware.StrictJSON(cfg.StrictJSON))
+
+	// Реплика только для чтения: изменения отклоняются с адресом основного экземпляра,
+	// публичные ответы кэшируются дольше, админка не регистрируется
+	trackView := viewHandler.TrackView
+	if cfg.ReadOnly.Enabled {
+		router.Use(middleware.ReadOnly(cfg.ReadOnly.PrimaryURL))
+		cfg.HTTPCache.MaxAge = cfg.ReadOnly.HTTPCacheMaxAge
+		trackView = func(c *gin.Context) { c.Next() } // Просмотры некому сбрасывать в БД
+	}
+
+	// При перегрузке первыми отклоняются выгрузки и отчеты, затем просмотр каталога;
+	// изменения каталога получают слот, пока он есть (health и /internal не ограничиваются)
+	shedder := middleware.NewLoadShedder(cfg.LoadShedding)
+
 	// Регистрируем маршруты (URL пути) и связываем их с обработчиками
-	router.GET("/albums", albumHandler.GetAlbums)
-	router.GET("/albums/:id", albumHandler.GetAlbumByID)
-	router.POST("/albums", albumHandler.CreateAlbum)
-	router.PUT("/albums/:id", albumHandler.UpdateAlbum)
-	router.DELETE("/albums/:id", albumHandler.DeleteAlbum)
-	router.GET("/artists/:artist/albums", albumHandler.GetAlbumsByArtist)
-	router.GET("/albums/stock", albumHandler.GetAlbumsInStock)
+	// Публичные маршруты на чтение отдаются с заголовками кэширования для CDN
+	public := router.Group("/", rateLimiter.Limit(), shedder.Limit(middleware.PriorityNormal), middleware.CacheControl(cfg.HTTPCache))
+	public.GET("/albums", albumHandler.GetAlbums)
+	public.GET("/albums/:id", trackView, albumHandler.GetAlbumByID)
+	public.GET("/artists/:artist/albums", albumHandler.GetAlbumsByArtist)
+	public.GET("/albums/stock", albumHandler.GetAlbumsInStock)
+	public.GET("/albums/stats", statsHandler.GetAlbumStats)
+	public.GET("/albums/trending", viewHandler.GetTrending)
+	public.GET("/albums/suggest", suggestHandler.Suggest)
+	public.GET("/albums/search", searchHandler.Search)
+	public.GET("/albums/:id/reviews", reviewHandler.GetReviews)
+	public.GET("/albums/:id/tracks", trackHandler.GetTracks)
+	public.GET("/albums/:id/variants", variantHandler.GetVariants)
+	public.GET("/albums/by-external/:system/:external_id", externalIDHandler.ResolveAlbumID, albumHandler.GetAlbumByID)
+	public.GET("/feeds/releases.ics", releaseHandler.GetReleasesICS)
+	public.GET("/labels", labelHandler.GetLabels)
+	public.GET("/labels/:id", labelHandler.GetLabel)
+	public.GET("/labels/:id/albums", labelHandler.GetLabelAlbums)
+
+	// API для партнеров - по ключу из PARTNER_API_KEYS, со своим лимитом частоты на каждого партнера
+	if partnerKeys := middleware.ParsePartnerKeys(cfg.Partner.APIKeys); len(partnerKeys) > 0 {
+		partnerLimiter := middleware.NewRateLimiter(
+			config.RateLimitConfig{RequestsPerSecond: cfg.Partner.RequestsPerSecond, Burst: cfg.Partner.Burst},
+			trafficMetrics, middleware.CurrentPartner,
+		)
+		partner := router.Group("/partner", middleware.PartnerAuth(partnerKeys), partnerLimiter.Limit(), shedder.Limit(middleware.PriorityNormal))
+		partner.POST("/availability", partnerHandler.GetAvailability)
+	}
+
+	// Синхронизация офлайн-каталога - не кэшируется: курсор должен видеть последние изменения
+	router.GET("/sync/albums", shedder.Limit(middleware.PriorityNormal), syncHandler.GetChanges)
+	router.GET("/sync/albums/checksum", shedder.Limit(middleware.PriorityNormal), syncHandler.GetChecksum)
+
+	// Изменения каталога - с токеном (JWT_SECRET): каталог меняют администраторы,
+	// наличие - еще и сотрудники склада
+	// Без JWT_SECRET сервис не запускается: пропущенная переменная не должна открывать изменения всем
+	switch {
+	case cfg.Auth.Disabled:
+		log.Println("WARNING: AUTH_DISABLED is set, album changes and admin routes are not authenticated")
+	case cfg.Auth.JWTSecret == "":
+		log.Fatal("JWT_SECRET is not set (set AUTH_DISABLED=true to run without authentication in development)")
+	}
+	catalogWrite := middleware.Authenticate(cfg.Auth, service.PermCatalogWrite)
+	writes := router.Group("/", shedder.Limit(middleware.PriorityCritical))
+	writes.POST("/albums", catalogWrite, albumHandler.CreateAlbum)
+	writes.PUT("/albums/:id", catalogWrite, albumHandler.UpdateAlbum)
+	writes.DELETE("/albums/:id", catalogWrite, albumHandler.DeleteAlbum)
+	writes.PUT("/albums/:id/tracks", catalogWrite, trackHandler.ReplaceTracks)
+	writes.POST("/albums/:id/tracks", catalogWrite, trackHandler.AddTrack)
+	writes.PUT("/albums/:id/tracks/:track_id", catalogWrite, trackHandler.UpdateTrack)
+	writes.DELETE("/albums/:id/tracks/:track_id", catalogWrite, trackHandler.DeleteTrack)
+	writes.POST("/albums/:id/variants", catalogWrite, variantHandler.AddVariant)
+	writes.PUT("/albums/:id/variants/:variant_id", catalogWrite, variantHandler.UpdateVariant)
+	writes.DELETE("/albums/:id/variants/:variant_id", catalogWrite, variantHandler.DeleteVariant)
+	if coverHandler != nil {
+		writes.POST("/albums/:id/cover", catalogWrite, coverHandler.UploadCover)
+		writes.DELETE("/albums/:id/cover", catalogWrite, coverHandler.DeleteCover)
+	}
+	stockWrite := middleware.Authenticate(cfg.Auth, service.PermStockWrite)
+	writes.PUT("/albums/:id/stock", stockWrite, albumHandler.SetStock)
+	writes.POST("/albums/:id/stock/adjust", stockWrite, albumHandler.AdjustStock)
+	writes.POST("/albums/:id/variants/:variant_id/stock/adjust", stockWrite, variantHandler.AdjustStock)
+	reviewWrite := middleware.Authenticate(cfg.Auth, service.PermReviewWrite)
+	writes.POST("/albums/:id/reviews", reviewWrite, reviewHandler.AddReview)
+	writes.DELETE("/albums/:id/reviews", reviewWrite, reviewHandler.DeleteReview)
 
 	// Маршрут для проверки здоровья приложения
 	// Используется мониторингами чтобы проверить что приложение работает
 	router.GET("/health", func(c *gin.Context) {
-		c.IndentedJSON(http.StatusOK, gin.H{
+		c.JSON(http.StatusOK, gin.H{
 			"status":  "ok",
 			"service": "vintage-jazz-shop",
 			"database": "connected",
@@ -91,9 +436,108 @@ func main() {
 		})
 	})
 
+	// Админка и служебные эндпоинты - только администраторам
+	adminAccess := middleware.Authenticate(cfg.Auth, service.PermAdmin)
+
+	// Маршруты для админки (на репликах только для чтения не регистрируются)
+	if !cfg.ReadOnly.Enabled {
+		// Закупочные цены и маржа (альбомы админки, ячейки склада, оценка запасов) отдаются только
+		// в группах с adminAccess: маршруты с cost_price не регистрируются без проверки роли
+		admin := router.Group("/admin", shedder.Limit(middleware.PriorityNormal), adminAccess)
+		admin.GET("/albums", costHandler.GetAlbums)
+		admin.GET("/albums/:id", costHandler.GetAlbum)
+		admin.POST("/albums/bulk-archive", albumHandler.ArchiveAlbums)
+		admin.PUT("/albums/:id/cost", costHandler.SetCostPrice)
+		admin.PUT("/albums/:id/condition-notes", costHandler.SetConditionNotes)
+		admin.PUT("/albums/:id/bin", binHandler.AssignBin)
+		admin.PUT("/albums/:id/release-date", releaseHandler.SetReleaseDate)
+		admin.GET("/albums/:id/views", viewHandler.GetAlbumViews)
+		admin.PUT("/albums/:id/label", labelHandler.SetAlbumLabel)
+		admin.GET("/albums/:id/external-ids", externalIDHandler.GetExternalIDs)
+		admin.PUT("/albums/:id/external-ids/:system", externalIDHandler.SetExternalID)
+		admin.DELETE("/albums/:id/external-ids/:system", externalIDHandler.DeleteExternalID)
+		admin.GET("/albums/:id/localizations", localizationHandler.GetLocalizations)
+		admin.PUT("/albums/:id/localizations/:lang", localizationHandler.SetLocalization)
+		admin.DELETE("/albums/:id/localizations/:lang", localizationHandler.DeleteLocalization)
+		admin.GET("/albums/:id/label.pdf", shelfLabelHandler.GetLabel)
+		admin.GET("/shelf-labels.pdf", shelfLabelHandler.GetLabels)
+		admin.GET("/bins/:code", binHandler.GetBinContents)
+		admin.GET("/inventory/unknown-barcodes", inventoryHandler.GetUnknownBarcodes)
+		admin.POST("/labels", labelHandler.CreateLabel)
+		admin.PUT("/labels/:id", labelHandler.UpdateLabel)
+		admin.DELETE("/labels/:id", labelHandler.DeleteLabel)
+		admin.GET("/snapshots", snapshotHandler.ListSnapshots)
+		admin.GET("/audit", auditHandler.GetAuditLog)
+		admin.GET("/fx/rates", fxHandler.GetRates)
+		admin.PUT("/fx/rates/:currency", fxHandler.SetOverride)
+		admin.GET("/flash-sale", flashSaleHandler.GetFlashSale)
+		admin.POST("/flash-sale", flashSaleHandler.ScheduleFlashSale)
+		admin.DELETE("/flash-sale", flashSaleHandler.CancelFlashSale)
+		admin.DELETE("/fx/rates/:currency", fxHandler.DeleteOverride)
+
+		// Заметки к альбомам ведут и сотрудники магазина; автор заметки - пользователь из токена
+		notes := router.Group("/admin", shedder.Limit(middleware.PriorityNormal), middleware.Authenticate(cfg.Auth, service.PermNotes))
+		notes.GET("/albums/:id/notes", noteHandler.GetNotes)
+		notes.POST("/albums/:id/notes", noteHandler.AddNote)
+		notes.DELETE("/albums/:id/notes/:note_id", noteHandler.DeleteNote)
+
+		// Приемка сканером - с высоким приоритетом: сканер ждет подтверждения каждого скана
+		router.POST("/admin/inventory/receive", shedder.Limit(middleware.PriorityCritical), stockWrite, inventoryHandler.Receive)
+
+		// Тяжелые админские операции (снимки каталога, отчеты) - с низким приоритетом
+		adminReports := router.Group("/admin", shedder.Limit(middleware.PriorityBestEffort), adminAccess)
+		adminReports.POST("/snapshots", snapshotHandler.CreateSnapshot)
+		adminReports.POST("/snapshots/:id/preview", snapshotHandler.PreviewRestore)
+		adminReports.POST("/snapshots/:id/restore", snapshotHandler.Restore)
+		adminReports.GET("/albums/export", albumHandler.ExportAlbums) // Потоковая выгрузка каталога (NDJSON) - не кэшируется
+		adminReports.GET("/reports/valuation", costHandler.GetValuation)
+		adminReports.GET("/reports/zero-result-searches", searchHandler.GetZeroResultSearches)
+		adminReports.GET("/quality", qualityHandler.GetReport)
+		adminReports.POST("/jobs/exports", jobHandler.StartExport)
+		adminReports.POST("/jobs/reindex", jobHandler.StartReindex)
+		admin.GET("/imports/fields", importHandler.GetFields)
+		adminReports.POST("/imports/preview", importHandler.Preview)
+		adminReports.POST("/imports/:id/commit", importHandler.Commit)
+		admin.GET("/jobs", jobHandler.ListJobs)
+		admin.GET("/jobs/:id", jobHandler.GetJob)
+		admin.GET("/jobs/:id/result", jobHandler.GetResult)
+		admin.DELETE("/jobs/:id", jobHandler.CancelJob)
+	}
+
+	// Служебные эндпоинты для эксплуатации
+	internal := router.Group("/internal", adminAccess)
+	internal.GET("/pools", internalHandler.GetPoolStats)
+	internal.GET("/cache", internalHandler.GetCacheStats)
+	internal.GET("/cache/usage", internalHandler.GetCacheUsage)
+	internal.GET("/cache/reconciliation", internalHandler.GetCacheReconciliation)
+	internal.GET("/traffic", flashSaleHandler.GetTraffic)
+	// Отладочные записи содержат заголовки и тела чужих запросов - как и остальные служебные эндпоинты, только администраторам
+	internal.GET("/captures", debugCaptureHandler.ListCaptures)
+	internal.GET("/captures/:request_id", debugCaptureHandler.GetCapture)
+
 	// Запускаем HTTP сервер на указанном порту
-	log.Printf("Server starting on port %s", cfg.ServerPort)
-	router.Run(":" + cfg.ServerPort)
+	// Используем http.Server напрямую, чтобы задать таймауты (router.Run их не выставляет)
+	server := &http.Server{
+		Addr:              ":" + cfg.ServerPort,
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(cfg.HTTPServer.ReadHeaderTimeout) * time.Second,
+		ReadTimeout:       time.Duration(cfg.HTTPServer.ReadTimeout) * time.Second,
+		WriteTimeout:      time.Duration(cfg.HTTPServer.WriteTimeout) * time.Second,
+		IdleTimeout:       time.Duration(cfg.HTTPServer.IdleTimeout) * time.Second,
+		MaxHeaderBytes:    cfg.HTTPServer.MaxHeaderBytes,
+	}
+
+	// Слушаем TCP порт, unix socket или сокет от systemd - в зависимости от конфигурации
+	ln, err := listener.Listen(cfg.Listen, server.Addr)
+	if err != nil {
+		log.Fatalf("starting HTTP listener error: %v", err)
+	}
+
+	// HTTPS (и HTTP/2) включается, если заданы сертификаты или домены для Let's Encrypt
+	log.Printf("Server starting on %s", ln.Addr())
+	if err := httpserver.Serve(server, ln, cfg.TLS); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("HTTP server error: %v", err)
+	}
 
 }
 
@@ -0,0 +1,99 @@
+// Команда catalog-diff - сравнивает каталоги двух окружений перед переносом массовых изменений
+// Пример: go run ./cmd/catalog-diff -left https://staging.example.com -right https://shop.example.com
+// Вместо адреса можно указать файл выгрузки или снимка каталога (.ndjson, .ndjson.gz)
+// Выгрузка доступна только администраторам: токены окружений - в -left-token и -right-token (см. cmd/issue-token)
+// Код выхода 1 - каталоги различаются, 2 - ошибка
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-music-shop/pkg/catalogdiff"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	left := flag.String("left", "", "source catalog: deployment URL or export/snapshot file")
+	right := flag.String("right", "", "target catalog: deployment URL or export/snapshot file")
+	leftToken := flag.String("left-token", "", "admin token for the left deployment")
+	rightToken := flag.String("right-token", "", "admin token for the right deployment")
+	key := flag.String("key", catalogdiff.KeyID, "how to match albums: id or artist-title")
+	ignore := flag.String("ignore", "created_at,updated_at", "comma-separated fields to skip")
+	format := flag.String("format", "text", "output format: text or json")
+	timeout := flag.Duration("timeout", 5*time.Minute, "timeout for fetching both catalogs")
+	flag.Parse()
+
+	log.SetFlags(0)
+	if *left == "" || *right == "" {
+		log.Print("-left and -right are required")
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	client := &http.Client{}
+
+	leftAlbums, err := catalogdiff.Load(ctx, client, *left, *leftToken)
+	if err != nil {
+		log.Printf("loading left catalog error: %v", err)
+		os.Exit(2)
+	}
+	rightAlbums, err := catalogdiff.Load(ctx, client, *right, *rightToken)
+	if err != nil {
+		log.Printf("loading right catalog error: %v", err)
+		os.Exit(2)
+	}
+
+	opts := catalogdiff.Options{Key: *key}
+	if *ignore != "" {
+		opts.Ignore = strings.Split(*ignore, ",")
+	}
+	diff, err := catalogdiff.Compare(leftAlbums, rightAlbums, opts)
+	if err != nil {
+		log.Printf("comparing catalogs error: %v", err)
+		os.Exit(2)
+	}
+	diff.Left, diff.Right = *left, *right
+
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(diff)
+	} else {
+		printText(diff)
+	}
+
+	if !diff.Empty() {
+		os.Exit(1)
+	}
+}
+
+// printText - отчет для чтения глазами
+func printText(diff *catalogdiff.Diff) {
+	fmt.Printf("left:  %s\nright: %s\n", diff.Left, diff.Right)
+	fmt.Printf("missing on the right: %d, only on the right: %d, changed: %d, unchanged: %d\n",
+		len(diff.Missing), len(diff.Extra), len(diff.Changed), diff.Unchanged)
+
+	for _, e := range diff.Missing {
+		fmt.Printf("- %s  %s - %s\n", e.ID, e.Artist, e.Title)
+	}
+	for _, e := range diff.Extra {
+		fmt.Printf("+ %s  %s - %s\n", e.ID, e.Artist, e.Title)
+	}
+	for _, c := range diff.Changed {
+		id := c.ID
+		if c.RightID != "" {
+			id += " -> " + c.RightID
+		}
+		fmt.Printf("~ %s  %s - %s\n", id, c.Artist, c.Title)
+		for _, f := range c.Fields {
+			fmt.Printf("    %s: %s -> %s\n", f.Field, f.Left, f.Right)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"go-music-shop/internal/delivery/catalog"
 	"go-music-shop/internal/config"
+	"go-music-shop/internal/monitoring"
 	"go-music-shop/internal/repository"
 	"go-music-shop/internal/service"
+	"go-music-shop/internal/startup"
+	"go-music-shop/pkg/alert"
+	"go-music-shop/pkg/cdn"
 	"go-music-shop/pkg/database"
+	"go-music-shop/pkg/hooks"
+	"go-music-shop/pkg/listener"
 	"go-music-shop/pkg/redis"
 	"log"
-	"net"
+	"os"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -21,6 +28,11 @@ func main() {
 	// Загружаем конфигурацию
 	cfg := config.Load()
 
+	// Маскирование персональных данных и секретов во всех логах
+	if cfg.LogMasking.Enabled {
+		log.SetOutput(monitoring.NewMasker(cfg.LogMasking.Fields).Writer(os.Stderr))
+	}
+
 	// Подключаемся к PostgreSQL
 	db, err := database.NewPostgresConnection(cfg)
 	if err != nil {
@@ -35,30 +47,63 @@ func main() {
 	}
 	defer redisClient.Close()
 
+	// Проверяем окружение (схема БД, Redis, конфигурация, часы) до приема запросов
+	startup.Run("catalog-service", cfg, db, redisClient)
+
 	// Создаем репозитории
 	postgresRepo := repository.NewPostgresAlbumRepository(db)
-	cachedRepo := repository.NewCachedAlbumRepository(postgresRepo, redisClient)
+	if err := repository.SetIDStrategy(cfg.IDStrategy); err != nil {
+		log.Fatalf("invalid ID strategy: %v", err)
+	}
+	cacheMetrics := monitoring.NewCacheMetrics()
+	cachedRepo := repository.NewCachedAlbumRepository(postgresRepo, redisClient, cacheMetrics)
+	cachedRepo.SetNamespace(cfg.Redis.CacheNamespace)
+	cachedRepo.SetSchemaVersion(cfg.Redis.CacheSchemaVersion)
 
 	//Создаем СЕРВИСНЫЙ СЛОЙ (AlbumService)
-	albumService := service.NewAlbumService(cachedRepo)
+	albumService := service.NewAlbumService(cachedRepo, cdn.NewPurger(cfg))
+	albumService.SetPriceGuard(service.NewPriceGuard(cfg.PriceGuard, repository.NewPostgresAuditRepository(db)))
+	albumService.SetTombstoneReader(repository.NewPostgresSyncRepository(db)) // Повторный DeleteAlbum не считается ошибкой
+	albumService.SetVariantRepository(repository.NewPostgresVariantRepository(db)) // Цена и количество по изданиям
+
+	// Изменения через gRPC тоже должны попадать в индекс подсказок поиска
+	albumService.Subscribe(service.NewSuggestService(redisClient))
+	albumService.Subscribe(hooks.Default) // Хуки кода, встраивающего сервис (hooks.OnAlbumChanged)
 
-	// Создаем gRPC сервер
-	grpcServer := grpc.NewServer()
+	// Мониторинг пулов подключений: предупреждает в логах и чатах команды об исчерпании пулов
+	poolMonitor := monitoring.NewPoolMonitor(db, redisClient, cfg.Monitoring)
+	poolMonitor.SetNotifier(alert.NewNotifier(cfg.Alerts))
+	poolMonitor.Start(context.Background())
+
+	// Создаем gRPC сервер: лимиты размера сообщений, gzip для клиентов, которые его поддерживают,
+	// и роли для изменений каталога (те же, что в REST API)
+	switch {
+	case cfg.Auth.Disabled:
+		log.Println("WARNING: AUTH_DISABLED is set, album changes are not authenticated")
+	case cfg.Auth.JWTSecret == "":
+		log.Fatal("JWT_SECRET is not set (set AUTH_DISABLED=true to run without authentication in development)")
+	}
+	grpcOptions, err := catalog.ServerOptions(cfg.GRPC, cfg.Auth)
+	if err != nil {
+		log.Fatalf("invalid gRPC configuration: %v", err)
+	}
+	grpcServer := grpc.NewServer(grpcOptions...)
 
 	// Регистрируем наш сервис
 	catalogService := catalog.NewCatalogService(albumService)
+	catalogService.SetTrackService(service.NewTrackService(repository.NewPostgresTrackRepository(db)))
 	catalogpb.RegisterCatalogServiceServer(grpcServer, catalogService)
 
 	// Включаем reflection для тестирования (dev only)
 	reflection.Register(grpcServer)
 
-	// Запускаем gRPC сервер
-	lis, err := net.Listen("tcp", ":50051")
+	// Запускаем gRPC сервер (TCP порт, unix socket или сокет от systemd)
+	lis, err := listener.Listen(cfg.Listen, ":50051")
 	if err != nil {
 		log.Fatalf("starting gRPC server error: %v", err)
 	}
 
-	log.Printf("gRPC Catalog Service has been started on port :50051")
+	log.Printf("gRPC Catalog Service has been started on %s", lis.Addr())
 
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("gRPC server error: %v", err)
@@ -0,0 +1,42 @@
+// Команда issue-token - выдает токен доступа (JWT) с ключом из JWT_SECRET
+// Пример: JWT_SECRET=... go run ./cmd/issue-token -sub alice -roles staff
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/pkg/auth"
+	"log"
+	"strings"
+	"time"
+)
+
+func main() {
+	cfg := config.Load()
+
+	subject := flag.String("sub", "", "user id")
+	roles := flag.String("roles", auth.RoleAdmin, "comma-separated roles: admin, staff, customer")
+	ttl := flag.Duration("ttl", time.Duration(cfg.Auth.TokenExpiry)*time.Second, "token lifetime")
+	flag.Parse()
+
+	switch {
+	case cfg.Auth.JWTSecret == "":
+		log.Fatal("JWT_SECRET is not set")
+	case *subject == "":
+		log.Fatal("-sub is required")
+	}
+
+	roleList := strings.Split(*roles, ",")
+	for _, role := range roleList {
+		if role != auth.RoleAdmin && role != auth.RoleStaff && role != auth.RoleCustomer {
+			log.Fatalf("unknown role %q", role)
+		}
+	}
+
+	token, err := auth.Issue([]byte(cfg.Auth.JWTSecret), *subject, roleList, *ttl)
+	if err != nil {
+		log.Fatalf("issuing token error: %v", err)
+	}
+	fmt.Println(token)
+}
@@ -0,0 +1,96 @@
+// Команда seed - заполняет базу детерминированным демо-каталогом
+// Пример: go run ./cmd/seed -albums 5000 -artists 400 -seed 42
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/pkg/database"
+	"go-music-shop/pkg/seed"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	defaults := seed.DefaultConfig()
+
+	seedValue := flag.Uint64("seed", defaults.Seed, "generator seed: the same seed produces the same catalog")
+	albums := flag.Int("albums", defaults.Albums, "number of albums")
+	artists := flag.Int("artists", defaults.Artists, "number of artists")
+	labels := flag.Int("labels", defaults.Labels, "number of record labels (0 - no labels)")
+	genres := flag.String("genres", "", "genre weights, e.g. \"Jazz=60,Bebop=25,Blues=15\" (default: built-in jazz mix)")
+	priceMedian := flag.Float64("price-median", defaults.PriceMedian, "median album price")
+	priceSpread := flag.Float64("price-spread", defaults.PriceSpread, "price spread (sigma of the log-normal distribution)")
+	inStock := flag.Float64("in-stock", defaults.InStock, "share of albums in stock, 0..1")
+	dryRun := flag.Bool("dry-run", false, "print the catalog as NDJSON instead of writing to the database")
+	flag.Parse()
+
+	cfg := seed.Config{
+		Seed:        *seedValue,
+		Albums:      *albums,
+		Artists:     *artists,
+		Labels:      *labels,
+		Genres:      defaults.Genres,
+		PriceMedian: *priceMedian,
+		PriceSpread: *priceSpread,
+		InStock:     *inStock,
+	}
+	if *genres != "" {
+		parsed, err := parseGenres(*genres)
+		if err != nil {
+			log.Fatalf("invalid -genres: %v", err)
+		}
+		cfg.Genres = parsed
+	}
+
+	dataset, err := seed.Generate(cfg)
+	if err != nil {
+		log.Fatalf("generating demo catalog error: %v", err)
+	}
+
+	if *dryRun {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, album := range dataset.Albums {
+			if err := encoder.Encode(album.Album); err != nil {
+				log.Fatalf("writing album error: %v", err)
+			}
+		}
+		return
+	}
+
+	db, err := database.NewPostgresConnection(config.Load())
+	if err != nil {
+		log.Fatalf("could not connect to PostgreSQL: %v", err)
+	}
+	defer db.Close()
+
+	insertedLabels, insertedAlbums, err := seed.Load(context.Background(), db, dataset)
+	if err != nil {
+		log.Fatalf("loading demo catalog error: %v", err)
+	}
+	log.Printf("demo catalog loaded (seed %d): %d/%d labels, %d/%d albums inserted, the rest already existed",
+		cfg.Seed, insertedLabels, len(dataset.Labels), insertedAlbums, len(dataset.Albums))
+}
+
+// parseGenres - разбирает веса жанров из строки "Jazz=60,Bebop=25"
+func parseGenres(value string) (map[string]float64, error) {
+	genres := make(map[string]float64)
+	for _, part := range strings.Split(value, ",") {
+		name, weight, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected genre=weight, got %q", part)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for %q: %w", name, err)
+		}
+		genres[name] = w
+	}
+	return genres, nil
+}
@@ -4,14 +4,199 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config - главная структура конфигурации всего приложения
 // Хранит ВСЕ настройки приложения в одном месте
 type Config struct {
 	ServerPort string
+	PrettyJSON bool // Форматировать JSON ответы с отступами (удобно для отладки, медленнее)
+	StrictJSON bool // Отклонять неизвестные поля в JSON телах запросов (иначе они игнорируются)
+	IDStrategy string // Формат id новых альбомов: "timestamp" или "uuid"
+	HTTPServer HTTPServerConfig
+	TLS TLSConfig
+	Listen ListenConfig
 	DataBase DataBaseConfig
 	Redis RedisConfig
+	HTTPCache HTTPCacheConfig
+	CDN CDNConfig
+	Monitoring MonitoringConfig
+	StatsRefreshInterval int // Как часто пересчитывать статистику каталога, в секундах
+	ViewsFlushInterval int // Как часто сбрасывать счетчики просмотров из Redis в БД, в секундах
+	Startup StartupConfig
+	FX FXConfig
+	StorageDir string // Каталог файлового хранилища (снимки каталога)
+	PriceGuard PriceGuardConfig
+	DebugCapture DebugCaptureConfig
+	Alerts AlertConfig
+	LoadShedding LoadSheddingConfig
+	ReadOnly ReadOnlyConfig
+	ShelfLabels ShelfLabelConfig
+	RateLimit RateLimitConfig
+	FlashSale FlashSaleConfig
+	Quality QualityConfig
+	GRPC GRPCConfig
+	Auth AuthConfig
+	Partner PartnerConfig
+	Import ImportConfig
+	Discogs DiscogsConfig
+	LogMasking LogMaskingConfig
+	Localization LocalizationConfig
+	S3 S3Config
+	Covers CoverConfig
+}
+
+// PartnerConfig - API для партнеров-маркетплейсов (пакетная проверка наличия и цен)
+type PartnerConfig struct {
+	APIKeys []string // Ключи партнеров в виде "имя:ключ"; пусто - API для партнеров выключено
+	RequestsPerSecond int // Средняя частота запросов одного партнера
+	Burst int
+	MaxItems int // Максимум id и штрихкодов в одном запросе
+}
+
+// ImportConfig - загрузка альбомов из таблиц (CSV, XLSX)
+type ImportConfig struct {
+	MaxRows int // Максимум строк в одном файле; размер файла ограничен HTTP_MAX_BODY_BYTES
+}
+
+// DiscogsConfig - поиск в Discogs сведений о пластинках, отсканированных на складе, но неизвестных каталогу
+type DiscogsConfig struct {
+	Token string // Персональный токен Discogs; пусто - неизвестные штрихкоды только копятся в очереди
+	EnrichInterval int // Как часто обрабатывать очередь неизвестных штрихкодов, в секундах
+	EnrichBatch int // Сколько штрихкодов искать за один проход (лимит Discogs - 60 запросов в минуту)
+}
+
+// LogMaskingConfig - маскирование персональных данных и секретов во всех логах и отладочных записях
+// Email, токены (Bearer, JWT) и номера карт маскируются всегда, когда маскирование включено
+type LogMaskingConfig struct {
+	Enabled bool // Включить маскирование (по умолчанию включено)
+	Fields []string // Поля, значения которых скрываются (field=value, "field": "value")
+}
+
+// LocalizationConfig - названия и примечания альбомов на разных языках (японские издания и т.п.)
+type LocalizationConfig struct {
+	DefaultLanguage string // Язык основных названий альбомов: для него переводы не подставляются
+}
+
+// S3Config - объектное хранилище S3 или MinIO для обложек альбомов
+type S3Config struct {
+	Endpoint string // Адрес API, например https://s3.eu-central-1.amazonaws.com или http://minio:9000; пусто - загрузка обложек выключена
+	Region string // Регион для подписи запросов (у MinIO обычно us-east-1)
+	Bucket string
+	AccessKey string
+	SecretKey string
+	PathStyle bool // Адреса вида endpoint/bucket/key (нужно для MinIO)
+	PublicURL string // Публичный адрес бакета или CDN перед ним; пусто - ссылки ведут прямо в бакет
+}
+
+// CoverConfig - загрузка обложек альбомов
+type CoverConfig struct {
+	MaxBytes int64 // Максимальный размер файла обложки; для POST /albums/:id/cover заменяет HTTP_MAX_BODY_BYTES
+}
+
+// AuthConfig - токены доступа (JWT) для изменений каталога
+type AuthConfig struct {
+	JWTSecret string // Ключ подписи токенов (HS256); без него сервисы не запускаются
+	TokenExpiry int // Время жизни выдаваемого токена, в секундах
+	Disabled bool // AUTH_DISABLED=true - запуск без JWT_SECRET и без проверки токенов (только локальная разработка)
+}
+
+// GRPCConfig - настройки gRPC сервера каталога
+type GRPCConfig struct {
+	MaxRecvMsgBytes int // Максимальный размер входящего сообщения
+	MaxSendMsgBytes int // Максимальный размер ответа (полный каталог одним ответом больше 4 МБ)
+	Compression bool // Сжимать ответы gzip для клиентов, которые его поддерживают
+	CompressionLevel int // Уровень gzip: 1 - быстрее, 9 - меньше (-1 - стандартный)
+	CompressionMinBytes int // Ответы меньше этого размера не сжимаются
+}
+
+// QualityConfig - проверка качества карточек каталога
+type QualityConfig struct {
+	MinYear int // Год раньше этого считается опечаткой
+	WeeklyDigest bool // Отправлять еженедельную сводку в чат команды
+}
+
+// RateLimitConfig - ограничение частоты публичных запросов с одного IP
+type RateLimitConfig struct {
+	RequestsPerSecond int // Средняя частота запросов с одного IP (0 - без ограничения)
+	Burst int // Сколько запросов подряд можно сделать сверх средней частоты
+}
+
+// FlashSaleConfig - режим распродажи: на заданное окно времени кэш наличия живет меньше,
+// лимит частоты запросов строже, а популярные альбомы заранее загружаются в кэш
+type FlashSaleConfig struct {
+	StockCacheTTL int // Время жизни кэша альбомов в наличии во время распродажи, в секундах
+	RateLimitRPS int // Лимит запросов в секунду с одного IP во время распродажи
+	RateLimitBurst int
+	PrewarmCount int // Сколько популярных альбомов загрузить в кэш при начале распродажи
+	CheckInterval int // Как часто проверять начало и конец распродажи, в секундах
+	MaxDuration int // Максимальная длительность распродажи, в секундах
+}
+
+// ShelfLabelConfig - оформление ценников на полки (PDF со штрихкодом), у каждого магазина свое
+type ShelfLabelConfig struct {
+	StoreName string // Название магазина на этикетке (пусто - не печатать)
+	WidthMM int // Размер этикетки в миллиметрах, под рулон принтера этикеток
+	HeightMM int
+	ShowCondition bool // Печатать состояние пластинки рядом с ценой
+}
+
+// ReadOnlyConfig - режим только для чтения: дешевые реплики API на edge без прав на запись в БД
+// Отдаются только публичные эндпоинты на чтение, фоновые задачи с записью в БД не запускаются
+type ReadOnlyConfig struct {
+	Enabled bool
+	PrimaryURL string // Адрес основного экземпляра, куда отправлять изменения (в ответе 405)
+	HTTPCacheMaxAge int // max-age публичных ответов в этом режиме, в секундах (кэшируем агрессивнее)
+}
+
+// LoadSheddingConfig - ограничение одновременных запросов с приоритетами при перегрузке
+type LoadSheddingConfig struct {
+	MaxConcurrent int // Сколько запросов обрабатывать одновременно (0 - без ограничения)
+	NormalPercent int // Доля слотов, доступная обычным запросам (просмотр каталога), в процентах
+	BestEffortPercent int // Доля слотов, доступная выгрузкам и отчетам, в процентах
+	RetryAfter int // Значение Retry-After для отклоненных запросов, в секундах
+}
+
+// AlertConfig - операционные оповещения в чаты команды
+type AlertConfig struct {
+	SlackWebhookURL string // Slack Incoming Webhook; пусто - не отправляем в Slack
+	DiscordWebhookURL string // Discord Webhook; пусто - не отправляем в Discord
+	Routes []string // Каналы по типам оповещений ("pool_saturated=slack"); типы без настройки идут во все каналы
+	ThrottleSeconds int // Не повторять оповещение того же типа чаще, чем раз в столько секунд
+}
+
+// DebugCaptureConfig - запись выборки запросов и ответов целиком для отладки
+// Записи хранятся в памяти процесса и доступны по ID запроса через /internal/captures
+type DebugCaptureConfig struct {
+	Enabled bool // Включить запись (по умолчанию выключена)
+	SamplePercent int // Процент записываемых запросов (0 - только с заголовком X-Debug-Capture: 1 и токеном сотрудника)
+	BufferSize int // Сколько последних записей хранить
+	MaxBodyBytes int64 // Максимальный размер сохраняемого тела; более длинные тела не сохраняются
+	RedactFields []string // Поля JSON и параметры запроса, значения которых скрываются (персональные данные)
+	RedactHeaders []string // Заголовки, значения которых скрываются (секреты)
+}
+
+// PriceGuardConfig - проверки подозрительных изменений цены (защита от опечаток)
+// Такие изменения отклоняются, если не подтверждены явно
+type PriceGuardConfig struct {
+	MaxChangePercent int // Максимальное изменение цены за одну правку, в процентах (0 - не проверять)
+	MaxPrice int // Цена, выше которой нужно подтверждение (0 - не проверять)
+}
+
+// FXConfig - настройки курсов валют для показа цен в валюте покупателя
+type FXConfig struct {
+	Provider string // Источник курсов: "ecb", "exchangeratehost"; пусто - конвертация отключена
+	APIKey string // Ключ API провайдера (exchangerate.host)
+	BaseCurrency string // Валюта, в которой хранятся цены каталога
+	RefreshInterval int // Как часто обновлять курсы, в секундах
+	MaxStaleness int // Возраст курсов, после которого цены отдаются в базовой валюте, в секундах
+}
+
+// StartupConfig - настройки проверки окружения при запуске
+type StartupConfig struct {
+	MaxClockSkew int // Допустимое расхождение часов с БД, в секундах
+	IgnoreFailures bool // Запускаться даже при критических ошибках проверки (аварийный флаг)
 }
 
 // DatabaseConfig - структура для настроек конкретно базы данных
@@ -32,6 +217,63 @@ type RedisConfig struct {
 	DB int // Номер базы данных Redis (0-15)
 	// TTL - Time To Live (время жизни кэша в секундах)
 	DefaultTTL int // Стандартное время жизни кэшированных данных
+	CacheNamespace string // Префикс ключей кэша каталога (отдельный для каждого магазина); пусто - без префикса
+	CacheSchemaVersion string // Версия записей кэша от деплоя; смена (например, хуком деплоя) безопасно сбрасывает весь кэш
+	CacheMaxBytes int64 // Лимит памяти кэша каталога в пространстве имен; при превышении кэш сбрасывается (0 - без лимита)
+	CacheQuotaCheckInterval int // Как часто оценивать память кэша, в секундах (0 - не оценивать и не ограничивать)
+	CacheReconcileInterval int // Как часто сверять выборку ключей кэша с БД, в секундах (0 - не сверять)
+	CacheReconcileSample int // Сколько ключей проверять за одну сверку
+	CacheReconcileHeal bool // Удалять ключи, расходящиеся с БД
+}
+
+// HTTPServerConfig - настройки защиты HTTP сервера
+type HTTPServerConfig struct {
+	TrustedProxies []string // CIDR/IP балансировщиков, которым доверяем X-Forwarded-For (пусто - никому)
+	MaxBodyBytes int64 // Максимальный размер тела запроса в байтах
+	ReadHeaderTimeout int // Таймаут чтения заголовков, в секундах
+	ReadTimeout int // Таймаут чтения всего запроса, в секундах
+	WriteTimeout int // Таймаут записи ответа, в секундах
+	IdleTimeout int // Сколько держать keep-alive соединение без запросов, в секундах
+	MaxHeaderBytes int // Максимальный размер заголовков запроса в байтах
+}
+
+// ListenConfig - на чем слушать входящие соединения (HTTP и gRPC)
+// По умолчанию - TCP порт; для работы за локальным прокси можно использовать unix socket
+// или сокет, открытый systemd (socket activation)
+type ListenConfig struct {
+	UnixSocket string // Путь к unix socket
+	UnixSocketMode uint32 // Права на файл сокета
+	SystemdActivation bool // Использовать сокет, переданный systemd
+}
+
+// TLSConfig - настройки HTTPS для небольших инсталляций без отдельного прокси
+// Включается либо файлами сертификата, либо автоматическим получением через Let's Encrypt
+type TLSConfig struct {
+	CertFile string // Путь к сертификату (PEM)
+	KeyFile string // Путь к приватному ключу (PEM)
+	AutocertDomains []string // Домены для автоматических сертификатов Let's Encrypt
+	AutocertCacheDir string // Каталог для хранения полученных сертификатов
+	RedirectPort string // Порт HTTP, с которого перенаправляем на HTTPS (пусто - не слушаем)
+}
+
+// HTTPCacheConfig - настройки HTTP кэширования публичных ответов (браузер и CDN)
+type HTTPCacheConfig struct {
+	MaxAge int // max-age в секундах для браузеров
+	StaleWhileRevalidate int // Сколько секунд можно отдавать устаревший ответ, пока CDN обновляет его
+	SurrogateMaxAge int // Время жизни ответа в CDN (Surrogate-Control), в секундах
+}
+
+// CDNConfig - настройки инвалидации кэша CDN (Fastly/Cloudflare)
+type CDNConfig struct {
+	PurgeURL string // URL API очистки кэша; если пустой - очистка отключена
+	APIToken string // Токен для API CDN
+	PublicBaseURL string // Публичный адрес магазина, например https://shop.example.com
+}
+
+// MonitoringConfig - настройки мониторинга пулов подключений
+type MonitoringConfig struct {
+	PoolCheckInterval int // Как часто проверять пулы, в секундах
+	PoolWaitThresholdMs int // Суммарное ожидание подключения за интервал, после которого пишем предупреждение (мс)
 }
 
 // Load - главная функция которая загружает всю конфигурацию
@@ -41,6 +283,19 @@ func Load() *Config {
 		// Загружаем порт сервера из переменных окружения
         // Если переменной нет - используем "8080" по умолчанию
 		ServerPort: getEnv("SERVER_PORT", "8080"),
+		PrettyJSON: getEnvAsBool("PRETTY_JSON", false),
+		StrictJSON: getEnvAsBool("JSON_STRICT_BINDING", false),
+		IDStrategy: getEnv("ID_STRATEGY", "timestamp"),
+
+		HTTPServer: HTTPServerConfig{
+			TrustedProxies: getEnvAsSlice("HTTP_TRUSTED_PROXIES", nil),
+			MaxBodyBytes: int64(getEnvAsInt("HTTP_MAX_BODY_BYTES", 1<<20)), // 1 МБ
+			ReadHeaderTimeout: getEnvAsInt("HTTP_READ_HEADER_TIMEOUT", 5),
+			ReadTimeout: getEnvAsInt("HTTP_READ_TIMEOUT", 15),
+			WriteTimeout: getEnvAsInt("HTTP_WRITE_TIMEOUT", 30),
+			IdleTimeout: getEnvAsInt("HTTP_IDLE_TIMEOUT", 120),
+			MaxHeaderBytes: getEnvAsInt("HTTP_MAX_HEADER_BYTES", 1<<20), // 1 МБ
+		},
 
 		// Инициализируем настройки базы данных
 		DataBase: DataBaseConfig{
@@ -59,6 +314,180 @@ func Load() *Config {
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB: getEnvAsInt("REDIS_DB", 0),
 			DefaultTTL: getEnvAsInt("REDIS_DEFAULT_TTL", 300), // 5 минут по умолчанию
+			CacheNamespace: getEnv("REDIS_CACHE_NAMESPACE", ""),
+			CacheSchemaVersion: getEnv("REDIS_CACHE_SCHEMA_VERSION", ""),
+			CacheMaxBytes: int64(getEnvAsInt("REDIS_CACHE_MAX_BYTES", 0)),
+			CacheQuotaCheckInterval: getEnvAsInt("REDIS_CACHE_QUOTA_CHECK_INTERVAL", 60),
+			CacheReconcileInterval: getEnvAsInt("REDIS_CACHE_RECONCILE_INTERVAL", 300),
+			CacheReconcileSample: getEnvAsInt("REDIS_CACHE_RECONCILE_SAMPLE", 100),
+			CacheReconcileHeal: getEnvAsBool("REDIS_CACHE_RECONCILE_HEAL", false),
+		},
+
+		Listen: ListenConfig{
+			UnixSocket: getEnv("LISTEN_UNIX_SOCKET", ""),
+			UnixSocketMode: getEnvAsFileMode("LISTEN_UNIX_SOCKET_MODE", 0660), // Восьмеричное, как у chmod: 0660 или 660
+			SystemdActivation: getEnvAsBool("LISTEN_SYSTEMD", false),
+		},
+
+		TLS: TLSConfig{
+			CertFile: getEnv("TLS_CERT_FILE", ""),
+			KeyFile: getEnv("TLS_KEY_FILE", ""),
+			AutocertDomains: getEnvAsSlice("TLS_AUTOCERT_DOMAINS", nil),
+			AutocertCacheDir: getEnv("TLS_AUTOCERT_CACHE_DIR", "certs"),
+			RedirectPort: getEnv("TLS_REDIRECT_PORT", ""),
+		},
+
+		HTTPCache: HTTPCacheConfig{
+			MaxAge: getEnvAsInt("HTTP_CACHE_MAX_AGE", 30),
+			StaleWhileRevalidate: getEnvAsInt("HTTP_CACHE_STALE_WHILE_REVALIDATE", 60),
+			SurrogateMaxAge: getEnvAsInt("HTTP_CACHE_SURROGATE_MAX_AGE", 300),
+		},
+
+		CDN: CDNConfig{
+			PurgeURL: getEnv("CDN_PURGE_URL", ""),
+			APIToken: getEnv("CDN_API_TOKEN", ""),
+			PublicBaseURL: getEnv("CDN_PUBLIC_BASE_URL", ""),
+		},
+
+		StatsRefreshInterval: getEnvAsInterval("STATS_REFRESH_INTERVAL", 300), // 5 минут по умолчанию
+		ViewsFlushInterval: getEnvAsInterval("VIEWS_FLUSH_INTERVAL", 60),
+
+		FX: FXConfig{
+			Provider: getEnv("FX_PROVIDER", ""),
+			APIKey: getEnv("FX_API_KEY", ""),
+			BaseCurrency: strings.ToUpper(getEnv("FX_BASE_CURRENCY", "USD")),
+			RefreshInterval: getEnvAsInterval("FX_REFRESH_INTERVAL", 3600), // 1 час
+			MaxStaleness: getEnvAsInt("FX_MAX_STALENESS", 86400), // 1 сутки
+		},
+
+		StorageDir: getEnv("STORAGE_DIR", "./data/storage"),
+
+		ShelfLabels: ShelfLabelConfig{
+			StoreName: getEnv("SHELF_LABEL_STORE_NAME", ""),
+			WidthMM: getEnvAsInt("SHELF_LABEL_WIDTH_MM", 70), // Длинные id (UUID) на более узкой этикетке сканер читает плохо
+			HeightMM: getEnvAsInt("SHELF_LABEL_HEIGHT_MM", 40),
+			ShowCondition: getEnvAsBool("SHELF_LABEL_SHOW_CONDITION", true),
+		},
+
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: getEnvAsInt("RATE_LIMIT_RPS", 0),
+			Burst: getEnvAsInt("RATE_LIMIT_BURST", 20),
+		},
+
+		FlashSale: FlashSaleConfig{
+			StockCacheTTL: getEnvAsInt("FLASH_SALE_STOCK_CACHE_TTL", 5),
+			RateLimitRPS: getEnvAsInt("FLASH_SALE_RATE_LIMIT_RPS", 5),
+			RateLimitBurst: getEnvAsInt("FLASH_SALE_RATE_LIMIT_BURST", 10),
+			PrewarmCount: getEnvAsInt("FLASH_SALE_PREWARM_COUNT", 50),
+			CheckInterval: getEnvAsInterval("FLASH_SALE_CHECK_INTERVAL", 5),
+			MaxDuration: getEnvAsInt("FLASH_SALE_MAX_DURATION", 86400), // 1 сутки
+		},
+
+		Auth: AuthConfig{
+			JWTSecret: getEnv("JWT_SECRET", ""),
+			TokenExpiry: getEnvAsInt("JWT_TOKEN_EXPIRY", 3600), // 1 час
+			Disabled: getEnvAsBool("AUTH_DISABLED", false),
+		},
+
+		Partner: PartnerConfig{
+			APIKeys: getEnvAsSlice("PARTNER_API_KEYS", nil),
+			RequestsPerSecond: getEnvAsInt("PARTNER_RATE_LIMIT_RPS", 2),
+			Burst: getEnvAsInt("PARTNER_RATE_LIMIT_BURST", 5),
+			MaxItems: getEnvAsInt("PARTNER_MAX_ITEMS", 500),
+		},
+
+		Import: ImportConfig{
+			MaxRows: getEnvAsInt("IMPORT_MAX_ROWS", 5000),
+		},
+
+		Discogs: DiscogsConfig{
+			Token: getEnv("DISCOGS_TOKEN", ""),
+			EnrichInterval: getEnvAsInterval("DISCOGS_ENRICH_INTERVAL", 60),
+			EnrichBatch: getEnvAsInt("DISCOGS_ENRICH_BATCH", 20),
+		},
+
+		LogMasking: LogMaskingConfig{
+			Enabled: getEnvAsBool("LOG_MASKING_ENABLED", true),
+			Fields: getEnvAsSlice("LOG_MASK_FIELDS",
+				[]string{"password", "token", "access_token", "refresh_token", "secret", "api_key", "authorization",
+					"email", "phone", "address", "card_number", "cvv", "cvc"}),
+		},
+
+		Localization: LocalizationConfig{
+			DefaultLanguage: getEnv("LOCALIZATION_DEFAULT_LANGUAGE", "en"),
+		},
+
+		S3: S3Config{
+			Endpoint: getEnv("S3_ENDPOINT", ""),
+			Region: getEnv("S3_REGION", "us-east-1"),
+			Bucket: getEnv("S3_BUCKET", ""),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+			PathStyle: getEnvAsBool("S3_PATH_STYLE", false),
+			PublicURL: getEnv("S3_PUBLIC_URL", ""),
+		},
+
+		Covers: CoverConfig{
+			MaxBytes: int64(getEnvAsInt("COVER_MAX_BYTES", 5<<20)), // 5 МБ
+		},
+
+		GRPC: GRPCConfig{
+			MaxRecvMsgBytes: getEnvAsInt("GRPC_MAX_RECV_MSG_BYTES", 4<<20), // 4 МБ
+			MaxSendMsgBytes: getEnvAsInt("GRPC_MAX_SEND_MSG_BYTES", 64<<20), // 64 МБ
+			Compression: getEnvAsBool("GRPC_COMPRESSION", true),
+			CompressionLevel: getEnvAsInt("GRPC_COMPRESSION_LEVEL", 1),
+			CompressionMinBytes: getEnvAsInt("GRPC_COMPRESSION_MIN_BYTES", 1024),
+		},
+
+		Quality: QualityConfig{
+			MinYear: getEnvAsInt("QUALITY_MIN_YEAR", 1900),
+			WeeklyDigest: getEnvAsBool("QUALITY_WEEKLY_DIGEST", false),
+		},
+
+		PriceGuard: PriceGuardConfig{
+			MaxChangePercent: getEnvAsInt("PRICE_MAX_CHANGE_PERCENT", 50),
+			MaxPrice: getEnvAsInt("PRICE_MAX", 1000),
+		},
+
+		DebugCapture: DebugCaptureConfig{
+			Enabled: getEnvAsBool("DEBUG_CAPTURE_ENABLED", false),
+			SamplePercent: getEnvAsInt("DEBUG_CAPTURE_SAMPLE_PERCENT", 0),
+			BufferSize: getEnvAsInt("DEBUG_CAPTURE_BUFFER_SIZE", 500),
+			MaxBodyBytes: int64(getEnvAsInt("DEBUG_CAPTURE_MAX_BODY_BYTES", 64<<10)), // 64 КБ
+			RedactFields: getEnvAsSlice("DEBUG_CAPTURE_REDACT_FIELDS",
+				[]string{"password", "token", "email", "phone", "address", "card_number", "api_key"}),
+			RedactHeaders: getEnvAsSlice("DEBUG_CAPTURE_REDACT_HEADERS",
+				[]string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}),
+		},
+
+		ReadOnly: ReadOnlyConfig{
+			Enabled: getEnvAsBool("READ_ONLY", false),
+			PrimaryURL: getEnv("READ_ONLY_PRIMARY_URL", ""),
+			HTTPCacheMaxAge: getEnvAsInt("READ_ONLY_HTTP_CACHE_MAX_AGE", 300),
+		},
+
+		LoadShedding: LoadSheddingConfig{
+			MaxConcurrent: getEnvAsInt("LOAD_SHEDDING_MAX_CONCURRENT", 0),
+			NormalPercent: getEnvAsInt("LOAD_SHEDDING_NORMAL_PERCENT", 80),
+			BestEffortPercent: getEnvAsInt("LOAD_SHEDDING_BEST_EFFORT_PERCENT", 20),
+			RetryAfter: getEnvAsInt("LOAD_SHEDDING_RETRY_AFTER", 5),
+		},
+
+		Alerts: AlertConfig{
+			SlackWebhookURL: getEnv("ALERT_SLACK_WEBHOOK_URL", ""),
+			DiscordWebhookURL: getEnv("ALERT_DISCORD_WEBHOOK_URL", ""),
+			Routes: getEnvAsSlice("ALERT_ROUTES", nil),
+			ThrottleSeconds: getEnvAsInt("ALERT_THROTTLE_SECONDS", 900), // 15 минут
+		},
+
+		Startup: StartupConfig{
+			MaxClockSkew: getEnvAsInt("STARTUP_MAX_CLOCK_SKEW", 5),
+			IgnoreFailures: getEnvAsBool("STARTUP_IGNORE_FAILURES", false),
+		},
+
+		Monitoring: MonitoringConfig{
+			PoolCheckInterval: getEnvAsInterval("POOL_CHECK_INTERVAL", 15),
+			PoolWaitThresholdMs: getEnvAsInt("POOL_WAIT_THRESHOLD_MS", 500),
 		},
 	}
 }
@@ -81,4 +510,49 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 }
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvAsInterval - период фоновой задачи в секундах; ноль и отрицательные значения
+// заменяются значением по умолчанию (time.NewTicker паникует на неположительном периоде)
+func getEnvAsInterval(key string, defaultValue int) int {
+	if interval := getEnvAsInt(key, defaultValue); interval > 0 {
+		return interval
+	}
+	return defaultValue
+}
+
+// getEnvAsFileMode - права на файл в восьмеричной записи, как у chmod ("0660", "660")
+func getEnvAsFileMode(key string, defaultValue uint32) uint32 {
+	if value := os.Getenv(key); value != "" {
+		if mode, err := strconv.ParseUint(value, 8, 32); err == nil {
+			return uint32(mode)
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsBool - аналогично getEnv, но преобразует значение в bool ("true", "1", "false", "0")
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsSlice - читает список значений, разделенных запятыми ("10.0.0.0/8,192.168.0.1")
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/auth"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodPermissions - какие методы требуют разрешения; остальные (чтение) доступны всем
+// Те же разрешения, что у соответствующих REST маршрутов
+var methodPermissions = map[string]service.Permission{
+	"/catalog.CatalogService/CreateAlbum": service.PermCatalogWrite,
+	"/catalog.CatalogService/UpdateAlbum": service.PermCatalogWrite,
+	"/catalog.CatalogService/DeleteAlbum": service.PermCatalogWrite,
+}
+
+// authorize - проверяет токен из метаданных authorization: Bearer <token> для методов с изменениями
+// Как и в REST API, проверку отключает только AUTH_DISABLED; без ключа подписи изменения отклоняются
+func authorize(cfg config.AuthConfig) grpc.UnaryServerInterceptor {
+	key := []byte(cfg.JWTSecret)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		perm, ok := methodPermissions[info.FullMethod]
+		if !ok || cfg.Disabled {
+			return handler(ctx, req)
+		}
+		if cfg.JWTSecret == "" {
+			return nil, status.Error(codes.Unavailable, "authentication is not configured")
+		}
+
+		var claims *auth.Claims
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, value := range md.Get("authorization") {
+				token, ok := strings.CutPrefix(value, "Bearer ")
+				if !ok {
+					continue
+				}
+				verified, err := auth.Verify(key, strings.TrimSpace(token))
+				if err != nil {
+					return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+				}
+				claims = verified
+				break
+			}
+		}
+
+		err := service.Authorize(claims, perm)
+		switch {
+		case errors.Is(err, service.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case err != nil:
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return handler(auth.NewContext(ctx, claims), req)
+	}
+}
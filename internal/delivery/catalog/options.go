@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"go-music-shop/internal/config"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip" // Регистрирует gzip: входящие сжатые запросы принимаются всегда
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerOptions - настройки gRPC сервера каталога: лимиты размера сообщений, сжатие ответов
+// и проверка токенов для изменений каталога
+// Полный каталог одним ответом GetAlbums больше стандартного лимита в 4 МБ
+func ServerOptions(cfg config.GRPCConfig, authCfg config.AuthConfig) ([]grpc.ServerOption, error) {
+	interceptors := []grpc.UnaryServerInterceptor{authorize(authCfg)}
+
+	if cfg.Compression {
+		if err := gzip.SetLevel(cfg.CompressionLevel); err != nil {
+			return nil, fmt.Errorf("invalid GRPC_COMPRESSION_LEVEL: %w", err)
+		}
+		interceptors = append(interceptors, compressResponses(cfg.CompressionMinBytes))
+	}
+
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgBytes),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgBytes),
+		grpc.ChainUnaryInterceptor(interceptors...),
+	}, nil
+}
+
+// compressResponses - сжимает gzip ответы от minBytes и больше, если клиент объявил поддержку gzip
+// Маленькие ответы отдаются как есть: на них сжатие тратит процессор без заметной выгоды
+func compressResponses(minBytes int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		msg, ok := resp.(proto.Message)
+		if !ok || proto.Size(msg) < minBytes {
+			return resp, nil
+		}
+
+		supported, err := grpc.ClientSupportedCompressors(ctx)
+		if err == nil && slices.Contains(supported, gzip.Name) {
+			// Ответ еще не отправлен - компрессор можно выбрать после обработчика
+			_ = grpc.SetSendCompressor(ctx, gzip.Name)
+		}
+		return resp, nil
+	}
+}
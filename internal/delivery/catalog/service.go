@@ -2,11 +2,15 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go-music-shop/internal/delivery/protoconv"
 	"go-music-shop/internal/domain/models"
 	"go-music-shop/internal/service"
 	"log"
-	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	// Импортируем сгенерированный protobuf код
 	catalogpb "go-music-shop/pkg/gen/catalog"
@@ -16,6 +20,7 @@ import (
 type CatalogService struct {
 	catalogpb.UnimplementedCatalogServiceServer
 	albumService *service.AlbumService
+	trackService *service.TrackService // nil - include_tracks не поддерживается
 }
 
 // NewCatalogService создает новый экземпляр CatalogService
@@ -25,46 +30,54 @@ func NewCatalogService(albumService *service.AlbumService) *CatalogService {
 	}
 }
 
+// SetTrackService включает include_tracks в GetAlbumByID
+func (s *CatalogService) SetTrackService(trackService *service.TrackService) {
+	s.trackService = trackService
+}
+
 // GetAlbums возвращает все альбомы (с пагинацией)
 func (s *CatalogService) GetAlbums(ctx context.Context, req *catalogpb.GetAlbumsRequest) (*catalogpb.GetAlbumsResponse, error) {
-	log.Printf("gRPC GetAlbums has been called: limit=%d, offset=%d", req.GetLimit(), req.GetOffset())
+	log.Printf("gRPC GetAlbums has been called: limit=%d, offset=%d, sort=%q", req.GetLimit(), req.GetOffset(), req.GetSort())
 
-	// Получаем все альбомы из репозитория
-	albums, err := s.albumService.GetAllAlbums()
+	sort, err := service.ParseAlbumSort(req.GetSort())
 	if err != nil {
-		return nil, fmt.Errorf("could not get albums %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "could not get albums: %v", err)
 	}
 
-	// Применяем пагинацию
-	start := int(req.GetOffset())
-	if start < 0 {
-		start = 0
+	// Если limit не указан, возвращаем все (сортировка и курсор поддерживаются только для страниц)
+	if req.GetLimit() == 0 && (sort != nil || req.GetCursor() != "") {
+		return nil, status.Error(codes.InvalidArgument, "limit is required when sort or cursor is set")
 	}
-	if start > len(albums) {
-		start = len(albums)
+	if req.GetLimit() == 0 {
+		albums, err := s.albumService.GetAllAlbums()
+		if err != nil {
+			return nil, fmt.Errorf("could not get albums %v", err)
+		}
+		return &catalogpb.GetAlbumsResponse{
+			Albums:     protoconv.AlbumsToProto(albums),
+			TotalCount: int32(len(albums)),
+		}, nil
 	}
 
-	end := start + int(req.GetLimit())
-	if end > len(albums) {
-		end = len(albums)
+	// Пагинация выполняется в базе (LIMIT/OFFSET), весь каталог в память не читаем
+	page, err := s.albumService.GetAlbumsPage(domain.AlbumFilter{Sort: sort}, req.GetCursor(),
+		int(req.GetLimit()), max(int(req.GetOffset()), 0))
+	if errors.Is(err, service.ErrInvalidAlbumFilter) {
+		return nil, status.Errorf(codes.InvalidArgument, "could not get albums: %v", err)
 	}
-	if req.GetLimit() == 0 {
-		end = len(albums) // Если limit не указан, возвращаем все
+	if err != nil {
+		return nil, fmt.Errorf("could not get albums %v", err)
 	}
 
-	paginatedAlbums := albums[start:end]
-
 	// Конвертируем domain альбомы в protobuf альбомы
-	pbAlbums := make([]*catalogpb.Album, len(paginatedAlbums))
-	for i := range paginatedAlbums {
-		pbAlbums[i] = s.domainToProtoAlbum(&paginatedAlbums[i])
-	}
+	pbAlbums := protoconv.AlbumsToProto(page.Albums)
 
-	log.Printf("%d albums had been returned (all: %d)", len(pbAlbums), len(albums))
+	log.Printf("%d albums had been returned (all: %d)", len(pbAlbums), page.Total)
 
 	return &catalogpb.GetAlbumsResponse{
 		Albums:     pbAlbums,
-		TotalCount: int32(len(albums)),
+		TotalCount: int32(page.Total),
+		NextCursor: page.NextCursor,
 	}, nil
 }
 
@@ -80,8 +93,20 @@ func (s *CatalogService) GetAlbumByID(ctx context.Context, req *catalogpb.GetAlb
 
 	log.Printf("album was found: %s - %s", album.Artist, album.Title)
 
+	pbAlbum := protoconv.AlbumToProto(album)
+	if req.GetIncludeTracks() {
+		if s.trackService == nil {
+			return nil, status.Error(codes.Unimplemented, "tracks are not available")
+		}
+		tracks, err := s.trackService.GetTracks(album.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get tracks: %w", err)
+		}
+		pbAlbum.Tracks = protoconv.TracksToProto(tracks)
+	}
+
 	return &catalogpb.GetAlbumByIDResponse{
-		Album: s.domainToProtoAlbum(album),
+		Album: pbAlbum,
 	}, nil
 
 }
@@ -98,17 +123,28 @@ func (s *CatalogService) CreateAlbum(ctx context.Context, req *catalogpb.CreateA
 		Year:      int(req.GetYear()),
 		Genre:     req.GetGenre(),
 		Condition: req.GetCondition(),
-		InStock:   req.GetInStock(),
+		StockQuantity: int(req.GetStockQuantity()),
+		CatalogNumber: req.GetCatalogNumber(),
+		Description: req.GetDescription(),
 	}
 
-	if err := s.albumService.CreateAlbum(album); err != nil {
+	opts := []service.WriteOption{service.ConfirmPriceChange(req.GetConfirmPriceChange())}
+	if req.StockQuantity == nil {
+		inStock := req.GetInStock()
+		opts = append(opts, service.LegacyStock(&inStock)) // Старый клиент: только флаг наличия
+	}
+
+	if err := s.albumService.CreateAlbum(album, opts...); err != nil {
+		if errors.Is(err, service.ErrPriceConfirmationRequired) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, fmt.Errorf("could not create album: %w", err)
 	}
 
 	log.Printf("album has been created: ID=%s", album.ID)
 
 	return &catalogpb.CreateAlbumResponse{
-		Album: s.domainToProtoAlbum(album),
+		Album: protoconv.AlbumToProto(album),
 	}, nil
 }
 
@@ -125,17 +161,34 @@ func (s *CatalogService) UpdateAlbum(ctx context.Context, req *catalogpb.UpdateA
 		Year:      int(req.GetYear()),
 		Genre:     req.GetGenre(),
 		Condition: req.GetCondition(),
-		InStock:   req.GetInStock(),
+		StockQuantity: int(req.GetStockQuantity()),
+		CatalogNumber: req.GetCatalogNumber(),
+		Description: req.GetDescription(),
+	}
+
+	opts := []service.WriteOption{service.ConfirmPriceChange(req.GetConfirmPriceChange())}
+	if req.StockQuantity == nil {
+		inStock := req.GetInStock()
+		opts = append(opts, service.LegacyStock(&inStock)) // Старый клиент: только флаг наличия
+	}
+	if req.CatalogNumber == nil {
+		opts = append(opts, service.KeepCatalogNumber())
+	}
+	if req.Description == nil {
+		opts = append(opts, service.KeepDescription())
 	}
 
-	if err := s.albumService.UpdateAlbum(album); err != nil {
+	if err := s.albumService.UpdateAlbum(album, opts...); err != nil {
+		if errors.Is(err, service.ErrPriceConfirmationRequired) || errors.Is(err, domain.ErrVariantRequired) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, fmt.Errorf("could not update album: %w", err)
 	}
 
 	log.Printf("album has been updated: ID=%s", album.ID)
 
 	return &catalogpb.UpdateAlbumResponse{
-		Album: s.domainToProtoAlbum(album),
+		Album: protoconv.AlbumToProto(album),
 	}, nil
 }
 
@@ -145,6 +198,15 @@ func (s *CatalogService) DeleteAlbum(ctx context.Context, req *catalogpb.DeleteA
 	log.Printf("gRPC DeleteAlbum has been called: id=%s", id)
 
 	if err := s.albumService.DeleteAlbum(id); err != nil {
+		// Повторное удаление считается успешным, как и в HTTP API
+		var deleted *service.AlbumDeletedError
+		if errors.As(err, &deleted) {
+			log.Printf("album was already deleted: ID=%s", id)
+			return &catalogpb.DeleteAlbumResponse{
+				Success: true,
+				Message: deleted.Error(),
+			}, nil
+		}
 		return nil, fmt.Errorf("could not delete album: %w", err)
 	}
 
@@ -169,10 +231,7 @@ func (s *CatalogService) SearchAlbumsByArtist(ctx context.Context, req *catalogp
 	log.Printf("albums has been searched: artist=%s", artist)
 
 	// Конвертируем domain альбомы в protobuf альбомы
-	pbAlbums := make([]*catalogpb.Album, len(albums))
-	for i, album := range albums {
-		pbAlbums[i] = s.domainToProtoAlbum(&album)
-	}
+	pbAlbums := protoconv.AlbumsToProto(albums)
 
 	return &catalogpb.SearchAlbumsByArtistResponse{
 		Albums: pbAlbums,
@@ -192,28 +251,9 @@ func (s *CatalogService) GetAlbumsInStock(ctx context.Context, req *catalogpb.Ge
 	log.Printf("albums in stock has been searched")
 
 	// Конвертируем domain альбомы в protobuf альбомы
-	pbAlbums := make([]*catalogpb.Album, len(albums))
-	for i, album := range albums {
-		pbAlbums[i] = s.domainToProtoAlbum(&album)
-	}
+	pbAlbums := protoconv.AlbumsToProto(albums)
 
 	return &catalogpb.GetAlbumsInStockResponse{
 		Albums: pbAlbums,
 	}, nil
 }
-
-// domainToProtoAlbum конвертирует domain.Album в catalogpb.Album
-func (s *CatalogService) domainToProtoAlbum(album *domain.Album) *catalogpb.Album {
-	return &catalogpb.Album{
-		Id:        album.ID,
-		Title:     album.Title,
-		Artist:    album.Artist,
-		Price:     album.Price,
-		Year:      int32(album.Year),
-		Genre:     album.Genre,
-		Condition: album.Condition,
-		InStock:   album.InStock,
-		CreatedAt: album.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: album.UpdatedAt.Format(time.RFC3339),
-	}
-}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// albumArchiveParams - параметры фильтра массового архивирования: те же, что у списка альбомов, без страницы и сортировки
+var albumArchiveParams = []string{"genre", "condition", "year_from", "year_to", "price_min", "price_max", "in_stock"}
+
+// archiveAlbumsRequest - подтверждение массового архивирования
+type archiveAlbumsRequest struct {
+	ExpectedCount *int   `json:"expected_count"` // Сколько альбомов клиент ожидает заархивировать (обязательно)
+	Reason        string `json:"reason"`         // Причина для журнала аудита
+}
+
+// ArchiveAlbums - архивирует альбомы под фильтром из параметров запроса (?genre=Hard+Bop&in_stock=false)
+// В теле обязательно expected_count: если под фильтр подходит другое количество альбомов
+// (каталог изменился после просмотра списка), ничего не архивируется и возвращается 409 с фактическим количеством
+func (h *AlbumHandler) ArchiveAlbums(c *gin.Context) {
+	for name := range c.Request.URL.Query() {
+		if !slices.Contains(albumArchiveParams, name) {
+			writeJSON(c, http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("unknown query parameter %q", name),
+				"hint":  "supported parameters: " + strings.Join(albumArchiveParams, ", "),
+			})
+			return
+		}
+	}
+
+	filter, err := parseAlbumFilter(c)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req archiveAlbumsRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	if req.ExpectedCount == nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "expected_count is required"})
+		return
+	}
+
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	albums, err := h.albumService.ArchiveAlbums(filter, *req.ExpectedCount, strings.TrimSpace(req.Reason), actor)
+	if err != nil {
+		writeArchiveError(c, err)
+		return
+	}
+
+	ids := make([]string, len(albums))
+	for i, album := range albums {
+		ids[i] = album.ID
+	}
+	writeJSON(c, http.StatusOK, gin.H{"archived": len(ids), "ids": ids})
+}
+
+// writeArchiveError - ответ на ошибку массового архивирования
+func writeArchiveError(c *gin.Context, err error) {
+	var mismatch *domain.ArchiveCountMismatchError
+	switch {
+	case errors.As(err, &mismatch):
+		writeJSON(c, http.StatusConflict, gin.H{
+			"error":          err.Error(),
+			"expected_count": mismatch.Expected,
+			"matched_count":  mismatch.Matched,
+		})
+	case errors.Is(err, service.ErrInvalidAlbumFilter), errors.Is(err, service.ErrInvalidArchive):
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -2,82 +2,428 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/delivery/middleware"
 	"go-music-shop/internal/domain/models"
 	"go-music-shop/internal/service"
+	"log"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type AlbumHandler struct {
 	albumService *service.AlbumService
+	fxService    *service.FXService // Пересчет цен в валюту покупателя
+	trackService *service.TrackService // Треки для ?include=tracks (nil - недоступны)
+	localizationService *service.LocalizationService // Названия на языке из Accept-Language (nil - только основные)
 }
 
 // NewAlbumHandler - конструктор обработчика
-func NewAlbumHandler(albumService *service.AlbumService) *AlbumHandler {
-	return &AlbumHandler{albumService: albumService}
+func NewAlbumHandler(albumService *service.AlbumService, fxService *service.FXService) *AlbumHandler {
+	return &AlbumHandler{albumService: albumService, fxService: fxService}
+}
+
+// SetTrackService - включает ?include=tracks для GET /albums/:id
+func (h *AlbumHandler) SetTrackService(trackService *service.TrackService) {
+	h.trackService = trackService
+}
+
+// SetLocalizationService - включает названия альбомов на языке из Accept-Language
+func (h *AlbumHandler) SetLocalizationService(localizationService *service.LocalizationService) {
+	h.localizationService = localizationService
+}
+
+// requestedLanguages - языки покупателя, для которых нужны переводы (nil - отдаем основные названия)
+func (h *AlbumHandler) requestedLanguages(c *gin.Context) []string {
+	if h.localizationService == nil {
+		return nil
+	}
+	return h.localizationService.PreferredLanguages(c.GetHeader("Accept-Language"))
+}
+
+// localize - названия альбомов списка на языке покупателя
+// Если переводы недоступны, список отдается с основными названиями
+func (h *AlbumHandler) localize(c *gin.Context, albums []domain.Album) []domain.Album {
+	languages := h.requestedLanguages(c)
+	if len(languages) == 0 {
+		return albums
+	}
+
+	localized, err := h.localizationService.LocalizeAlbums(albums, languages)
+	if err != nil {
+		log.Printf("localizing albums error: %v", err)
+		return albums
+	}
+	return localized
 }
 
 // GetAlbums - обработчик для получения всех альбомов
+// С фильтрами (?genre=Hard+Bop&year_from=1955&in_stock=true), сортировкой (?sort=price,-year)
+// или ?limit=/?offset=/?cursor= отдает одну страницу; общее количество подходящих альбомов -
+// в заголовке X-Total-Count, курсор следующей страницы - в X-Next-Cursor (в заголовках, а не в теле,
+// потому что тело - тот же список, что и без пагинации, в JSON, protobuf или msgpack)
 func (h *AlbumHandler) GetAlbums(c *gin.Context) {
+	query := c.Request.URL.Query()
+	for name := range query {
+		if !slices.Contains(albumListParams, name) {
+			writeJSON(c, http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("unknown query parameter %q", name),
+				"hint":  "supported parameters: " + strings.Join(albumListParams, ", "),
+			})
+			return
+		}
+	}
+
+	for _, name := range albumPageParams {
+		if query.Has(name) {
+			h.getAlbumsPage(c)
+			return
+		}
+	}
+
 	albums, err := h.albumService.GetAllAlbums()
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, albums)
+	respondAlbumList(c, h.localize(c, convertPrices(c, h.fxService, albums)))
+}
+
+// Параметры списка альбомов: фильтры и страница, а также общие параметры ответа
+var (
+	albumPageParams = []string{"genre", "condition", "year_from", "year_to", "price_min", "price_max", "in_stock", "sort", "cursor", "limit", "offset"}
+	albumListParams = append([]string{"view", "currency", "pretty"}, albumPageParams...)
+)
+
+// getAlbumsPage - страница списка альбомов с фильтрами
+func (h *AlbumHandler) getAlbumsPage(c *gin.Context) {
+	const defaultLimit = 50
+
+	filter, err := parseAlbumFilter(c)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := queryInt(c, "limit", defaultLimit, service.MaxAlbumsPageSize)
+	offset := queryInt(c, "offset", 0, math.MaxInt32)
+
+	page, err := h.albumService.GetAlbumsPage(filter, c.Query("cursor"), limit, offset)
+	if errors.Is(err, service.ErrInvalidAlbumFilter) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	respondAlbumPage(c, h.localize(c, convertPrices(c, h.fxService, page.Albums)), page.Total)
+}
+
+// parseAlbumFilter - читает фильтр списка альбомов из параметров запроса
+func parseAlbumFilter(c *gin.Context) (domain.AlbumFilter, error) {
+	filter := domain.AlbumFilter{
+		Genre:     c.Query("genre"),
+		Condition: c.Query("condition"),
+	}
+
+	ints := map[string]*int{"year_from": &filter.YearFrom, "year_to": &filter.YearTo}
+	for name, target := range ints {
+		if value := c.Query(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be an integer", name)
+			}
+			*target = n
+		}
+	}
+
+	floats := map[string]*float64{"price_min": &filter.PriceMin, "price_max": &filter.PriceMax}
+	for name, target := range floats {
+		if value := c.Query(name); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return filter, fmt.Errorf("%s must be a number", name)
+			}
+			*target = f
+		}
+	}
+
+	sort, err := service.ParseAlbumSort(c.Query("sort"))
+	if err != nil {
+		return filter, err
+	}
+	filter.Sort = sort
+
+	if value := c.Query("in_stock"); value != "" {
+		inStock, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("in_stock must be true or false")
+		}
+		filter.InStock = &inStock
+	}
+
+	return filter, nil
 }
 
 // GetAlbumByID - обработчик для получения альбома по ID
 func (h *AlbumHandler) GetAlbumByID(c *gin.Context) {
 	id := c.Param("id")
 
+	includeTracks, err := h.includeTracks(c)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	languages := h.requestedLanguages(c)
+
+	// Горячий путь: компактный JSON в базовой валюте отдаем готовыми байтами из кэша без перекодирования
+	if !includeTracks && len(languages) == 0 && negotiateFormat(c) == binding.MIMEJSON && !c.GetBool(middleware.PrettyJSONKey) && requestedCurrency(c, h.fxService) == "" {
+		data, err := h.albumService.GetAlbumJSONByID(id)
+		if err != nil {
+			writeJSON(c, http.StatusNotFound, gin.H{"error": "album not found"})
+			return
+		}
+
+		c.Header("Content-Currency", h.fxService.BaseCurrency())
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+		return
+	}
+
 	album, err := h.albumService.GetAlbumByID(id)
 	if err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"error":"album not found"})
+		writeJSON(c, http.StatusNotFound, gin.H{"error":"album not found"})
+		return
+	}
+
+	album = &convertPrices(c, h.fxService, []domain.Album{*album})[0]
+	if len(languages) > 0 {
+		localized, err := h.localizationService.LocalizeAlbum(*album, languages)
+		if err != nil {
+			log.Printf("localizing album %s error: %v", album.ID, err)
+		} else if localized.Language != "" {
+			c.Header("Content-Language", localized.Language)
+			if !includeTracks {
+				respondLocalizedAlbum(c, localized)
+				return
+			}
+			album = &localized.Album
+		}
+	}
+	if includeTracks {
+		tracks, err := h.trackService.GetTracks(album.ID)
+		if err != nil {
+			writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		respondAlbumWithTracks(c, album, tracks)
 		return
 	}
+	respondAlbum(c, http.StatusOK, album)
+}
+
+// includeTracks - запрошены ли треки альбома (?include=tracks); другие значения include не поддерживаются
+func (h *AlbumHandler) includeTracks(c *gin.Context) (bool, error) {
+	include := c.Query("include")
+	if include == "" {
+		return false, nil
+	}
+	for _, value := range strings.Split(include, ",") {
+		if value = strings.TrimSpace(value); value != "tracks" {
+			return false, fmt.Errorf("unsupported include %q, expected tracks", value)
+		}
+	}
+	if h.trackService == nil {
+		return false, fmt.Errorf("tracks are not available")
+	}
+	return true, nil
+}
 
-	c.IndentedJSON(http.StatusOK, album)
+// albumRequest - тело запроса на создание/обновление альбома
+// in_stock принимается от клиентов, которые еще не передают stock_quantity
+// Без catalog_number и description в запросе номер и описание альбома при обновлении не меняются
+type albumRequest struct {
+	domain.Album
+	StockQuantity *int    `json:"stock_quantity"`
+	InStock       *bool   `json:"in_stock"`
+	CatalogNumber *string `json:"catalog_number"`
+	Description   *string `json:"description"`
+}
+
+// album - альбом из запроса и параметры записи (подтверждение цены, количество по флагу наличия)
+func (r *albumRequest) album(c *gin.Context) (domain.Album, []service.WriteOption) {
+	album := r.Album
+	opts := []service.WriteOption{confirmPriceChange(c)}
+	if r.StockQuantity != nil {
+		album.StockQuantity = *r.StockQuantity
+	} else {
+		opts = append(opts, service.LegacyStock(r.InStock))
+	}
+	if r.CatalogNumber != nil {
+		album.CatalogNumber = *r.CatalogNumber
+	} else {
+		opts = append(opts, service.KeepCatalogNumber())
+	}
+	if r.Description != nil {
+		album.Description = *r.Description
+	} else {
+		opts = append(opts, service.KeepDescription())
+	}
+	return album, opts
 }
 
 // CreateAlbum - обработчик для создания альбома
 func (h *AlbumHandler) CreateAlbum(c *gin.Context) {
-	var newAlbum domain.Album
+	var req albumRequest
 
-	if err := c.BindJSON(&newAlbum); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error":"invalid input"})
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
 		return
 	}
 
-	if err := h.albumService.CreateAlbum(&newAlbum); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error":err.Error()})
+	newAlbum, opts := req.album(c)
+	if err := h.albumService.CreateAlbum(&newAlbum, opts...); err != nil {
+		writeAlbumWriteError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusCreated, newAlbum)
+	writeJSON(c, http.StatusCreated, newAlbum)
 }
 
 // UpdateAlbum - обработчик для обновления альбома
 func (h *AlbumHandler) UpdateAlbum(c *gin.Context) {
 	id := c.Param("id")
 	
-	var updatedAlbum domain.Album
+	var req albumRequest
 
-	if err := c.BindJSON(&updatedAlbum); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
 		return
 	}
 
+	updatedAlbum, opts := req.album(c)
 	// Устанавливаем ID из URL параметра
 	updatedAlbum.ID = id
 
-	if err := h.albumService.UpdateAlbum(&updatedAlbum); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.albumService.UpdateAlbum(&updatedAlbum, opts...); err != nil {
+		writeAlbumWriteError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, updatedAlbum)
+}
+
+// setStockRequest - тело запроса на изменение наличия: количество или (старые клиенты) флаг наличия
+type setStockRequest struct {
+	StockQuantity *int  `json:"stock_quantity"`
+	InStock       *bool `json:"in_stock"`
+}
+
+// SetStock - обработчик для изменения только наличия альбома (доступен сотрудникам склада)
+func (h *AlbumHandler) SetStock(c *gin.Context) {
+	var req setStockRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, updatedAlbum)
+	var album *domain.Album
+	var err error
+	switch {
+	case req.StockQuantity != nil:
+		album, err = h.albumService.SetStockQuantity(c.Param("id"), *req.StockQuantity)
+	case req.InStock != nil:
+		album, err = h.albumService.SetInStock(c.Param("id"), *req.InStock)
+	default:
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "stock_quantity is required"})
+		return
+	}
+	if err != nil {
+		writeStockError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// adjustStockRequest - тело запроса на приход или списание экземпляров
+type adjustStockRequest struct {
+	Delta  int    `json:"delta"`  // Сколько добавить (приход) или, с минусом, списать (продажа, брак)
+	Reason string `json:"reason"` // Причина для журнала аудита
+}
+
+// AdjustStock - обработчик для прихода и списания экземпляров (доступен сотрудникам склада)
+// Количество меняется атомарно, поэтому одновременные корректировки с разных касс не теряются
+func (h *AlbumHandler) AdjustStock(c *gin.Context) {
+	var req adjustStockRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	album, err := h.albumService.AdjustStock(c.Param("id"), req.Delta, req.Reason, actor)
+	if err != nil {
+		writeStockError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// writeStockError - ответ на ошибку изменения количества на складе
+// Нехватка экземпляров - 409: списание можно повторить после прихода;
+// у альбома несколько изданий - 409: количество меняется у издания
+func writeStockError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrVariantRequired):
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidStockAdjustment):
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	}
+}
+
+// confirmPriceChange - подтверждение подозрительной цены параметром ?confirm_price_change=true
+func confirmPriceChange(c *gin.Context) service.WriteOption {
+	return service.ConfirmPriceChange(c.Query("confirm_price_change") == "true")
+}
+
+// writeAlbumWriteError - ответ на ошибку создания/обновления альбома
+// Подозрительная цена - 409: запрос можно повторить с подтверждением
+func writeAlbumWriteError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrPriceConfirmationRequired) {
+		writeJSON(c, http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"hint":  "repeat the request with ?confirm_price_change=true if the price is correct",
+		})
+		return
+	}
+	if errors.Is(err, domain.ErrVariantRequired) {
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
 }
 
 // DeleteAlbum - обработчик для удаления альбома
@@ -85,11 +431,18 @@ func (h *AlbumHandler) DeleteAlbum(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.albumService.DeleteAlbum(id); err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		// Повторное удаление - тот же результат, что и первое: клиент может безопасно повторять запрос
+		var deleted *service.AlbumDeletedError
+		if errors.As(err, &deleted) {
+			c.Header("X-Album-Deleted-At", deleted.Tombstone.DeletedAt.UTC().Format(time.RFC3339))
+			c.Status(http.StatusNoContent)
+			return
+		}
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.IndentedJSON(http.StatusNoContent, nil) // 204 No Content для удаления
+	c.Status(http.StatusNoContent) // 204 No Content для удаления
 }
 
 // GetAlbumsByArtist - обработчик для получения альбомов по автору
@@ -98,11 +451,11 @@ func (h *AlbumHandler) GetAlbumsByArtist(c *gin.Context) {
 
 	albums, err := h.albumService.GetAlbumsByArtist(artist)
 	if err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, albums)
+	respondAlbumList(c, h.localize(c, convertPrices(c, h.fxService, albums)))
 }
 
 // GetAlbumsInStock - обработчик для получения альбомов по наличию
@@ -110,14 +463,47 @@ func (h *AlbumHandler) GetAlbumsInStock(c *gin.Context) {
 	
 	albums, err := h.albumService.GetAlbumsInStock()
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "albums not found"})
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": "albums not found"})
 		return
 	}
 
-	if len(albums) == 0 {
-        c.JSON(http.StatusOK, []domain.Album{}) // Пустой массив вместо ошибки
-        return
-    }
+	respondAlbumList(c, h.localize(c, convertPrices(c, h.fxService, albums))) // Пустой список отдается как [] вместо ошибки
+}
+
+// ExportAlbums - потоковая выгрузка всего каталога в формате NDJSON (один альбом на строку)
+// Альбомы пишутся в ответ по мере чтения из базы, без сборки всего списка в памяти
+func (h *AlbumHandler) ExportAlbums(c *gin.Context) {
+	const flushEvery = 100 // Как часто отправлять накопленные строки клиенту
+
+	// Выгрузка большого каталога может идти дольше WriteTimeout сервера - снимаем дедлайн
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("resetting write deadline error: %v", err)
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer) // Encode добавляет перевод строки после каждого объекта
+	count := 0
+
+	for album, err := range h.albumService.StreamAllAlbums() {
+		if err != nil {
+			// Статус уже отправлен - можем только оборвать поток
+			log.Printf("streaming albums error after %d albums: %v", count, err)
+			c.Abort()
+			return
+		}
+
+		if err := encoder.Encode(album); err != nil {
+			log.Printf("writing albums stream error: %v", err) // Клиент отключился
+			return
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			c.Writer.Flush()
+		}
+	}
 
-	c.IndentedJSON(http.StatusOK, albums)
+	c.Writer.Flush()
 }
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"go-music-shop/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlbumSummary - компактное представление альбома для списков на витрине
+// Содержит только поля, которые нужны карточке товара в каталоге
+type AlbumSummary struct {
+	ID      string  `json:"id"`
+	Title   string  `json:"title"`
+	Artist  string  `json:"artist"`
+	Price   float64 `json:"price"`
+	Year    int     `json:"year,omitempty"`
+	InStock bool    `json:"in_stock"`
+}
+
+// toAlbumSummaries - конвертирует полные альбомы в компактное представление
+func toAlbumSummaries(albums []domain.Album) []AlbumSummary {
+	summaries := make([]AlbumSummary, len(albums))
+	for i, album := range albums {
+		summaries[i] = AlbumSummary{
+			ID:      album.ID,
+			Title:   album.Title,
+			Artist:  album.Artist,
+			Price:   album.Price,
+			Year:    album.Year,
+			InStock: album.InStock(),
+		}
+	}
+	return summaries
+}
+
+// respondAlbumList - отдает список альбомов в представлении, выбранном параметром ?view=
+// view=compact - компактные карточки, иначе (view=full) - полные альбомы
+// Формат (JSON/protobuf/msgpack) выбирается по заголовку Accept
+func respondAlbumList(c *gin.Context, albums []domain.Album) {
+	respondAlbumPage(c, albums, len(albums))
+}
+
+// respondAlbumPage - отдает страницу списка альбомов; total - всего альбомов под фильтром
+// (в protobuf - total_count, в JSON его передает заголовок X-Total-Count)
+func respondAlbumPage(c *gin.Context, albums []domain.Album, total int) {
+	if albums == nil {
+		albums = []domain.Album{} // Пустой массив вместо null
+	}
+
+	if c.Query("view") == "compact" {
+		respondAlbums(c, albums, total, toAlbumSummaries(albums))
+		return
+	}
+
+	respondAlbums(c, albums, total, albums)
+}
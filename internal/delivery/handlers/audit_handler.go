@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"go-music-shop/internal/domain/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler - админский обработчик журнала аудита
+type AuditHandler struct {
+	repo domain.AuditRepository
+}
+
+// NewAuditHandler - конструктор обработчика аудита
+func NewAuditHandler(repo domain.AuditRepository) *AuditHandler {
+	return &AuditHandler{repo: repo}
+}
+
+// GetAuditLog - обработчик для просмотра журнала аудита
+// Параметры: entity_type, entity_id - фильтр по объекту, limit - количество записей (по умолчанию 100)
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	limit := queryInt(c, "limit", 100, 1000)
+
+	entries, err := h.repo.List(c.Query("entity_type"), c.Query("entity_id"), limit)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, entries)
+}
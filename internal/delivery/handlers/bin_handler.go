@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BinHandler - админский обработчик мест хранения на складе
+type BinHandler struct {
+	binService *service.BinService
+}
+
+// NewBinHandler - конструктор обработчика мест хранения
+func NewBinHandler(binService *service.BinService) *BinHandler {
+	return &BinHandler{binService: binService}
+}
+
+// assignBinRequest - тело запроса на перенос альбома ("" - снять с места)
+type assignBinRequest struct {
+	BinCode string `json:"bin_code"`
+}
+
+// AssignBin - обработчик для переноса альбома в другое место хранения
+func (h *BinHandler) AssignBin(c *gin.Context) {
+	var req assignBinRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.binService.AssignBin(c.Param("id"), req.BinCode); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBinContents - обработчик для получения содержимого места хранения
+func (h *BinHandler) GetBinContents(c *gin.Context) {
+	albums, err := h.binService.GetBinContents(c.Param("code"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, albums)
+}
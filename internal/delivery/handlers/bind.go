@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/delivery/middleware"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unknownFieldError - в теле запроса есть поле, которого нет в ожидаемой структуре (строгий режим)
+type unknownFieldError struct {
+	Field      string
+	Suggestion string // Похожее известное поле ("" - не нашли)
+}
+
+func (e *unknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// bindJSON - разбирает JSON тело запроса в obj
+// Единая точка разбора для всех обработчиков: политика неизвестных полей задается middleware.StrictJSON
+func bindJSON(c *gin.Context, obj any) error {
+	if c.Request.Body == nil {
+		return errors.New("request body is empty")
+	}
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if c.GetBool(middleware.StrictJSONKey) {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(obj)
+	if err == nil {
+		return nil
+	}
+
+	// encoding/json не экспортирует тип этой ошибки - достаем имя поля из текста
+	if quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if field, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil {
+			return &unknownFieldError{Field: field, Suggestion: closestField(obj, field)}
+		}
+	}
+	return err
+}
+
+// writeBindError - ответ 400 на ошибку разбора тела запроса
+func writeBindError(c *gin.Context, err error) {
+	var unknown *unknownFieldError
+	if errors.As(err, &unknown) {
+		body := gin.H{"error": unknown.Error()}
+		if unknown.Suggestion != "" {
+			body["hint"] = fmt.Sprintf("did you mean %q?", unknown.Suggestion)
+		}
+		writeJSON(c, http.StatusBadRequest, body)
+		return
+	}
+	writeJSON(c, http.StatusBadRequest, gin.H{"error": "invalid input"})
+}
+
+// closestField - известное JSON поле структуры obj, больше всего похожее на field (опечатка клиента)
+func closestField(obj any, field string) string {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	best, bestDistance := "", 3 // Дальше двух правок - уже не опечатка
+	for _, name := range jsonFieldNames(t) {
+		if d := editDistance(strings.ToLower(field), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// jsonFieldNames - имена полей структуры в JSON, включая поля встроенных структур
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(f.Type)...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// editDistance - расстояние Левенштейна между строками
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CostHandler - админский обработчик закупочных цен и маржи
+// Эти данные отдаются только через /admin: публичные ответы и protobuf их не содержат
+type CostHandler struct {
+	costService *service.CostService
+}
+
+// NewCostHandler - конструктор обработчика закупочных цен
+func NewCostHandler(costService *service.CostService) *CostHandler {
+	return &CostHandler{costService: costService}
+}
+
+// GetAlbums - обработчик для получения альбомов с закупочными ценами и маржой
+func (h *CostHandler) GetAlbums(c *gin.Context) {
+	albums, err := h.costService.GetAlbums()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, albums)
+}
+
+// GetAlbum - обработчик для получения альбома с закупочной ценой и маржой
+func (h *CostHandler) GetAlbum(c *gin.Context) {
+	album, err := h.costService.GetAlbum(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "album not found"})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// setCostRequest - тело запроса на изменение закупочной цены (null - очистить)
+type setCostRequest struct {
+	CostPrice *float64 `json:"cost_price"`
+}
+
+// SetCostPrice - обработчик для изменения закупочной цены альбома
+func (h *CostHandler) SetCostPrice(c *gin.Context) {
+	var req setCostRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	album, err := h.costService.SetCostPrice(c.Param("id"), req.CostPrice)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// setConditionNotesRequest - тело запроса на изменение заметок о состоянии ("" - очистить)
+type setConditionNotesRequest struct {
+	ConditionNotes string `json:"condition_notes"`
+}
+
+// SetConditionNotes - обработчик для изменения внутренних заметок о состоянии экземпляра
+func (h *CostHandler) SetConditionNotes(c *gin.Context) {
+	var req setConditionNotesRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	album, err := h.costService.SetConditionNotes(c.Param("id"), req.ConditionNotes)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// GetValuation - обработчик для оценки склада по ценам продажи и закупки
+func (h *CostHandler) GetValuation(c *gin.Context) {
+	valuation, err := h.costService.GetValuation()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, valuation)
+}
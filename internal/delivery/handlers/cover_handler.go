@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/service"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CoverHandler - загрузка обложек альбомов
+type CoverHandler struct {
+	coverService *service.CoverService
+}
+
+// NewCoverHandler - конструктор обработчика обложек
+func NewCoverHandler(coverService *service.CoverService) *CoverHandler {
+	return &CoverHandler{coverService: coverService}
+}
+
+// UploadCover - multipart форма с файлом обложки (cover): JPEG, PNG или WebP
+// Отвечает альбомом с новым cover_url
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	header, err := c.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSON(c, http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+			return
+		}
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "cover file is required"})
+		return
+	}
+	if header.Size > h.coverService.MaxBytes() {
+		writeCoverError(c, service.ErrCoverTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	// Читаем на байт больше лимита, чтобы сервис увидел превышение
+	data, err := io.ReadAll(io.LimitReader(file, h.coverService.MaxBytes()+1))
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+
+	album, err := h.coverService.UploadCover(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// DeleteCover - убирает обложку альбома
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	album, err := h.coverService.DeleteCover(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, album)
+}
+
+// writeCoverError - ответ на ошибку загрузки обложки
+func writeCoverError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCoverTooLarge):
+		writeJSON(c, http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCoverType):
+		writeJSON(c, http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidCover):
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"log"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestedCurrency - валюта, в которой клиент хочет видеть цены (?currency= или заголовок Accept-Currency)
+// Пустая строка - валюта не запрошена или совпадает с базовой
+func requestedCurrency(c *gin.Context, fxService *service.FXService) string {
+	currency := c.Query("currency")
+	if currency == "" {
+		currency = c.GetHeader("Accept-Currency")
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == fxService.BaseCurrency() {
+		return ""
+	}
+	return currency
+}
+
+// convertPrices - переводит цены альбомов в запрошенную валюту
+// Если курса нет или курсы устарели - цены остаются в базовой валюте, клиент получает заголовок Warning
+// Возвращает копию списка: альбомы могут быть общими с кэшем
+func convertPrices(c *gin.Context, fxService *service.FXService, albums []domain.Album) []domain.Album {
+	currency := requestedCurrency(c, fxService)
+	if currency == "" {
+		c.Header("Content-Currency", fxService.BaseCurrency())
+		return albums
+	}
+
+	if _, err := fxService.Rate(currency); err != nil {
+		log.Printf("converting prices to %s error: %v", currency, err)
+		c.Header("Content-Currency", fxService.BaseCurrency())
+		reason := "exchange rates are stale"
+		if errors.Is(err, service.ErrUnknownCurrency) {
+			reason = "currency is not supported"
+		}
+		c.Header("Warning", `299 - "`+reason+`, prices are in `+fxService.BaseCurrency()+`"`)
+		return albums
+	}
+
+	converted := make([]domain.Album, len(albums))
+	for i, album := range albums {
+		album.Price, _ = fxService.Convert(album.Price, currency) // Курс уже проверен выше
+		converted[i] = album
+	}
+
+	c.Header("Content-Currency", currency)
+	return converted
+}
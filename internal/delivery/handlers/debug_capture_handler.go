@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"go-music-shop/internal/monitoring"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugCaptureHandler - просмотр записанных запросов и ответов
+type DebugCaptureHandler struct {
+	buffer *monitoring.CaptureBuffer
+}
+
+// NewDebugCaptureHandler - конструктор обработчика отладочных записей
+func NewDebugCaptureHandler(buffer *monitoring.CaptureBuffer) *DebugCaptureHandler {
+	return &DebugCaptureHandler{buffer: buffer}
+}
+
+// ListCaptures - последние записанные запросы (?limit=, по умолчанию 50)
+func (h *DebugCaptureHandler) ListCaptures(c *gin.Context) {
+	limit := queryInt(c, "limit", 50, 500)
+	writeJSON(c, http.StatusOK, h.buffer.Recent(limit))
+}
+
+// GetCapture - запись запроса по его ID (заголовок X-Request-ID ответа)
+func (h *DebugCaptureHandler) GetCapture(c *gin.Context) {
+	capture, ok := h.buffer.Get(c.Param("request_id"))
+	if !ok {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "capture not found"})
+		return
+	}
+	writeJSON(c, http.StatusOK, capture)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExternalIDHandler - внешние id альбомов для интеграций
+type ExternalIDHandler struct {
+	externalIDService *service.ExternalIDService
+}
+
+// NewExternalIDHandler - конструктор обработчика внешних id
+func NewExternalIDHandler(externalIDService *service.ExternalIDService) *ExternalIDHandler {
+	return &ExternalIDHandler{externalIDService: externalIDService}
+}
+
+// ResolveAlbumID - находит наш id альбома по внешнему (/albums/by-external/:system/:external_id)
+// и передает его дальше как параметр :id, чтобы альбом отдал обычный обработчик
+func (h *ExternalIDHandler) ResolveAlbumID(c *gin.Context) {
+	albumID, err := h.externalIDService.FindAlbumID(c.Param("system"), c.Param("external_id"))
+	if errors.Is(err, service.ErrUnknownExternalSystem) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Abort()
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "album not found"})
+		c.Abort()
+		return
+	}
+
+	c.Params = append(c.Params, gin.Param{Key: "id", Value: albumID})
+	c.Next()
+}
+
+// GetExternalIDs - внешние id альбома
+func (h *ExternalIDHandler) GetExternalIDs(c *gin.Context) {
+	ids, err := h.externalIDService.GetExternalIDs(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, ids)
+}
+
+// setExternalIDRequest - тело запроса на привязку внешнего id
+type setExternalIDRequest struct {
+	ExternalID string `json:"external_id"`
+}
+
+// SetExternalID - привязывает внешний id системы к альбому
+func (h *ExternalIDHandler) SetExternalID(c *gin.Context) {
+	var req setExternalIDRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.externalIDService.SetExternalID(c.Param("id"), c.Param("system"), req.ExternalID); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteExternalID - отвязывает внешний id системы от альбома
+func (h *ExternalIDHandler) DeleteExternalID(c *gin.Context) {
+	err := h.externalIDService.DeleteExternalID(c.Param("id"), c.Param("system"))
+	if errors.Is(err, service.ErrUnknownExternalSystem) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/monitoring"
+	"go-music-shop/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlashSaleHandler - админский обработчик режима распродажи
+type FlashSaleHandler struct {
+	flashSaleService *service.FlashSaleService
+	traffic          *monitoring.TrafficMetrics
+}
+
+// NewFlashSaleHandler - конструктор обработчика распродаж
+func NewFlashSaleHandler(flashSaleService *service.FlashSaleService, traffic *monitoring.TrafficMetrics) *FlashSaleHandler {
+	return &FlashSaleHandler{flashSaleService: flashSaleService, traffic: traffic}
+}
+
+// scheduleFlashSaleRequest - тело запроса на назначение распродажи
+type scheduleFlashSaleRequest struct {
+	StartsAt time.Time `json:"starts_at"` // Пусто - с текущего момента
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// GetFlashSale - обработчик для просмотра назначенной распродажи
+func (h *FlashSaleHandler) GetFlashSale(c *gin.Context) {
+	sale, err := h.flashSaleService.Status(c.Request.Context())
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if sale == nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "no flash sale scheduled"})
+		return
+	}
+	writeJSON(c, http.StatusOK, sale)
+}
+
+// ScheduleFlashSale - обработчик для назначения распродажи (заменяет уже назначенную)
+func (h *FlashSaleHandler) ScheduleFlashSale(c *gin.Context) {
+	var req scheduleFlashSaleRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	sale, err := h.flashSaleService.Schedule(c.Request.Context(), req.StartsAt, req.EndsAt)
+	if errors.Is(err, service.ErrInvalidFlashSale) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, sale)
+}
+
+// CancelFlashSale - обработчик для досрочного завершения распродажи
+func (h *FlashSaleHandler) CancelFlashSale(c *gin.Context) {
+	if err := h.flashSaleService.Cancel(c.Request.Context()); err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTraffic - отдает счетчики публичного трафика этого экземпляра
+func (h *FlashSaleHandler) GetTraffic(c *gin.Context) {
+	writeJSON(c, http.StatusOK, h.traffic.Snapshot())
+}
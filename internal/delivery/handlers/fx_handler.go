@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FXHandler - админский обработчик курсов валют
+type FXHandler struct {
+	fxService *service.FXService
+}
+
+// NewFXHandler - конструктор обработчика курсов валют
+func NewFXHandler(fxService *service.FXService) *FXHandler {
+	return &FXHandler{fxService: fxService}
+}
+
+// GetRates - обработчик для просмотра текущих курсов и ручных переопределений
+func (h *FXHandler) GetRates(c *gin.Context) {
+	writeJSON(c, http.StatusOK, h.fxService.GetRates())
+}
+
+// overrideRateRequest - тело запроса на ручную установку курса
+type overrideRateRequest struct {
+	Rate float64 `json:"rate"`
+}
+
+// SetOverride - обработчик для ручной установки курса валюты
+func (h *FXHandler) SetOverride(c *gin.Context) {
+	var req overrideRateRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.fxService.SetOverride(c.Request.Context(), c.Param("currency"), req.Rate); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, h.fxService.GetRates())
+}
+
+// DeleteOverride - обработчик для удаления ручного курса (снова используется курс провайдера)
+func (h *FXHandler) DeleteOverride(c *gin.Context) {
+	if err := h.fxService.DeleteOverride(c.Request.Context(), c.Param("currency")); err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
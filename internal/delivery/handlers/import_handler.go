@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/storage"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportHandler - загрузка альбомов из таблиц сотрудников (CSV, XLSX)
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler - конструктор обработчика загрузки
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// GetFields - поля альбома, которым можно сопоставить колонки таблицы (для интерфейса загрузки)
+func (h *ImportHandler) GetFields(c *gin.Context) {
+	writeJSON(c, http.StatusOK, gin.H{"fields": service.ImportFields})
+}
+
+// Preview - предпросмотр загрузки: multipart форма с файлом (file) и соответствием колонок
+// (mapping - JSON {"поле": "заголовок колонки"}, необязательно)
+func (h *ImportHandler) Preview(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	var mapping domain.ImportMapping
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			writeJSON(c, http.StatusBadRequest, gin.H{"error": "mapping must be a JSON object of field to column"})
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+
+	preview, err := h.importService.Preview(c.Request.Context(), data, mapping)
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, preview)
+}
+
+// Commit - загружает альбомы по предпросмотру
+func (h *ImportHandler) Commit(c *gin.Context) {
+	result, err := h.importService.Commit(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, result)
+}
+
+// writeImportError - ответ на ошибку загрузки
+func writeImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidImport):
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrImportCommitted):
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, storage.ErrNotFound):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "import not found"})
+	default:
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"go-music-shop/internal/monitoring"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalHandler - служебные эндпоинты для эксплуатации (не для клиентов магазина)
+type InternalHandler struct {
+	poolMonitor  *monitoring.PoolMonitor
+	cacheMetrics *monitoring.CacheMetrics
+}
+
+// NewInternalHandler - конструктор обработчика служебных эндпоинтов
+func NewInternalHandler(poolMonitor *monitoring.PoolMonitor, cacheMetrics *monitoring.CacheMetrics) *InternalHandler {
+	return &InternalHandler{poolMonitor: poolMonitor, cacheMetrics: cacheMetrics}
+}
+
+// GetPoolStats - отдает состояние пулов подключений к PostgreSQL и Redis
+func (h *InternalHandler) GetPoolStats(c *gin.Context) {
+	writeJSON(c, http.StatusOK, h.poolMonitor.Stats())
+}
+
+// GetCacheStats - отдает счетчики попаданий/промахов кэша по типам данных
+func (h *InternalHandler) GetCacheStats(c *gin.Context) {
+	writeJSON(c, http.StatusOK, h.cacheMetrics.Snapshot())
+}
+
+// GetCacheUsage - отдает оценку памяти, занятой кэшем каталога
+func (h *InternalHandler) GetCacheUsage(c *gin.Context) {
+	usage := h.cacheMetrics.Usage()
+	if usage == nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "cache usage is not estimated yet"})
+		return
+	}
+	writeJSON(c, http.StatusOK, usage)
+}
+
+// GetCacheReconciliation - отдает результат последней сверки кэша с базой
+func (h *InternalHandler) GetCacheReconciliation(c *gin.Context) {
+	report := h.cacheMetrics.Reconciliation()
+	if report == nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "cache has not been reconciled yet"})
+		return
+	}
+	writeJSON(c, http.StatusOK, report)
+}
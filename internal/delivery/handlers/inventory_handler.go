@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/service"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReceiveBatch - максимум сканов в одном пакетном запросе приемки
+const maxReceiveBatch = 1000
+
+// InventoryHandler - приемка пластинок на склад сканером штрихкодов
+type InventoryHandler struct {
+	inventoryService *service.InventoryService
+}
+
+// NewInventoryHandler - конструктор обработчика приемки
+func NewInventoryHandler(inventoryService *service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventoryService}
+}
+
+// receiveRequest - пакет сканов
+type receiveRequest struct {
+	Scans []service.ReceiveScan `json:"scans"`
+}
+
+// receiveResponse - подтверждения пакета сканов и итог по статусам
+type receiveResponse struct {
+	Results  []service.ReceiveResult `json:"results"`
+	Received int                     `json:"received"`
+	Queued   int                     `json:"queued"`
+	Errors   int                     `json:"errors"`
+}
+
+// Receive - приемка по сканам штрихкодов
+// application/x-ndjson - поток сканов по одному в строке: подтверждение каждого скана уходит
+// сканеру сразу, не дожидаясь конца потока; иначе - пакет {"scans": [...]} с ответом целиком
+func (h *InventoryHandler) Receive(c *gin.Context) {
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	if strings.HasPrefix(c.ContentType(), "application/x-ndjson") {
+		h.receiveStream(c, actor)
+		return
+	}
+
+	var req receiveRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	if len(req.Scans) == 0 {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "scans are required"})
+		return
+	}
+	if len(req.Scans) > maxReceiveBatch {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("too many scans, at most %d per request", maxReceiveBatch)})
+		return
+	}
+
+	resp := receiveResponse{Results: h.inventoryService.ReceiveBatch(req.Scans, actor)}
+	for _, result := range resp.Results {
+		switch result.Status {
+		case service.ReceiveReceived:
+			resp.Received++
+		case service.ReceiveQueued:
+			resp.Queued++
+		default:
+			resp.Errors++
+		}
+	}
+
+	writeJSON(c, http.StatusOK, resp)
+}
+
+// receiveStream - принимает поток сканов и отвечает на каждый отдельной строкой
+// Размер потока ограничен HTTP_MAX_BODY_BYTES, как и любое тело запроса - сканер переподключается
+func (h *InventoryHandler) receiveStream(c *gin.Context, actor string) {
+	// Сканер держит соединение открытым всю смену приемки - снимаем дедлайны сервера
+	controller := http.NewResponseController(c.Writer)
+	if err := controller.SetReadDeadline(time.Time{}); err != nil {
+		log.Printf("resetting read deadline error: %v", err)
+	}
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("resetting write deadline error: %v", err)
+	}
+	if err := controller.EnableFullDuplex(); err != nil { // Ответы пишутся, пока тело запроса еще читается
+		log.Printf("enabling full duplex error: %v", err)
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	decoder := json.NewDecoder(c.Request.Body)
+	encoder := json.NewEncoder(c.Writer)
+	for {
+		var scan service.ReceiveScan
+		err := decoder.Decode(&scan)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Статус уже отправлен - сообщаем об ошибке последней строкой и закрываем поток
+			_ = encoder.Encode(gin.H{"error": "invalid scan: " + err.Error()})
+			return
+		}
+
+		if err := encoder.Encode(h.inventoryService.Receive(scan, actor)); err != nil {
+			log.Printf("writing receive stream error: %v", err) // Сканер отключился
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// GetUnknownBarcodes - очередь неизвестных штрихкодов (?status=pending|found|not_found, ?limit=)
+func (h *InventoryHandler) GetUnknownBarcodes(c *gin.Context) {
+	barcodes, err := h.inventoryService.UnknownBarcodes(c.Query("status"), queryInt(c, "limit", 100, 1000))
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, barcodes)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/storage"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler - админский обработчик фоновых задач (выгрузки, переиндексация)
+type JobHandler struct {
+	jobService *service.JobService
+	export     service.JobFunc
+	reindex    service.JobFunc
+}
+
+// NewJobHandler - конструктор обработчика фоновых задач
+func NewJobHandler(jobService *service.JobService, export, reindex service.JobFunc) *JobHandler {
+	return &JobHandler{jobService: jobService, export: export, reindex: reindex}
+}
+
+// StartExport - обработчик для запуска выгрузки каталога; отвечает 202 с задачей
+func (h *JobHandler) StartExport(c *gin.Context) {
+	h.submit(c, service.JobKindCatalogExport, h.export)
+}
+
+// StartReindex - обработчик для запуска перестройки индекса подсказок
+func (h *JobHandler) StartReindex(c *gin.Context) {
+	h.submit(c, service.JobKindSuggestIndex, h.reindex)
+}
+
+// submit - ставит задачу и отдает ссылку, по которой опрашивать ее состояние
+func (h *JobHandler) submit(c *gin.Context, kind string, fn service.JobFunc) {
+	job := h.jobService.Submit(kind, fn)
+	c.Header("Location", jobURL(job.ID))
+	writeJSON(c, http.StatusAccepted, withResultURL(job))
+}
+
+// ListJobs - обработчик для списка задач
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	jobs := h.jobService.List()
+	for i := range jobs {
+		jobs[i] = withResultURL(jobs[i])
+	}
+	writeJSON(c, http.StatusOK, jobs)
+}
+
+// GetJob - обработчик для опроса состояния задачи
+func (h *JobHandler) GetJob(c *gin.Context) {
+	job, err := h.jobService.Get(c.Param("id"))
+	if err != nil {
+		writeJobError(c, err)
+		return
+	}
+	writeJSON(c, http.StatusOK, withResultURL(job))
+}
+
+// CancelJob - обработчик для отмены задачи
+func (h *JobHandler) CancelJob(c *gin.Context) {
+	job, err := h.jobService.Cancel(c.Param("id"))
+	if err != nil {
+		writeJobError(c, err)
+		return
+	}
+	writeJSON(c, http.StatusOK, withResultURL(job))
+}
+
+// GetResult - обработчик для скачивания результата задачи
+func (h *JobHandler) GetResult(c *gin.Context) {
+	r, filename, err := h.jobService.Result(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeJobError(c, err)
+		return
+	}
+	defer r.Close()
+
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, r); err != nil {
+		log.Printf("sending job result error: %v", err) // Клиент отключился
+	}
+}
+
+// jobURL - адрес задачи для опроса
+func jobURL(id string) string {
+	return "/admin/jobs/" + id
+}
+
+// withResultURL - добавляет ссылку на скачивание, если результат готов
+func withResultURL(job domain.Job) domain.Job {
+	if job.State == domain.JobSucceeded && job.ResultKey != "" {
+		job.ResultURL = jobURL(job.ID) + "/result"
+	}
+	return job
+}
+
+// writeJobError - ответ на ошибку работы с задачей
+func writeJobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound), errors.Is(err, service.ErrJobNoResult), errors.Is(err, storage.ErrNotFound):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrJobNotFinished):
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
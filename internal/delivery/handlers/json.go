@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"go-music-shop/internal/delivery/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeJSON - отдает JSON ответ: компактный по умолчанию,
+// с отступами только если это включено middleware.PrettyJSON
+func writeJSON(c *gin.Context, code int, obj any) {
+	if c.GetBool(middleware.PrettyJSONKey) {
+		c.IndentedJSON(code, obj)
+		return
+	}
+	c.JSON(code, obj)
+}
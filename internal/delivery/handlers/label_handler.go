@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LabelHandler - лейблы звукозаписи и просмотр каталога по лейблам
+type LabelHandler struct {
+	labelService *service.LabelService
+	fxService    *service.FXService // Пересчет цен альбомов в валюту покупателя
+}
+
+// NewLabelHandler - конструктор обработчика лейблов
+func NewLabelHandler(labelService *service.LabelService, fxService *service.FXService) *LabelHandler {
+	return &LabelHandler{labelService: labelService, fxService: fxService}
+}
+
+// GetLabels - обработчик для получения всех лейблов
+func (h *LabelHandler) GetLabels(c *gin.Context) {
+	labels, err := h.labelService.GetLabels()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	writeJSON(c, http.StatusOK, labels)
+}
+
+// GetLabel - обработчик для получения лейбла по ID
+func (h *LabelHandler) GetLabel(c *gin.Context) {
+	label, err := h.labelService.GetLabel(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "label not found"})
+		return
+	}
+	writeJSON(c, http.StatusOK, label)
+}
+
+// GetLabelAlbums - обработчик для получения альбомов лейбла
+func (h *LabelHandler) GetLabelAlbums(c *gin.Context) {
+	albums, err := h.labelService.GetLabelAlbums(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "label not found"})
+		return
+	}
+	respondAlbumList(c, convertPrices(c, h.fxService, albums))
+}
+
+// CreateLabel - обработчик для создания лейбла
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var label domain.Label
+	if err := bindJSON(c, &label); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.labelService.CreateLabel(&label); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	writeJSON(c, http.StatusCreated, label)
+}
+
+// UpdateLabel - обработчик для изменения лейбла
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	var label domain.Label
+	if err := bindJSON(c, &label); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	label.ID = c.Param("id")
+
+	if err := h.labelService.UpdateLabel(&label); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	writeJSON(c, http.StatusOK, label)
+}
+
+// DeleteLabel - обработчик для удаления лейбла
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	if err := h.labelService.DeleteLabel(c.Param("id")); err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// setAlbumLabelRequest - тело запроса на назначение лейбла ("" - снять лейбл)
+type setAlbumLabelRequest struct {
+	LabelID string `json:"label_id"`
+}
+
+// SetAlbumLabel - обработчик для назначения альбому лейбла
+func (h *LabelHandler) SetAlbumLabel(c *gin.Context) {
+	var req setAlbumLabelRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.labelService.SetAlbumLabel(c.Param("id"), req.LabelID); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
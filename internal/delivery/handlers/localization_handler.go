@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocalizationHandler - переводы названий и примечаний альбомов (админка)
+type LocalizationHandler struct {
+	localizationService *service.LocalizationService
+}
+
+// NewLocalizationHandler - конструктор обработчика переводов
+func NewLocalizationHandler(localizationService *service.LocalizationService) *LocalizationHandler {
+	return &LocalizationHandler{localizationService: localizationService}
+}
+
+// GetLocalizations - все переводы альбома по языкам
+func (h *LocalizationHandler) GetLocalizations(c *gin.Context) {
+	localizations, err := h.localizationService.GetLocalizations(c.Param("id"))
+	if err != nil {
+		writeLocalizationError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, localizations)
+}
+
+// SetLocalization - добавляет или заменяет перевод альбома на языке :lang
+func (h *LocalizationHandler) SetLocalization(c *gin.Context) {
+	var req domain.AlbumLocalization
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.localizationService.SetLocalization(c.Param("id"), c.Param("lang"), req); err != nil {
+		writeLocalizationError(c, err)
+		return
+	}
+
+	h.GetLocalizations(c)
+}
+
+// DeleteLocalization - удаляет перевод альбома на языке :lang
+func (h *LocalizationHandler) DeleteLocalization(c *gin.Context) {
+	if err := h.localizationService.DeleteLocalization(c.Param("id"), c.Param("lang")); err != nil {
+		writeLocalizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeLocalizationError - ответ на ошибку работы с переводами
+func writeLocalizationError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"go-music-shop/internal/delivery/protoconv"
+	"go-music-shop/internal/domain/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+
+	catalogpb "go-music-shop/pkg/gen/catalog"
+)
+
+// negotiateFormat - выбирает формат ответа по заголовку Accept
+// Внутренние высоконагруженные клиенты могут запросить protobuf или msgpack,
+// по умолчанию (и для неизвестных форматов) отдаем JSON
+func negotiateFormat(c *gin.Context) string {
+	format := c.NegotiateFormat(binding.MIMEJSON, binding.MIMEPROTOBUF, binding.MIMEMSGPACK, binding.MIMEMSGPACK2)
+	if format == binding.MIMEMSGPACK2 {
+		return binding.MIMEMSGPACK
+	}
+	if format == "" {
+		return binding.MIMEJSON
+	}
+	return format
+}
+
+// respondAlbum - отдает один альбом в согласованном формате
+func respondAlbum(c *gin.Context, code int, album *domain.Album) {
+	switch negotiateFormat(c) {
+	case binding.MIMEPROTOBUF:
+		c.ProtoBuf(code, protoconv.AlbumToProto(album))
+	case binding.MIMEMSGPACK:
+		c.Render(code, render.MsgPack{Data: msgPackData(album)})
+	default:
+		writeJSON(c, code, album)
+	}
+}
+
+// respondAlbumWithTracks - отдает альбом вместе с треками в согласованном формате
+func respondAlbumWithTracks(c *gin.Context, album *domain.Album, tracks []domain.Track) {
+	switch negotiateFormat(c) {
+	case binding.MIMEPROTOBUF:
+		pbAlbum := protoconv.AlbumToProto(album)
+		pbAlbum.Tracks = protoconv.TracksToProto(tracks)
+		c.ProtoBuf(http.StatusOK, pbAlbum)
+	case binding.MIMEMSGPACK:
+		c.Render(http.StatusOK, render.MsgPack{Data: msgPackData(domain.AlbumWithTracks{Album: *album, Tracks: tracks})})
+	default:
+		writeJSON(c, http.StatusOK, domain.AlbumWithTracks{Album: *album, Tracks: tracks})
+	}
+}
+
+// respondLocalizedAlbum - отдает альбом на языке покупателя в согласованном формате
+// В protobuf нет полей перевода - отдается альбом с переведенным названием
+func respondLocalizedAlbum(c *gin.Context, album domain.LocalizedAlbum) {
+	switch negotiateFormat(c) {
+	case binding.MIMEPROTOBUF:
+		c.ProtoBuf(http.StatusOK, protoconv.AlbumToProto(&album.Album))
+	case binding.MIMEMSGPACK:
+		c.Render(http.StatusOK, render.MsgPack{Data: msgPackData(album)})
+	default:
+		writeJSON(c, http.StatusOK, album)
+	}
+}
+
+// respondAlbums - отдает список альбомов в согласованном формате
+// Для protobuf переиспользуем сообщение GetAlbumsResponse из gRPC контракта
+// total - всего альбомов в списке (у постраничного списка больше, чем на странице; как X-Total-Count)
+// jsonData - что отдать в JSON/msgpack (полные альбомы или компактные карточки)
+func respondAlbums(c *gin.Context, albums []domain.Album, total int, jsonData any) {
+	switch negotiateFormat(c) {
+	case binding.MIMEPROTOBUF:
+		c.ProtoBuf(http.StatusOK, &catalogpb.GetAlbumsResponse{
+			Albums:     protoconv.AlbumsToProto(albums),
+			TotalCount: int32(total),
+		})
+	case binding.MIMEMSGPACK:
+		c.Render(http.StatusOK, render.MsgPack{Data: msgPackData(jsonData)})
+	default:
+		writeJSON(c, http.StatusOK, jsonData)
+	}
+}
+
+// albumMsgPack - альбом для msgpack: кодек msgpack не вызывает Album.MarshalJSON,
+// поэтому вычисляемое поле in_stock задается явно (имена полей берутся из тегов json)
+type albumMsgPack struct {
+	domain.Album
+	InStock bool `json:"in_stock"`
+}
+
+// albumWithTracksMsgPack - альбом с треками для msgpack
+type albumWithTracksMsgPack struct {
+	albumMsgPack
+	Tracks []domain.Track `json:"tracks"`
+}
+
+// localizedAlbumMsgPack - альбом на языке покупателя для msgpack (поля как в JSON)
+type localizedAlbumMsgPack struct {
+	albumMsgPack
+	Language      string `json:"language"`
+	OriginalTitle string `json:"original_title"`
+	Notes         string `json:"notes,omitempty"`
+}
+
+// msgPackData - данные ответа для msgpack: альбомы заменяются представлениями с полями из MarshalJSON
+func msgPackData(data any) any {
+	switch v := data.(type) {
+	case *domain.Album:
+		return toAlbumMsgPack(*v)
+	case []domain.Album:
+		albums := make([]albumMsgPack, len(v))
+		for i, album := range v {
+			albums[i] = toAlbumMsgPack(album)
+		}
+		return albums
+	case domain.AlbumWithTracks:
+		return albumWithTracksMsgPack{toAlbumMsgPack(v.Album), v.Tracks}
+	case domain.LocalizedAlbum:
+		return localizedAlbumMsgPack{toAlbumMsgPack(v.Album), v.Language, v.OriginalTitle, v.Notes}
+	}
+	return data
+}
+
+// toAlbumMsgPack - альбом для msgpack
+func toAlbumMsgPack(album domain.Album) albumMsgPack {
+	return albumMsgPack{Album: album, InStock: album.InStock()}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteHandler - внутренние заметки сотрудников к альбомам (только админка)
+type NoteHandler struct {
+	noteService *service.NoteService
+}
+
+// NewNoteHandler - конструктор обработчика заметок
+func NewNoteHandler(noteService *service.NoteService) *NoteHandler {
+	return &NoteHandler{noteService: noteService}
+}
+
+// GetNotes - заметки альбома от старых к новым
+func (h *NoteHandler) GetNotes(c *gin.Context) {
+	notes, err := h.noteService.GetNotes(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, notes)
+}
+
+// addNoteRequest - тело запроса на добавление заметки
+type addNoteRequest struct {
+	Body string `json:"body"`
+}
+
+// AddNote - добавляет заметку к альбому от имени пользователя из токена
+func (h *NoteHandler) AddNote(c *gin.Context) {
+	user := middleware.CurrentUser(c)
+	if user == nil {
+		writeJSON(c, http.StatusUnauthorized, gin.H{"error": service.ErrUnauthenticated.Error()})
+		return
+	}
+
+	var req addNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	note := domain.AlbumNote{AlbumID: c.Param("id"), Author: user.Subject, Body: req.Body}
+	if err := h.noteService.AddNote(&note); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, note)
+}
+
+// DeleteNote - удаляет заметку альбома
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	noteID, err := strconv.ParseInt(c.Param("note_id"), 10, 64)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "invalid note id"})
+		return
+	}
+
+	if err := h.noteService.DeleteNote(c.Param("id"), noteID); err != nil {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"fmt"
+	"go-music-shop/internal/delivery/middleware"
+	domain "go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PartnerHandler - API для партнеров-маркетплейсов: цена и наличие многих альбомов одним запросом
+type PartnerHandler struct {
+	albumService      *service.AlbumService
+	externalIDService *service.ExternalIDService
+	fxService         *service.FXService
+	maxItems          int
+}
+
+// NewPartnerHandler - конструктор обработчика API для партнеров
+func NewPartnerHandler(albumService *service.AlbumService, externalIDService *service.ExternalIDService, fxService *service.FXService, maxItems int) *PartnerHandler {
+	return &PartnerHandler{
+		albumService:      albumService,
+		externalIDService: externalIDService,
+		fxService:         fxService,
+		maxItems:          maxItems,
+	}
+}
+
+// availabilityRequest - тело запроса наличия: наши id альбомов и/или штрихкоды изданий
+type availabilityRequest struct {
+	IDs      []string `json:"ids"`
+	Barcodes []string `json:"barcodes"`
+}
+
+// availabilityItem - цена и наличие одного альбома
+type availabilityItem struct {
+	ID            string  `json:"id"`
+	Barcode       string  `json:"barcode,omitempty"` // Если альбом запрошен по штрихкоду
+	Price         float64 `json:"price"`
+	InStock       bool    `json:"in_stock"`
+	StockQuantity int     `json:"stock_quantity"`
+	Condition     string  `json:"condition,omitempty"`
+}
+
+// availabilityNotFound - id и штрихкоды, по которым альбомов нет
+type availabilityNotFound struct {
+	IDs      []string `json:"ids"`
+	Barcodes []string `json:"barcodes"`
+}
+
+// availabilityResponse - ответ на запрос наличия
+type availabilityResponse struct {
+	Currency string               `json:"currency"`
+	Items    []availabilityItem   `json:"items"`
+	NotFound availabilityNotFound `json:"not_found"`
+}
+
+// GetAvailability - цена, валюта, наличие и состояние альбомов по списку id и штрихкодов
+// Альбомы берутся из кэша одним запросом, недостающие - одним запросом к базе
+func (h *PartnerHandler) GetAvailability(c *gin.Context) {
+	var req availabilityRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	ids, barcodes := uniqueNonEmpty(req.IDs), uniqueNonEmpty(req.Barcodes)
+	if len(ids)+len(barcodes) == 0 {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "ids or barcodes are required"})
+		return
+	}
+	if len(ids)+len(barcodes) > h.maxItems {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("too many items, at most %d per request", h.maxItems)})
+		return
+	}
+
+	byBarcode, err := h.externalIDService.FindAlbumIDs(domain.ExternalSystemBarcode, barcodes)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	lookup := append([]string{}, ids...)
+	for _, barcode := range barcodes {
+		if id, ok := byBarcode[barcode]; ok {
+			lookup = append(lookup, id)
+		}
+	}
+
+	albums, err := h.albumService.GetAlbumsByIDs(uniqueNonEmpty(lookup))
+	if err != nil {
+		log.Printf("partner %s availability error: %v", middleware.CurrentPartner(c), err)
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": "failed to load albums"})
+		return
+	}
+	albums = convertPrices(c, h.fxService, albums)
+
+	byID := make(map[string]domain.Album, len(albums))
+	for _, album := range albums {
+		byID[album.ID] = album
+	}
+
+	resp := availabilityResponse{
+		Currency: c.Writer.Header().Get("Content-Currency"),
+		Items:    make([]availabilityItem, 0, len(ids)+len(barcodes)),
+		NotFound: availabilityNotFound{IDs: []string{}, Barcodes: []string{}},
+	}
+	for _, id := range ids {
+		album, ok := byID[id]
+		if !ok {
+			resp.NotFound.IDs = append(resp.NotFound.IDs, id)
+			continue
+		}
+		resp.Items = append(resp.Items, newAvailabilityItem(album, ""))
+	}
+	for _, barcode := range barcodes {
+		album, ok := byID[byBarcode[barcode]]
+		if !ok {
+			resp.NotFound.Barcodes = append(resp.NotFound.Barcodes, barcode)
+			continue
+		}
+		resp.Items = append(resp.Items, newAvailabilityItem(album, barcode))
+	}
+
+	writeJSON(c, http.StatusOK, resp)
+}
+
+// newAvailabilityItem - цена и наличие альбома для ответа партнеру
+func newAvailabilityItem(album domain.Album, barcode string) availabilityItem {
+	return availabilityItem{
+		ID:            album.ID,
+		Barcode:       barcode,
+		Price:         album.Price,
+		InStock:       album.InStock(),
+		StockQuantity: album.StockQuantity,
+		Condition:     album.Condition,
+	}
+}
+
+// uniqueNonEmpty - значения без пустых и повторов, в исходном порядке
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
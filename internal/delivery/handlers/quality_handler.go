@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QualityHandler - админский обработчик отчета о качестве каталога
+type QualityHandler struct {
+	qualityService *service.QualityService
+}
+
+// NewQualityHandler - конструктор обработчика качества каталога
+func NewQualityHandler(qualityService *service.QualityService) *QualityHandler {
+	return &QualityHandler{qualityService: qualityService}
+}
+
+// GetReport - обработчик для отчета о качестве каталога
+// ?issue=zero_price оставляет только альбомы с этой проблемой, ?limit= ограничивает список
+func (h *QualityHandler) GetReport(c *gin.Context) {
+	const (
+		defaultLimit = 100
+		maxLimit     = 1000
+	)
+
+	report, err := h.qualityService.Report()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if code := c.Query("issue"); code != "" {
+		items := report.Items[:0]
+		for _, item := range report.Items {
+			for _, issue := range item.Issues {
+				if issue.Code == code {
+					items = append(items, item)
+					break
+				}
+			}
+		}
+		report.Items = items
+	}
+
+	if limit := queryInt(c, "limit", defaultLimit, maxLimit); len(report.Items) > limit {
+		report.Items = report.Items[:limit]
+	}
+
+	writeJSON(c, http.StatusOK, report)
+}
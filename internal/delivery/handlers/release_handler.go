@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReleaseHandler - даты выхода альбомов и календарь релизов (iCalendar)
+type ReleaseHandler struct {
+	releaseService *service.ReleaseService
+}
+
+// NewReleaseHandler - конструктор обработчика релизов
+func NewReleaseHandler(releaseService *service.ReleaseService) *ReleaseHandler {
+	return &ReleaseHandler{releaseService: releaseService}
+}
+
+// setReleaseDateRequest - тело запроса на изменение даты выхода ("" - снять дату)
+type setReleaseDateRequest struct {
+	ReleaseDate string `json:"release_date"`
+}
+
+// SetReleaseDate - обработчик для изменения даты выхода альбома
+func (h *ReleaseHandler) SetReleaseDate(c *gin.Context) {
+	var req setReleaseDateRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	if err := h.releaseService.SetReleaseDate(c.Param("id"), req.ReleaseDate); err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetReleasesICS - календарь предстоящих релизов для подписки в календарях (?artist=, ?genre=)
+func (h *ReleaseHandler) GetReleasesICS(c *gin.Context) {
+	releases, err := h.releaseService.GetUpcomingReleases(c.Query("artist"), c.Query("genre"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(buildReleasesICS(releases, time.Now())))
+}
+
+// buildReleasesICS - собирает календарь в формате iCalendar (RFC 5545)
+// Каждый релиз - событие на весь день выхода
+func buildReleasesICS(releases []domain.Release, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) { writeICSLine(&b, s) }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//go-music-shop//Release Calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Upcoming releases")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, release := range releases {
+		line("BEGIN:VEVENT")
+		line("UID:release-" + release.AlbumID + "@go-music-shop")
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + release.ReleaseDate.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + release.ReleaseDate.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICSText(fmt.Sprintf("%s - %s", release.Artist, release.Title)))
+		if release.Genre != "" {
+			line("CATEGORIES:" + escapeICSText(release.Genre))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// icsTextEscaper - спецсимволы текстовых полей iCalendar
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeICSText - экранирует текст для полей SUMMARY, CATEGORIES и т.п.
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// writeICSLine - пишет строку iCalendar, перенося ее по 75 байт (продолжение начинается с пробела)
+func writeICSLine(b *strings.Builder, s string) {
+	maxLen := 75
+	for len(s) > maxLen {
+		// Не разрываем многобайтовый символ UTF-8
+		cut := maxLen
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		maxLen = 74 // Пробел в начале продолжения тоже считается
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
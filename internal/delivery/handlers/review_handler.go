@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler - отзывы покупателей к альбомам
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+// NewReviewHandler - конструктор обработчика отзывов
+func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService}
+}
+
+// reviewPage - страница отзывов альбома
+type reviewPage struct {
+	Reviews []domain.Review `json:"reviews"`
+	Total   int             `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// GetReviews - отзывы альбома от новых к старым (?limit=, ?offset=)
+func (h *ReviewHandler) GetReviews(c *gin.Context) {
+	limit := queryInt(c, "limit", 20, 100)
+	offset := queryInt(c, "offset", 0, 1000000)
+
+	reviews, total, err := h.reviewService.GetReviews(c.Param("id"), limit, offset)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, reviewPage{Reviews: reviews, Total: total, Limit: limit, Offset: offset})
+}
+
+// addReviewRequest - тело запроса на добавление отзыва
+type addReviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+// AddReview - добавляет отзыв от имени покупателя из токена
+func (h *ReviewHandler) AddReview(c *gin.Context) {
+	user := middleware.CurrentUser(c)
+	if user == nil {
+		writeJSON(c, http.StatusUnauthorized, gin.H{"error": service.ErrUnauthenticated.Error()})
+		return
+	}
+
+	var req addReviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	review := domain.Review{AlbumID: c.Param("id"), CustomerID: user.Subject, Rating: req.Rating, Text: req.Text}
+	if err := h.reviewService.AddReview(&review); err != nil {
+		writeReviewError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, review)
+}
+
+// DeleteReview - удаляет свой отзыв к альбому
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	user := middleware.CurrentUser(c)
+	if user == nil {
+		writeJSON(c, http.StatusUnauthorized, gin.H{"error": service.ErrUnauthenticated.Error()})
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Param("id"), user.Subject); err != nil {
+		writeReviewError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeReviewError - ответ на ошибку изменения отзыва
+// Повторный отзыв - 409: свой отзыв можно удалить и оставить заново
+func writeReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDuplicateReview):
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/service"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler - обработчик поиска по каталогу
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+// NewSearchHandler - конструктор обработчика поиска
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search - обработчик для поиска альбомов
+// Параметры: q - строка поиска, поддерживает поля и диапазоны (artist:"Miles Davis" year:1959..1965 -reissue);
+// limit (по умолчанию 50, не больше service.MaxAlbumsPageSize) и offset - страница результатов
+// Всего найдено - в meta.total и в заголовке X-Total-Count, как у списка альбомов
+func (h *SearchHandler) Search(c *gin.Context) {
+	const defaultLimit = 50
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	limit := queryInt(c, "limit", defaultLimit, service.MaxAlbumsPageSize)
+	offset := queryInt(c, "offset", 0, math.MaxInt32)
+
+	result, err := h.searchService.Search(query, limit, offset)
+	if errors.Is(err, service.ErrInvalidQuery) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(result.Meta.Total))
+	writeJSON(c, http.StatusOK, result)
+}
+
+// GetZeroResultSearches - отчет для закупок: что покупатели искали, но не нашли в каталоге
+// Параметры: days - период в днях (по умолчанию 30), limit - количество запросов (по умолчанию 100)
+func (h *SearchHandler) GetZeroResultSearches(c *gin.Context) {
+	days := queryInt(c, "days", 30, 365)
+	limit := queryInt(c, "limit", 100, 1000)
+
+	searches, err := h.searchService.ZeroResultSearches(days, limit)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, searches)
+}
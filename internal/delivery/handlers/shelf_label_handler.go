@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/shelflabel"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxShelfLabelsBatch - сколько этикеток можно напечатать одним запросом
+const maxShelfLabelsBatch = 200
+
+// ShelfLabelHandler - печать ценников на полки в PDF
+type ShelfLabelHandler struct {
+	albumService *service.AlbumService
+	fxService    *service.FXService // Валюта цены на ценнике - базовая валюта каталога
+	template     shelflabel.Template
+}
+
+// NewShelfLabelHandler - конструктор обработчика ценников
+func NewShelfLabelHandler(albumService *service.AlbumService, fxService *service.FXService, cfg config.ShelfLabelConfig) *ShelfLabelHandler {
+	return &ShelfLabelHandler{
+		albumService: albumService,
+		fxService:    fxService,
+		template: shelflabel.Template{
+			StoreName:     cfg.StoreName,
+			WidthMM:       float64(cfg.WidthMM),
+			HeightMM:      float64(cfg.HeightMM),
+			ShowCondition: cfg.ShowCondition,
+		},
+	}
+}
+
+// GetLabel - ценник одного альбома (/admin/albums/:id/label.pdf)
+func (h *ShelfLabelHandler) GetLabel(c *gin.Context) {
+	h.render(c, []string{c.Param("id")}, "label-"+c.Param("id")+".pdf")
+}
+
+// GetLabels - ценники нескольких альбомов, по одному на страницу (/admin/shelf-labels.pdf?ids=1,2,3)
+func (h *ShelfLabelHandler) GetLabels(c *gin.Context) {
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > maxShelfLabelsBatch {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ids must list 1 to %d album IDs", maxShelfLabelsBatch)})
+		return
+	}
+
+	h.render(c, ids, "shelf-labels.pdf")
+}
+
+// render - собирает этикетки альбомов и отдает PDF
+func (h *ShelfLabelHandler) render(c *gin.Context, ids []string, filename string) {
+	labels := make([]shelflabel.Label, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		album, err := h.albumService.GetAlbumByID(id)
+		if err != nil {
+			missing = append(missing, id)
+			continue
+		}
+		labels = append(labels, h.label(album))
+	}
+	if len(missing) > 0 {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "albums not found", "ids": missing})
+		return
+	}
+
+	// Рендерим в буфер: ошибку еще можно отдать JSON, пока ответ не начался
+	var pdf bytes.Buffer
+	if err := shelflabel.Render(&pdf, h.template, labels); err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", pdf.Bytes())
+}
+
+// label - данные этикетки альбома; штрихкод - наш id альбома
+func (h *ShelfLabelHandler) label(album *domain.Album) shelflabel.Label {
+	return shelflabel.Label{
+		Artist:    album.Artist,
+		Title:     album.Title,
+		Price:     fmt.Sprintf("%.2f %s", album.Price, h.fxService.BaseCurrency()),
+		Condition: album.Condition,
+		Code:      album.ID,
+	}
+}
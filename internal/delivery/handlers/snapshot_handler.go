@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SnapshotHandler - админский обработчик снимков каталога
+type SnapshotHandler struct {
+	snapshotService *service.SnapshotService
+}
+
+// NewSnapshotHandler - конструктор обработчика снимков
+func NewSnapshotHandler(snapshotService *service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{snapshotService: snapshotService}
+}
+
+// CreateSnapshot - обработчик для создания снимка текущего каталога
+func (h *SnapshotHandler) CreateSnapshot(c *gin.Context) {
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	info, err := h.snapshotService.CreateSnapshot(c.Request.Context(), actor)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, info)
+}
+
+// ListSnapshots - обработчик для получения списка снимков
+func (h *SnapshotHandler) ListSnapshots(c *gin.Context) {
+	snapshots, err := h.snapshotService.ListSnapshots(c.Request.Context())
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, snapshots)
+}
+
+// PreviewRestore - обработчик для предпросмотра восстановления: что изменится в каталоге
+func (h *SnapshotHandler) PreviewRestore(c *gin.Context) {
+	diff, err := h.snapshotService.PreviewRestore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeSnapshotError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, diff)
+}
+
+// restoreRequest - тело запроса на восстановление (защита от случайного вызова)
+type restoreRequest struct {
+	Confirm bool `json:"confirm"`
+}
+
+// Restore - обработчик для восстановления каталога из снимка
+// Требует {"confirm": true}; перед восстановлением стоит посмотреть предпросмотр
+func (h *SnapshotHandler) Restore(c *gin.Context) {
+	var req restoreRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	if !req.Confirm {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": `restore must be confirmed with {"confirm": true}`})
+		return
+	}
+
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	if err := h.snapshotService.Restore(c.Request.Context(), c.Param("id"), actor); err != nil {
+		writeSnapshotError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, gin.H{"restored": c.Param("id")})
+}
+
+// writeSnapshotError - 404 для несуществующего снимка, 500 для остальных ошибок
+func writeSnapshotError(c *gin.Context, err error) {
+	if errors.Is(err, storage.ErrNotFound) {
+		writeJSON(c, http.StatusNotFound, gin.H{"error": "snapshot not found"})
+		return
+	}
+	writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
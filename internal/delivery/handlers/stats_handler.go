@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler - обработчик статистики каталога
+type StatsHandler struct {
+	statsService *service.StatsService
+}
+
+// NewStatsHandler - конструктор обработчика статистики
+func NewStatsHandler(statsService *service.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+// statsResponse - статистика вместе с информацией о ее свежести
+type statsResponse struct {
+	*domain.AlbumStats
+	AgeSeconds int64 `json:"age_seconds"` // Сколько секунд назад статистика была пересчитана
+}
+
+// GetAlbumStats - обработчик для получения статистики каталога
+func (h *StatsHandler) GetAlbumStats(c *gin.Context) {
+	stats, age, err := h.statsService.GetStats()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, statsResponse{AlbumStats: stats, AgeSeconds: int64(age.Seconds())})
+}
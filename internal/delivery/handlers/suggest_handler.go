@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuggestHandler - обработчик автодополнения поиска
+type SuggestHandler struct {
+	suggestService *service.SuggestService
+}
+
+// NewSuggestHandler - конструктор обработчика подсказок
+func NewSuggestHandler(suggestService *service.SuggestService) *SuggestHandler {
+	return &SuggestHandler{suggestService: suggestService}
+}
+
+// Suggest - обработчик для получения подсказок по началу строки поиска
+// Параметры: q - введенный текст, limit - количество подсказок (по умолчанию 10)
+func (h *SuggestHandler) Suggest(c *gin.Context) {
+	limit := queryInt(c, "limit", 10, 20)
+
+	suggestions, err := h.suggestService.Suggest(c.Query("q"), limit)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, suggestions)
+}
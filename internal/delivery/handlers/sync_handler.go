@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncHandler - синхронизация каталога для офлайн-режима мобильного приложения
+type SyncHandler struct {
+	syncService *service.SyncService
+}
+
+// NewSyncHandler - конструктор обработчика синхронизации
+func NewSyncHandler(syncService *service.SyncService) *SyncHandler {
+	return &SyncHandler{syncService: syncService}
+}
+
+// GetChanges - изменения каталога после курсора (?since=, ?limit= по умолчанию 500)
+func (h *SyncHandler) GetChanges(c *gin.Context) {
+	batch, err := h.syncService.GetChanges(c.Query("since"), queryInt(c, "limit", 500, 1000))
+	if errors.Is(err, service.ErrInvalidCursor) {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, batch)
+}
+
+// GetChecksum - контрольная сумма каталога: если не совпадает с локальной, клиент синхронизируется заново
+func (h *SyncHandler) GetChecksum(c *gin.Context) {
+	checksum, err := h.syncService.GetChecksum()
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, checksum)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrackHandler - список треков альбомов
+type TrackHandler struct {
+	trackService *service.TrackService
+}
+
+// NewTrackHandler - конструктор обработчика треков
+func NewTrackHandler(trackService *service.TrackService) *TrackHandler {
+	return &TrackHandler{trackService: trackService}
+}
+
+// trackRequest - трек в теле запроса
+type trackRequest struct {
+	Position        int    `json:"position"`
+	Title           string `json:"title"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+// track - трек альбома из запроса
+func (r trackRequest) track(albumID string) domain.Track {
+	return domain.Track{AlbumID: albumID, Position: r.Position, Title: r.Title, DurationSeconds: r.DurationSeconds}
+}
+
+// GetTracks - треки альбома по порядку
+func (h *TrackHandler) GetTracks(c *gin.Context) {
+	tracks, err := h.trackService.GetTracks(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, tracks)
+}
+
+// replaceTracksRequest - новый список треков альбома
+type replaceTracksRequest struct {
+	Tracks []trackRequest `json:"tracks"`
+}
+
+// ReplaceTracks - заменяет весь список треков альбома (пустой список удаляет все треки)
+func (h *TrackHandler) ReplaceTracks(c *gin.Context) {
+	var req replaceTracksRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	albumID := c.Param("id")
+	tracks := make([]domain.Track, len(req.Tracks))
+	for i, track := range req.Tracks {
+		tracks[i] = track.track(albumID)
+	}
+
+	if err := h.trackService.ReplaceTracks(albumID, tracks); err != nil {
+		writeTrackError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, tracks)
+}
+
+// AddTrack - добавляет трек к альбому (без position - в конец списка)
+func (h *TrackHandler) AddTrack(c *gin.Context) {
+	var req trackRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	track := req.track(c.Param("id"))
+	if err := h.trackService.AddTrack(&track); err != nil {
+		writeTrackError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, track)
+}
+
+// UpdateTrack - изменяет позицию, название и длительность трека
+func (h *TrackHandler) UpdateTrack(c *gin.Context) {
+	trackID, err := strconv.ParseInt(c.Param("track_id"), 10, 64)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "invalid track id"})
+		return
+	}
+
+	var req trackRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	track := req.track(c.Param("id"))
+	track.ID = trackID
+	if err := h.trackService.UpdateTrack(&track); err != nil {
+		writeTrackError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, track)
+}
+
+// DeleteTrack - удаляет трек альбома
+func (h *TrackHandler) DeleteTrack(c *gin.Context) {
+	trackID, err := strconv.ParseInt(c.Param("track_id"), 10, 64)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "invalid track id"})
+		return
+	}
+
+	if err := h.trackService.DeleteTrack(c.Param("id"), trackID); err != nil {
+		writeTrackError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeTrackError - ответ на ошибку изменения треков
+func writeTrackError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDuplicateTrackPosition):
+		writeJSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
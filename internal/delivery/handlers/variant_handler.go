@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"go-music-shop/internal/delivery/middleware"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VariantHandler - издания альбомов (формат, тираж, цена и количество)
+type VariantHandler struct {
+	albumService *service.AlbumService
+}
+
+// NewVariantHandler - конструктор обработчика изданий
+func NewVariantHandler(albumService *service.AlbumService) *VariantHandler {
+	return &VariantHandler{albumService: albumService}
+}
+
+// variantRequest - издание в теле запроса
+type variantRequest struct {
+	Format        string  `json:"format"`
+	PressingYear  int     `json:"pressing_year"`
+	WeightGrams   int     `json:"weight_grams"`
+	SpeedRPM      int     `json:"speed_rpm"`
+	Price         float64 `json:"price"`
+	StockQuantity int     `json:"stock_quantity"`
+}
+
+// variant - издание альбома из запроса
+func (r variantRequest) variant(albumID string) domain.AlbumVariant {
+	return domain.AlbumVariant{
+		AlbumID:       albumID,
+		Format:        r.Format,
+		PressingYear:  r.PressingYear,
+		WeightGrams:   r.WeightGrams,
+		SpeedRPM:      r.SpeedRPM,
+		Price:         r.Price,
+		StockQuantity: r.StockQuantity,
+	}
+}
+
+// variantResponse - измененное издание и альбом с пересчитанными ценой и количеством
+type variantResponse struct {
+	Variant *domain.AlbumVariant `json:"variant,omitempty"`
+	Album   *domain.Album        `json:"album"`
+}
+
+// GetVariants - издания альбома
+func (h *VariantHandler) GetVariants(c *gin.Context) {
+	variants, err := h.albumService.GetVariants(c.Param("id"))
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, variants)
+}
+
+// AddVariant - добавляет издание альбома
+func (h *VariantHandler) AddVariant(c *gin.Context) {
+	var req variantRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	variant := req.variant(c.Param("id"))
+	album, err := h.albumService.AddVariant(&variant, confirmPriceChange(c))
+	if err != nil {
+		writeVariantError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, variantResponse{Variant: &variant, Album: album})
+}
+
+// UpdateVariant - изменяет издание альбома целиком
+func (h *VariantHandler) UpdateVariant(c *gin.Context) {
+	variantID, ok := variantIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req variantRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	variant := req.variant(c.Param("id"))
+	variant.ID = variantID
+	album, err := h.albumService.UpdateVariant(&variant, confirmPriceChange(c))
+	if err != nil {
+		writeVariantError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, variantResponse{Variant: &variant, Album: album})
+}
+
+// DeleteVariant - удаляет издание альбома
+func (h *VariantHandler) DeleteVariant(c *gin.Context) {
+	variantID, ok := variantIDParam(c)
+	if !ok {
+		return
+	}
+
+	album, err := h.albumService.DeleteVariant(c.Param("id"), variantID)
+	if err != nil {
+		writeVariantError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, variantResponse{Album: album})
+}
+
+// AdjustStock - приход и списание экземпляров издания (доступен сотрудникам склада)
+func (h *VariantHandler) AdjustStock(c *gin.Context) {
+	variantID, ok := variantIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req adjustStockRequest
+	if err := bindJSON(c, &req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+
+	var actor string
+	if user := middleware.CurrentUser(c); user != nil {
+		actor = user.Subject
+	}
+
+	variant, album, err := h.albumService.AdjustVariantStock(c.Param("id"), variantID, req.Delta, req.Reason, actor)
+	if err != nil {
+		writeStockError(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, variantResponse{Variant: variant, Album: album})
+}
+
+// variantIDParam - id издания из пути; при ошибке ответ уже записан
+func variantIDParam(c *gin.Context) (int64, bool) {
+	variantID, err := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+	if err != nil {
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": "invalid variant id"})
+		return 0, false
+	}
+	return variantID, true
+}
+
+// writeVariantError - ответ на ошибку изменения изданий
+func writeVariantError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPriceConfirmationRequired):
+		writeAlbumWriteError(c, err)
+	case strings.Contains(err.Error(), "not found"):
+		writeJSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		writeJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"go-music-shop/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ViewHandler - обработчик просмотров альбомов
+type ViewHandler struct {
+	viewService *service.ViewService
+}
+
+// NewViewHandler - конструктор обработчика просмотров
+func NewViewHandler(viewService *service.ViewService) *ViewHandler {
+	return &ViewHandler{viewService: viewService}
+}
+
+// TrackView - middleware для страницы альбома: учитывает просмотр после успешного ответа
+func (h *ViewHandler) TrackView(c *gin.Context) {
+	c.Next()
+
+	if c.Writer.Status() == http.StatusOK {
+		h.viewService.RecordView(c.Param("id"))
+	}
+}
+
+// GetTrending - обработчик для получения популярных альбомов
+// Параметры: days - период в днях (по умолчанию 7), limit - количество (по умолчанию 10)
+func (h *ViewHandler) GetTrending(c *gin.Context) {
+	days := queryInt(c, "days", 7, 90)
+	limit := queryInt(c, "limit", 10, 100)
+
+	albums, err := h.viewService.GetTrending(days, limit)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, albums)
+}
+
+// GetAlbumViews - обработчик для получения просмотров альбома по дням (для админки)
+func (h *ViewHandler) GetAlbumViews(c *gin.Context) {
+	days := queryInt(c, "days", 30, 365)
+
+	views, err := h.viewService.GetAlbumViews(c.Param("id"), days)
+	if err != nil {
+		writeJSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, views)
+}
+
+// queryInt - читает положительный числовой параметр запроса с ограничением сверху
+func queryInt(c *gin.Context, name string, def, max int) int {
+	value, err := strconv.Atoi(c.Query(name))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return min(value, max)
+}
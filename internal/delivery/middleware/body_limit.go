@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodySize - ограничивает размер тела запроса
+// При превышении лимита чтение тела вернет ошибку и биндинг JSON завершится с 400
+// routeLimits - лимиты отдельных маршрутов по шаблону пути gin (например "/albums/:id/cover"), заменяют общий
+func MaxBodySize(maxBytes int64, routeLimits map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := maxBytes
+		if routeLimit, ok := routeLimits[c.FullPath()]; ok {
+			limit = routeLimit
+		}
+
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
@@ -0,0 +1,83 @@
+// Middleware - общие обработчики для HTTP запросов (заголовки, ограничения и т.д.)
+package middleware
+
+import (
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/pkg/cdn"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl - добавляет заголовки кэширования к публичным ответам на чтение,
+// чтобы браузеры и CDN (Fastly/Cloudflare) могли кэшировать каталог
+// Ответы помечаются тегами (Surrogate-Key у Fastly, Cache-Tag у Cloudflare): после изменения альбома
+// сервис очищает по тегу все копии - с любыми параметрами запроса (?cursor=, ?sort=, ?include=) и подресурсы
+func CacheControl(cfg config.HTTPCacheConfig) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", cfg.MaxAge, cfg.StaleWhileRevalidate)
+	surrogateControl := fmt.Sprintf("max-age=%d, stale-while-revalidate=%d", cfg.SurrogateMaxAge, cfg.StaleWhileRevalidate)
+
+	return func(c *gin.Context) {
+		// Кэшируем только запросы на чтение
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		// Ответ зависит от этих заголовков запроса - CDN должен хранить отдельные копии
+		c.Header("Vary", "Accept, Accept-Encoding, Accept-Language, Accept-Currency")
+
+		c.Writer = &cacheHeaderWriter{
+			ResponseWriter:   c.Writer,
+			cacheControl:     cacheControl,
+			surrogateControl: surrogateControl,
+			tags:             cacheTags(c),
+		}
+		c.Next()
+	}
+}
+
+// cacheHeaderWriter - выставляет заголовки кэширования в момент записи статуса,
+// когда уже известно успешный ли ответ (ошибки кэшировать нельзя)
+type cacheHeaderWriter struct {
+	gin.ResponseWriter
+	cacheControl     string
+	surrogateControl string
+	tags             []string // Теги CDN для очистки кэша
+}
+
+// cacheTags - теги ответа по маршруту: страница альбома и его подресурсы - тег альбома,
+// все остальное (списки, поиск, исполнители) - общий тег каталога
+func cacheTags(c *gin.Context) []string {
+	if id := c.Param("id"); id != "" {
+		return []string{cdn.AlbumTag(id)}
+	}
+
+	tags := []string{cdn.CatalogTag}
+	if artist := c.Param("artist"); artist != "" {
+		tags = append(tags, cdn.ArtistTag(artist))
+	}
+	return tags
+}
+
+// WriteHeader - добавляет заголовки кэширования перед отправкой статуса
+func (w *cacheHeaderWriter) WriteHeader(code int) {
+	if !w.Written() {
+		if code == http.StatusOK {
+			w.Header().Set("Cache-Control", w.cacheControl)
+			w.Header().Set("Surrogate-Control", w.surrogateControl)
+			w.Header().Set("Surrogate-Key", strings.Join(w.tags, " "))
+			w.Header().Set("Cache-Tag", strings.Join(w.tags, ","))
+		} else {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap - дает http.ResponseController доступ к исходному writer (дедлайны, flush)
+func (w *cacheHeaderWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/monitoring"
+	"go-music-shop/internal/service"
+	"go-music-shop/pkg/auth"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugCaptureHeader - заголовок, которым можно принудительно записать конкретный запрос
+// Учитывается только с токеном сотрудника или администратора (разрешение service.PermDebugCapture)
+const DebugCaptureHeader = "X-Debug-Capture"
+
+// DebugCapture - записывает выборку запросов и ответов целиком для разбора проблем
+// Персональные данные и секреты скрываются до сохранения - по своим спискам и по правилам
+// маскирования логов (masker, nil - только свои списки). Должен стоять после RequestID
+// authCfg нужен, чтобы проверить токен того, кто просит записать запрос заголовком
+func DebugCapture(cfg config.DebugCaptureConfig, authCfg config.AuthConfig, buffer *monitoring.CaptureBuffer, masker *monitoring.Masker) gin.HandlerFunc {
+	redactor := monitoring.NewRedactor(cfg.RedactFields, cfg.RedactHeaders)
+	if masker != nil {
+		redactor.SetMasker(masker)
+	}
+
+	return func(c *gin.Context) {
+		if !shouldCapture(c, cfg.SamplePercent, authCfg) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		// Читаем начало тела и возвращаем его обратно, чтобы обработчик получил запрос целиком
+		var requestBody []byte
+		truncated := false
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, cfg.MaxBodyBytes+1))
+			if int64(len(requestBody)) > cfg.MaxBodyBytes {
+				requestBody = requestBody[:cfg.MaxBodyBytes]
+				truncated = true
+			}
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+		}
+		// Заголовки и параметры снимаем до обработчика: он может их изменить
+		requestHeaders := redactor.Headers(c.Request.Header)
+		query := redactor.Query(c.Request.URL.Query())
+
+		writer := &captureWriter{ResponseWriter: c.Writer, limit: cfg.MaxBodyBytes}
+		c.Writer = writer
+		c.Next()
+
+		if truncated || writer.truncated {
+			// Обрезанный JSON не разобрать, а сохранять его без скрытия полей нельзя
+			requestBody, writer.body = nil, bytes.Buffer{}
+		}
+
+		buffer.Add(&monitoring.CapturedRequest{
+			RequestID:       c.GetString(RequestIDKey),
+			Time:            start.UTC(),
+			Method:          c.Request.Method,
+			Path:            c.Request.URL.Path,
+			Query:           query,
+			RequestHeaders:  requestHeaders,
+			RequestBody:     redactor.Body(c.ContentType(), requestBody),
+			Status:          writer.Status(),
+			ResponseHeaders: redactor.Headers(writer.Header()),
+			ResponseBody:    redactor.Body(writer.Header().Get("Content-Type"), writer.body.Bytes()),
+			DurationMs:      time.Since(start).Milliseconds(),
+			Truncated:       truncated || writer.truncated,
+		})
+	}
+}
+
+// shouldCapture - попадает ли запрос в выборку
+// Служебные эндпоинты не записываем, чтобы просмотр записей не вытеснял их из буфера
+func shouldCapture(c *gin.Context, samplePercent int, authCfg config.AuthConfig) bool {
+	if strings.HasPrefix(c.Request.URL.Path, "/internal/") {
+		return false
+	}
+	if c.GetHeader(DebugCaptureHeader) == "1" && canForceCapture(c, authCfg) {
+		return true
+	}
+	return samplePercent > 0 && rand.IntN(100) < samplePercent
+}
+
+// canForceCapture - может ли автор запроса записать его заголовком: иначе любой клиент
+// заполнял бы буфер своими запросами, вытесняя выборку
+// Middleware стоит до проверки токена на маршрутах, поэтому токен проверяется здесь
+func canForceCapture(c *gin.Context, authCfg config.AuthConfig) bool {
+	if authCfg.Disabled {
+		return true
+	}
+	if authCfg.JWTSecret == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	claims, err := auth.Verify([]byte(authCfg.JWTSecret), strings.TrimSpace(token))
+	if err != nil {
+		return false
+	}
+	return service.Authorize(claims, service.PermDebugCapture) == nil
+}
+
+// readCloser - тело запроса, прочитанное частично: отдаем сохраненное начало и остаток оригинала
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter - копирует начало тела ответа для записи, не мешая отправке клиенту
+type captureWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+// Write - отправляет данные клиенту и сохраняет копию в пределах лимита
+func (w *captureWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString - то же, что Write, для строк (используется gin при рендеринге)
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// capture - сохраняет копию данных, пока не достигнут лимит
+func (w *captureWriter) capture(data []byte) {
+	remaining := w.limit - int64(w.body.Len())
+	if int64(len(data)) > remaining {
+		data = data[:max(remaining, 0)]
+		w.truncated = true
+	}
+	w.body.Write(data)
+}
+
+// Unwrap - дает http.ResponseController доступ к исходному writer (дедлайны, flush)
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"go-music-shop/internal/config"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Priority - класс важности эндпоинта при перегрузке
+type Priority int
+
+const (
+	PriorityCritical   Priority = iota // Изменения каталога: не отклоняем, пока есть хоть один свободный слот
+	PriorityNormal                     // Просмотр каталога
+	PriorityBestEffort                 // Выгрузки и отчеты: первыми отклоняются при нагрузке
+)
+
+// LoadShedder - общий лимит одновременно обрабатываемых запросов с долями по приоритетам
+// Менее важные запросы могут занять только часть слотов, поэтому тяжелые отчеты
+// не вытесняют изменения каталога и не занимают весь пул подключений к БД
+type LoadShedder struct {
+	inFlight   atomic.Int64
+	limits     map[Priority]int64
+	retryAfter string
+}
+
+// NewLoadShedder - создает лимитер; при MaxConcurrent == 0 запросы не ограничиваются
+func NewLoadShedder(cfg config.LoadSheddingConfig) *LoadShedder {
+	maxConcurrent := int64(cfg.MaxConcurrent)
+	return &LoadShedder{
+		limits: map[Priority]int64{
+			PriorityCritical:   maxConcurrent,
+			PriorityNormal:     maxConcurrent * int64(cfg.NormalPercent) / 100,
+			PriorityBestEffort: maxConcurrent * int64(cfg.BestEffortPercent) / 100,
+		},
+		retryAfter: strconv.Itoa(cfg.RetryAfter),
+	}
+}
+
+// Limit - пропускает запрос, если для его приоритета есть свободный слот, иначе отвечает 503
+func (s *LoadShedder) Limit(priority Priority) gin.HandlerFunc {
+	limit := s.limits[priority]
+
+	return func(c *gin.Context) {
+		if s.limits[PriorityCritical] == 0 {
+			c.Next()
+			return
+		}
+
+		if s.inFlight.Add(1) > limit {
+			s.inFlight.Add(-1)
+			c.Header("Retry-After", s.retryAfter)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "server is overloaded, try again later"})
+			return
+		}
+		defer s.inFlight.Add(-1)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PartnerKey - ключ в контексте запроса с именем партнера, чей API ключ передан
+const PartnerKey = "partner"
+
+// ParsePartnerKeys - ключи партнеров из настроек в виде "имя:ключ" (ключ -> имя)
+// Записи без имени или ключа пропускаются с предупреждением
+func ParsePartnerKeys(entries []string) map[string]string {
+	keys := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, key, ok := strings.Cut(entry, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			log.Printf("skipping partner api key %q: expected name:key", name)
+			continue
+		}
+		keys[key] = name
+	}
+	return keys
+}
+
+// PartnerAuth - пускает только запросы с API ключом партнера в заголовке X-API-Key
+// и кладет имя партнера в контекст запроса (по нему считается лимит частоты запросов)
+func PartnerAuth(keys map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name, ok := keys[c.GetHeader("X-API-Key")]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing api key"})
+			return
+		}
+
+		c.Set(PartnerKey, name)
+		c.Next()
+	}
+}
+
+// CurrentPartner - имя партнера из контекста запроса (пусто - запрос без ключа партнера)
+func CurrentPartner(c *gin.Context) string {
+	return c.GetString(PartnerKey)
+}
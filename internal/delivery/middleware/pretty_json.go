@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// PrettyJSONKey - ключ в контексте запроса: true если ответ нужно отформатировать с отступами
+const PrettyJSONKey = "pretty_json"
+
+// PrettyJSON - включает форматированный JSON для запроса
+// По умолчанию ответы компактные (форматирование заметно медленнее и увеличивает размер),
+// отступы добавляются только если они включены в конфигурации или запрошены через ?pretty=1
+func PrettyJSON(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if enabled || c.Query("pretty") == "1" {
+			c.Set(PrettyJSONKey, true)
+		}
+		c.Next()
+	}
+}
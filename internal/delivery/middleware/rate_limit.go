@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/monitoring"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitIdleTimeout - через сколько без запросов забываем клиента
+const rateLimitIdleTimeout = 5 * time.Minute
+
+// rateLimit - действующий лимит частоты запросов одного клиента
+type rateLimit struct {
+	rps   float64 // Запросов в секунду (0 - без ограничения)
+	burst float64 // Сколько запросов можно сделать подряд сверх среднего
+}
+
+// tokenBucket - "ведро" токенов одного клиента
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimiter - ограничение частоты запросов одного клиента (token bucket)
+// Клиент - IP или партнер по API ключу; лимит можно ужесточить на время (распродажа)
+// и вернуть обратно без перезапуска
+type RateLimiter struct {
+	base    rateLimit
+	current atomic.Pointer[rateLimit]
+	metrics *monitoring.TrafficMetrics
+	key     func(c *gin.Context) string // Как определить клиента
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// NewIPRateLimiter - создает лимитер по IP клиента; при RequestsPerSecond == 0 запросы
+// не ограничиваются, пока лимит не задан через Tighten
+func NewIPRateLimiter(cfg config.RateLimitConfig, metrics *monitoring.TrafficMetrics) *RateLimiter {
+	return NewRateLimiter(cfg, metrics, (*gin.Context).ClientIP)
+}
+
+// NewRateLimiter - создает лимитер, который различает клиентов функцией key
+func NewRateLimiter(cfg config.RateLimitConfig, metrics *monitoring.TrafficMetrics, key func(c *gin.Context) string) *RateLimiter {
+	l := &RateLimiter{
+		base:      newRateLimit(cfg.RequestsPerSecond, cfg.Burst),
+		metrics:   metrics,
+		key:       key,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+	l.current.Store(&l.base)
+	return l
+}
+
+// newRateLimit - лимит из настроек; burst не может быть меньше одного запроса
+func newRateLimit(rps, burst int) rateLimit {
+	return rateLimit{rps: float64(rps), burst: math.Max(float64(burst), 1)}
+}
+
+// Tighten - временно заменяет лимит (более строгий на время распродажи)
+func (l *RateLimiter) Tighten(rps, burst int) {
+	limit := newRateLimit(rps, burst)
+	l.current.Store(&limit)
+}
+
+// Reset - возвращает лимит из конфигурации
+func (l *RateLimiter) Reset() {
+	l.current.Store(&l.base)
+}
+
+// Limit - считает запросы и отклоняет их с 429, если клиент превысил лимит
+func (l *RateLimiter) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l.metrics.Request()
+
+		limit := l.current.Load()
+		if limit.rps == 0 {
+			c.Next()
+			return
+		}
+
+		if wait := l.take(l.key(c), limit); wait > 0 {
+			l.metrics.RateLimited()
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, slow down"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// take - берет токен из ведра клиента; возвращает, сколько ждать, если токенов нет
+func (l *RateLimiter) take(client string, limit *rateLimit) time.Duration {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: limit.burst}
+		l.buckets[client] = bucket
+	} else {
+		bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * limit.rps
+		bucket.tokens = math.Min(bucket.tokens, limit.burst) // Лимит мог стать строже - излишек сгорает
+	}
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / limit.rps * float64(time.Second))
+	}
+	bucket.tokens--
+	return 0
+}
+
+// sweep - раз в минуту удаляет клиентов, которые давно не заходили, чтобы карта не росла бесконечно
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for client, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > rateLimitIdleTimeout {
+			delete(l.buckets, client)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadOnly - отклоняет запросы на изменение в режиме только для чтения (реплики на edge)
+// Клиент получает 405 и адрес основного экземпляра, который принимает изменения
+func ReadOnly(primaryURL string) gin.HandlerFunc {
+	primaryURL = strings.TrimRight(primaryURL, "/")
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		c.Header("Allow", "GET, HEAD, OPTIONS")
+		body := gin.H{"error": "this instance is read-only"}
+		if primaryURL != "" {
+			body["primary"] = primaryURL + c.Request.URL.RequestURI()
+		}
+		c.AbortWithStatusJSON(http.StatusMethodNotAllowed, body)
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDKey - ключ в контексте запроса с его идентификатором
+	RequestIDKey = "request_id"
+	// RequestIDHeader - заголовок с идентификатором запроса (принимаем от прокси и возвращаем клиенту)
+	RequestIDHeader = "X-Request-ID"
+)
+
+// requestIDPattern - допустимый ID от клиента: без пробелов и спецсимволов, чтобы его можно было писать в логи
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID - присваивает запросу идентификатор (или берет его из X-Request-ID) и возвращает его в ответе
+// По этому ID клиент и поддержка находят запрос в логах и отладочных записях
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// newRequestID - случайный идентификатор запроса
+func newRequestID() string {
+	b := make([]byte, 12)
+	rand.Read(b) // Никогда не возвращает ошибку
+	return hex.EncodeToString(b)
+}
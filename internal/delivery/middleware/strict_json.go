@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// StrictJSONKey - ключ в контексте запроса: true если неизвестные поля в JSON теле нужно отклонять
+const StrictJSONKey = "strict_json"
+
+// StrictJSON - включает строгий разбор JSON тел запросов (POST/PUT)
+// В строгом режиме опечатки клиентов ("prise" вместо "price") дают 400 вместо молча потерянного поля;
+// в нестрогом неизвестные поля игнорируются (удобно, пока клиенты переходят на новую версию API)
+func StrictJSON(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if enabled {
+			c.Set(StrictJSONKey, true)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+// Конвертация доменных моделей в protobuf сообщения (используется gRPC и REST)
+package protoconv
+
+import (
+	"go-music-shop/internal/domain/models"
+	"time"
+
+	catalogpb "go-music-shop/pkg/gen/catalog"
+)
+
+// AlbumToProto конвертирует domain.Album в catalogpb.Album
+func AlbumToProto(album *domain.Album) *catalogpb.Album {
+	return &catalogpb.Album{
+		Id:            album.ID,
+		Title:         album.Title,
+		Artist:        album.Artist,
+		Price:         album.Price,
+		Year:          int32(album.Year),
+		Genre:         album.Genre,
+		Condition:     album.Condition,
+		InStock:       album.InStock(),
+		CreatedAt:     formatTimestamp(album.CreatedAt),
+		UpdatedAt:     formatTimestamp(album.UpdatedAt),
+		StockQuantity: int32(album.StockQuantity),
+		AverageRating: album.AverageRating,
+		ReviewCount:   int32(album.ReviewCount),
+		LabelId:       album.LabelID,
+		CatalogNumber: album.CatalogNumber,
+		Description:   album.Description,
+		CoverUrl:      album.CoverURL,
+	}
+}
+
+// AlbumsToProto конвертирует слайс domain альбомов в protobuf альбомы
+func AlbumsToProto(albums []domain.Album) []*catalogpb.Album {
+	pbAlbums := make([]*catalogpb.Album, len(albums))
+	for i := range albums {
+		pbAlbums[i] = AlbumToProto(&albums[i])
+	}
+	return pbAlbums
+}
+
+// TracksToProto конвертирует треки альбома в protobuf треки
+func TracksToProto(tracks []domain.Track) []*catalogpb.Track {
+	pbTracks := make([]*catalogpb.Track, len(tracks))
+	for i, track := range tracks {
+		pbTracks[i] = &catalogpb.Track{
+			Id:              track.ID,
+			Position:        int32(track.Position),
+			Title:           track.Title,
+			DurationSeconds: int32(track.DurationSeconds),
+		}
+	}
+	return pbTracks
+}
+
+// formatTimestamp - форматирует время в RFC3339 (UTC), для нулевого времени возвращает пустую строку
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"strings"
+	"time"
+)
 
 // album represents data about a record album.
 type Album struct {
@@ -8,12 +14,128 @@ type Album struct {
 	Title  string  `json:"title" validate:"required"`
 	Artist string  `json:"artist" validate:"required"`
 	Price  float64 `json:"price" validate:"min=0"`
-	Year int `json:"year"`
-	Genre string `json:"genre"`
-	Condition string `json:"condition"` // "mint", "very good", "good", "fair"
-	InStock bool `json:"in_stock"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Year int `json:"year,omitempty"`
+	Genre string `json:"genre,omitempty"`
+	Condition string `json:"condition,omitempty"` // "mint", "very good", "good", "fair"
+	StockQuantity int `json:"stock_quantity" validate:"min=0"` // Экземпляров на складе; in_stock в JSON вычисляется из него
+	AverageRating float64 `json:"average_rating"` // Средняя оценка покупателей (0 - отзывов нет)
+	ReviewCount int `json:"review_count"`
+	LabelID string `json:"label_id,omitempty"` // Лейбл назначается отдельно: PUT /admin/albums/:id/label
+	CatalogNumber string `json:"catalog_number,omitempty"` // Каталожный номер издания на лейбле, например "BLP 1577"
+	Description string `json:"description,omitempty"` // Описание для страницы товара: markdown, HTML допускается только безопасный
+	CoverURL string `json:"cover_url,omitempty"` // Обложка загружается отдельно: POST /albums/:id/cover
+	CreatedAt time.Time `json:"created_at,omitzero"` // Не сериализуем нулевое время (0001-01-01)
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// ErrInsufficientStock - на складе меньше экземпляров, чем нужно списать
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// InStock - есть ли альбом на складе
+func (a Album) InStock() bool {
+	return a.StockQuantity > 0
+}
+
+// StockFromInStock - количество на складе по флагу in_stock от клиентов, которые еще не передают stock_quantity:
+// true сохраняет текущее количество (или ставит 1, если альбома не было), false обнуляет его
+func StockFromInStock(inStock bool, current int) int {
+	if !inStock {
+		return 0
+	}
+	return max(current, 1)
+}
+
+// MarshalJSON - сериализует альбом, приводя время к UTC (RFC3339 с суффиксом Z),
+// чтобы клиенты получали одинаковый формат независимо от часового пояса БД
+// in_stock отдается для совместимости с клиентами, которые не знают про stock_quantity
+func (a Album) MarshalJSON() ([]byte, error) {
+	type albumJSON Album // Отдельный тип без метода MarshalJSON, чтобы избежать рекурсии
+
+	v := albumJSON(a)
+	v.CreatedAt = v.CreatedAt.UTC()
+	v.UpdatedAt = v.UpdatedAt.UTC()
+
+	return json.Marshal(struct {
+		albumJSON
+		InStock bool `json:"in_stock"`
+	}{v, a.InStock()})
+}
+
+// marshalAlbumWith - сериализует альбом вместе с дополнительными полями в один JSON объект
+// Нужен типам, которые встраивают Album: иначе они наследуют Album.MarshalJSON и теряют свои поля
+func marshalAlbumWith(album Album, extra any) ([]byte, error) {
+	albumData, err := json.Marshal(album)
+	if err != nil {
+		return nil, err
+	}
+	extraData, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	if len(extraData) <= 2 { // "{}"
+		return albumData, nil
+	}
+
+	// {"id":...} + {"views":...} -> {"id":...,"views":...}
+	data := append(albumData[:len(albumData)-1], ',')
+	return append(data, extraData[1:]...), nil
+}
+
+// AlbumFilter - отбор альбомов для списка каталога (пустые поля не ограничивают)
+type AlbumFilter struct {
+	Genre     string // Точное совпадение без учета регистра
+	Condition string
+	YearFrom  int
+	YearTo    int
+	PriceMin  float64
+	PriceMax  float64
+	InStock   *bool
+	Sort      []AlbumSort // Порядок списка (пусто - сначала новые)
+	After     *AlbumCursor // Только альбомы после курсора (для порядка по умолчанию)
+}
+
+// AlbumCursor - позиция в списке альбомов в порядке по умолчанию (created_at DESC, id DESC)
+type AlbumCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Before - идет ли курсор раньше альбома в порядке по умолчанию
+func (c AlbumCursor) Before(a Album) bool {
+	if !a.CreatedAt.Equal(c.CreatedAt) {
+		return a.CreatedAt.Before(c.CreatedAt)
+	}
+	return a.ID < c.ID
+}
+
+// AlbumSortFields - поля, по которым можно сортировать список альбомов
+var AlbumSortFields = []string{"title", "artist", "price", "year", "created_at", "updated_at"}
+
+// AlbumSort - сортировка по одному полю из AlbumSortFields
+type AlbumSort struct {
+	Field string
+	Desc  bool
+}
+
+// Match - подходит ли альбом под условия фильтра (для хранилищ без SQL); курсор не учитывается
+func (f AlbumFilter) Match(a Album) bool {
+	switch {
+	case f.Genre != "" && !strings.EqualFold(a.Genre, f.Genre):
+		return false
+	case f.Condition != "" && a.Condition != f.Condition:
+		return false
+	case f.YearFrom != 0 && a.Year < f.YearFrom:
+		return false
+	case f.YearTo != 0 && a.Year > f.YearTo:
+		return false
+	case f.PriceMin != 0 && a.Price < f.PriceMin:
+		return false
+	case f.PriceMax != 0 && a.Price > f.PriceMax:
+		return false
+	case f.InStock != nil && a.InStock() != *f.InStock:
+		return false
+	}
+	return true
 }
 
 // AlbumRepository - интерфейс для работы с хранилищем альбомов.
@@ -21,9 +143,24 @@ type Album struct {
 type AlbumRepository interface {
 	GetAll() ([]Album, error)
 	GetByID(id string) (*Album, error)
+	// GetByIDs - альбомы с указанными id одним запросом; ненайденные id пропускаются
+	GetByIDs(ids []string) ([]Album, error)
 	Create(album *Album) error
 	Update(album *Album) error
 	Delete(id string) error
 	GetByArtist(artist string) ([]Album, error)
 	GetInStock()([]Album, error) // альбомы в наличии
-}
\ No newline at end of file
+	// AdjustStock - атомарно меняет количество на складе на delta и возвращает альбом после изменения
+	// Если экземпляров меньше, чем нужно списать, возвращает ErrInsufficientStock
+	AdjustStock(id string, delta int) (*Album, error)
+	// GetPage - страница альбомов, подходящих под фильтр, в порядке GetAll и общее количество таких альбомов
+	GetPage(filter AlbumFilter, limit, offset int) ([]Album, int, error)
+	// IterateAll - последовательно отдает все альбомы, не загружая весь каталог в память
+	IterateAll() iter.Seq2[Album, error]
+}
+
+// RawAlbumReader - необязательное расширение репозитория: отдает альбом сразу в виде JSON
+// Реализуется кэширующим репозиторием, чтобы не распаковывать и не упаковывать JSON на каждый запрос
+type RawAlbumReader interface {
+	GetRawByID(id string) ([]byte, error)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "fmt"
+
+// ArchiveCountMismatchError - под фильтр подходит не столько альбомов, сколько ожидал клиент; ничего не архивировано
+type ArchiveCountMismatchError struct {
+	Expected int
+	Matched  int
+}
+
+func (e *ArchiveCountMismatchError) Error() string {
+	return fmt.Sprintf("filter matches %d albums, expected %d: nothing archived", e.Matched, e.Expected)
+}
+
+// AlbumArchiveRepository - интерфейс архивирования (мягкого удаления) альбомов
+type AlbumArchiveRepository interface {
+	// ArchiveMatching - архивирует альбомы под фильтром одной транзакцией вместе с записями аудита
+	// (по одной на альбом, Details берутся из audit); если альбомов не ровно expected,
+	// транзакция откатывается и возвращается *ArchiveCountMismatchError
+	ArchiveMatching(filter AlbumFilter, expected int, audit AuditEntry) ([]Album, error)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// AuditEntry - запись журнала аудита
+type AuditEntry struct {
+	ID         int64          `json:"id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Action     string         `json:"action"`      // Например "price_change_rejected"
+	EntityType string         `json:"entity_type"` // Например "album"
+	EntityID   string         `json:"entity_id"`
+	Details    map[string]any `json:"details"`
+}
+
+// AuditRepository - интерфейс журнала аудита
+type AuditRepository interface {
+	Record(entry AuditEntry) error
+	// List - последние записи; пустые entityType/entityID - без фильтра
+	List(entityType, entityID string, limit int) ([]AuditEntry, error)
+}
@@ -0,0 +1,63 @@
+package domain
+
+// AdminAlbum - альбом со складскими данными: закупочная цена, маржа, место хранения (только для админки)
+// Отдельный тип, чтобы эти данные не попали в публичные ответы и кэш
+type AdminAlbum struct {
+	Album
+	CostPrice      *float64 `json:"cost_price"`      // nil - закупочная цена не указана
+	Margin         *float64 `json:"margin"`          // price - cost_price
+	MarginPercent  *float64 `json:"margin_percent"`  // Маржа в процентах от цены продажи
+	BinCode        string   `json:"bin_code"`        // Стеллаж/ячейка на складе, "" - не назначено
+	ConditionNotes string   `json:"condition_notes"` // Внутренние заметки о состоянии ("царапина на стороне B"), покупателям не отдаются
+}
+
+// MarshalJSON - поля альбома и складские данные одним объектом
+func (a AdminAlbum) MarshalJSON() ([]byte, error) {
+	return marshalAlbumWith(a.Album, struct {
+		CostPrice      *float64 `json:"cost_price"`
+		Margin         *float64 `json:"margin"`
+		MarginPercent  *float64 `json:"margin_percent"`
+		BinCode        string   `json:"bin_code"`
+		ConditionNotes string   `json:"condition_notes"`
+	}{a.CostPrice, a.Margin, a.MarginPercent, a.BinCode, a.ConditionNotes})
+}
+
+// CalculateMargin - пересчитывает маржу по цене и закупочной цене
+func (a *AdminAlbum) CalculateMargin() {
+	a.Margin, a.MarginPercent = nil, nil
+	if a.CostPrice == nil {
+		return
+	}
+
+	margin := a.Price - *a.CostPrice
+	a.Margin = &margin
+	if a.Price > 0 {
+		percent := margin / a.Price * 100
+		a.MarginPercent = &percent
+	}
+}
+
+// StockValuation - оценка склада по ценам продажи и закупки
+type StockValuation struct {
+	InStockAlbums   int     `json:"in_stock_albums"`
+	InStockCopies   int     `json:"in_stock_copies"`   // Экземпляров на складе всего
+	RetailValue     float64 `json:"retail_value"`      // Сумма цен продажи всех экземпляров на складе
+	CostValue       float64 `json:"cost_value"`        // Сумма закупочных цен (где они указаны)
+	Margin          float64 `json:"margin"`            // Маржа по альбомам с указанной закупочной ценой
+	MissingCostData int     `json:"missing_cost_data"` // Альбомы в наличии без закупочной цены
+}
+
+// AlbumCostRepository - интерфейс для работы с закупочными ценами
+type AlbumCostRepository interface {
+	GetAll() ([]AdminAlbum, error)
+	GetByID(id string) (*AdminAlbum, error)
+	SetCostPrice(id string, costPrice *float64) error
+	SetConditionNotes(id, notes string) error
+	GetValuation() (*StockValuation, error)
+}
+
+// AlbumBinRepository - интерфейс для работы с местами хранения на складе
+type AlbumBinRepository interface {
+	SetBin(id, binCode string) error
+	GetByBin(binCode string) ([]AdminAlbum, error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+// CoverRepository - интерфейс для работы с обложками альбомов
+type CoverRepository interface {
+	// SetCoverURL - заменяет адрес обложки (пусто - обложки нет) и возвращает прежний адрес и альбом
+	SetCoverURL(albumID, coverURL string) (previous string, album *Album, err error)
+}
@@ -0,0 +1,25 @@
+package domain
+
+// Внешние системы, id которых можно привязать к альбому
+const (
+	ExternalSystemDiscogs     = "discogs"
+	ExternalSystemMusicBrainz = "musicbrainz"
+	ExternalSystemShopify     = "shopify"
+	ExternalSystemPOS         = "pos"     // Старая кассовая система
+	ExternalSystemBarcode     = "barcode" // Штрихкод издания (UPC/EAN), по нему ищут партнеры-маркетплейсы
+)
+
+// ExternalSystems - поддерживаемые внешние системы
+var ExternalSystems = []string{ExternalSystemDiscogs, ExternalSystemMusicBrainz, ExternalSystemShopify, ExternalSystemPOS, ExternalSystemBarcode}
+
+// AlbumExternalIDRepository - интерфейс для работы с внешними id альбомов
+type AlbumExternalIDRepository interface {
+	// GetExternalIDs - внешние id альбома (система -> id)
+	GetExternalIDs(albumID string) (map[string]string, error)
+	SetExternalID(albumID, system, externalID string) error
+	DeleteExternalID(albumID, system string) error
+	// FindAlbumID - наш id альбома по внешнему id
+	FindAlbumID(system, externalID string) (string, error)
+	// FindAlbumIDs - наши id альбомов по списку внешних id (внешний id -> id); ненайденные пропускаются
+	FindAlbumIDs(system string, externalIDs []string) (map[string]string, error)
+}
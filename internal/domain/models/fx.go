@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// ExchangeRates - курсы валют относительно базовой валюты каталога
+type ExchangeRates struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`     // Курсы от провайдера
+	Overrides map[string]float64 `json:"overrides"` // Курсы, заданные вручную (важнее курсов провайдера)
+	FetchedAt time.Time          `json:"fetched_at,omitzero"`
+	Stale     bool               `json:"stale"` // Курсы провайдера устарели и не используются
+}
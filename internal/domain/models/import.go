@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// ImportMapping - какие колонки файла соответствуют полям альбома (поле -> заголовок колонки)
+type ImportMapping map[string]string
+
+// ImportRow - строка файла после приведения типов и проверки
+type ImportRow struct {
+	Row    int      `json:"row"` // Номер строки в файле (как в Excel: заголовок обычно строка 1)
+	Album  Album    `json:"album"`
+	Errors []string `json:"errors,omitempty"` // Пусто - строка будет загружена
+}
+
+// ImportPreview - предпросмотр загрузки альбомов из таблицы
+type ImportPreview struct {
+	ID        string        `json:"id"`
+	Columns   []string      `json:"columns"` // Заголовки колонок файла - для выбора соответствия в интерфейсе
+	Mapping   ImportMapping `json:"mapping"` // Использованное соответствие (с подобранными по заголовкам полями)
+	Rows      []ImportRow   `json:"rows"`
+	Valid     int           `json:"valid"`
+	Invalid   int           `json:"invalid"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ImportResult - итог загрузки альбомов по предпросмотру
+type ImportResult struct {
+	ID          string      `json:"id"`
+	Created     []string    `json:"created"` // id созданных альбомов
+	Failed      []ImportRow `json:"failed"`  // Строки, которые не удалось загрузить, с причинами
+	Skipped     int         `json:"skipped"` // Строки с ошибками из предпросмотра
+	CommittedAt time.Time   `json:"committed_at"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Статусы штрихкода в очереди неизвестных
+const (
+	UnknownBarcodePending  = "pending"   // Ждет поиска в Discogs
+	UnknownBarcodeFound    = "found"     // Найден в Discogs - можно заводить альбом
+	UnknownBarcodeNotFound = "not_found" // Discogs его не знает - заводить вручную
+)
+
+// UnknownBarcode - штрихкод, отсканированный при приемке, но не привязанный к альбому
+type UnknownBarcode struct {
+	Barcode          string     `json:"barcode"`
+	Condition        string     `json:"condition,omitempty"` // Из последнего скана
+	Price            *float64   `json:"price,omitempty"`
+	Scans            int        `json:"scans"` // Сколько экземпляров отсканировано
+	FirstScannedAt   time.Time  `json:"first_scanned_at"`
+	LastScannedAt    time.Time  `json:"last_scanned_at"`
+	Status           string     `json:"status"`
+	DiscogsReleaseID int64      `json:"discogs_release_id,omitempty"`
+	Title            string     `json:"title,omitempty"` // Сведения из Discogs
+	Artist           string     `json:"artist,omitempty"`
+	Year             int        `json:"year,omitempty"`
+	Genre            string     `json:"genre,omitempty"`
+	LookedUpAt       *time.Time `json:"looked_up_at,omitempty"`
+}
+
+// UnknownBarcodeRepository - интерфейс очереди неизвестных штрихкодов
+type UnknownBarcodeRepository interface {
+	// Record - добавляет скан: новый штрихкод встает в очередь, известный увеличивает счетчик
+	Record(barcode, condition string, price *float64, at time.Time) error
+	// List - штрихкоды в статусе status (пусто - все) от давно отсканированных к новым
+	List(status string, limit int) ([]UnknownBarcode, error)
+	// SaveLookup - сохраняет результат поиска (статус, сведения из Discogs, looked_up_at)
+	SaveLookup(barcode UnknownBarcode) error
+}
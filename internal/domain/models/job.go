@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// JobState - состояние фоновой задачи
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCanceled  JobState = "canceled"
+)
+
+// Finished - задача завершена (успешно, с ошибкой или отменена)
+func (s JobState) Finished() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCanceled
+}
+
+// Job - долгая операция (выгрузка, переиндексация), выполняемая в фоне
+type Job struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	State      JobState  `json:"state"`
+	Progress   float64   `json:"progress"` // Процент выполнения, 0-100
+	Error      string    `json:"error,omitempty"`
+	ResultKey  string    `json:"-"`                    // Ключ результата в файловом хранилище (пусто - без файла)
+	ResultURL  string    `json:"result_url,omitempty"` // Ссылка на скачивание результата
+	CreatedAt  time.Time `json:"created_at"`
+	StartedAt  time.Time `json:"started_at,omitzero"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+}
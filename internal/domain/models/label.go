@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Label - лейбл звукозаписи
+type Label struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country,omitempty"`      // ISO 3166-1 alpha-2
+	FoundedYear int       `json:"founded_year,omitempty"` // 0 - неизвестно
+	AlbumCount  int       `json:"album_count"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+}
+
+// LabelRepository - интерфейс для работы с лейблами
+type LabelRepository interface {
+	GetAll() ([]Label, error)
+	GetByID(id string) (*Label, error)
+	Create(label *Label) error
+	Update(label *Label) error
+	Delete(id string) error
+	GetAlbums(labelID string) ([]Album, error)
+	// SetAlbumLabel - назначает альбому лейбл ("" - снять лейбл)
+	SetAlbumLabel(albumID, labelID string) error
+}
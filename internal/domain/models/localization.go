@@ -0,0 +1,35 @@
+package domain
+
+// AlbumLocalization - название и примечания альбома на одном языке
+type AlbumLocalization struct {
+	Title string `json:"title,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// AlbumLocalizations - переводы альбома по языкам (тег BCP 47 в нижнем регистре: "ja", "ja-latn", "en")
+type AlbumLocalizations map[string]AlbumLocalization
+
+// LocalizedAlbum - альбом на языке покупателя (GET /albums/:id с Accept-Language)
+type LocalizedAlbum struct {
+	Album                // Title - переведенное название
+	Language      string // Язык перевода
+	OriginalTitle string
+	Notes         string
+}
+
+// MarshalJSON - поля альбома и перевода одним объектом
+func (a LocalizedAlbum) MarshalJSON() ([]byte, error) {
+	return marshalAlbumWith(a.Album, struct {
+		Language      string `json:"language"`
+		OriginalTitle string `json:"original_title"`
+		Notes         string `json:"notes,omitempty"`
+	}{a.Language, a.OriginalTitle, a.Notes})
+}
+
+// LocalizationRepository - интерфейс для работы с переводами альбомов
+type LocalizationRepository interface {
+	// GetLocalizations - переводы альбомов по id; несуществующих альбомов в ответе нет
+	GetLocalizations(albumIDs []string) (map[string]AlbumLocalizations, error)
+	SetLocalization(albumID, language string, localization AlbumLocalization) error
+	DeleteLocalization(albumID, language string) error
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// AlbumNote - внутренняя заметка сотрудника к альбому (только для админки)
+type AlbumNote struct {
+	ID        int64     `json:"id"`
+	AlbumID   string    `json:"album_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	Mentions  []string  `json:"mentions"` // Упомянутые через @имя сотрудники
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AlbumNoteRepository - интерфейс для работы с заметками к альбомам
+type AlbumNoteRepository interface {
+	// GetNotes - заметки альбома от старых к новым
+	GetNotes(albumID string) ([]AlbumNote, error)
+	Create(note *AlbumNote) error
+	Delete(albumID string, noteID int64) error
+}
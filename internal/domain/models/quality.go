@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Коды проблем качества карточек альбомов
+const (
+	QualityMissingGenre     = "missing_genre"
+	QualityMissingCondition = "missing_condition"
+	QualityMissingYear      = "missing_year"
+	QualityImplausibleYear  = "implausible_year"
+	QualityZeroPrice        = "zero_price"
+	QualityDuplicateSuspect = "duplicate_suspect" // Тот же исполнитель и название, что у другого альбома
+)
+
+// QualityIssue - одна проблема в карточке альбома
+type QualityIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Penalty int    `json:"penalty"` // Сколько баллов снимается за проблему
+}
+
+// AlbumQuality - оценка карточки альбома: 100 - без проблем, 0 - продавать нельзя
+type AlbumQuality struct {
+	AlbumID string         `json:"album_id"`
+	Title   string         `json:"title"`
+	Artist  string         `json:"artist"`
+	Score   int            `json:"score"`
+	Issues  []QualityIssue `json:"issues"`
+}
+
+// QualityReport - отчет о качестве каталога
+type QualityReport struct {
+	Score       float64        `json:"score"` // Средняя оценка по всем альбомам
+	Albums      int            `json:"albums"`
+	Flagged     int            `json:"flagged"`      // Альбомов хотя бы с одной проблемой
+	IssueCounts map[string]int `json:"issue_counts"` // Количество альбомов по кодам проблем
+	Items       []AlbumQuality `json:"items"`        // Альбомы с проблемами, начиная с худших
+	GeneratedAt time.Time      `json:"generated_at"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Release - предстоящий выход альбома (для календаря релизов)
+type Release struct {
+	AlbumID     string    `json:"album_id"`
+	Title       string    `json:"title"`
+	Artist      string    `json:"artist"`
+	Genre       string    `json:"genre,omitempty"`
+	ReleaseDate time.Time `json:"release_date"`
+}
+
+// ReleaseFilter - отбор релизов для календаря (пустые поля не ограничивают)
+type ReleaseFilter struct {
+	Artist string
+	Genre  string
+	From   time.Time // Релизы начиная с этой даты
+	Limit  int
+}
+
+// AlbumReleaseRepository - интерфейс для работы с датами выхода альбомов
+type AlbumReleaseRepository interface {
+	SetReleaseDate(id string, date *time.Time) error // nil - снять дату
+	GetReleases(filter ReleaseFilter) ([]Release, error)
+}
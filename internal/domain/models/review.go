@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Review - отзыв покупателя к альбому
+type Review struct {
+	ID         int64     `json:"id"`
+	AlbumID    string    `json:"album_id"`
+	CustomerID string    `json:"customer_id"`
+	Rating     int       `json:"rating"` // От 1 до 5
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ErrDuplicateReview - покупатель уже оставил отзыв к этому альбому
+var ErrDuplicateReview = errors.New("review already exists")
+
+// ReviewRepository - интерфейс для работы с отзывами
+// Create и Delete пересчитывают average_rating и review_count альбома
+type ReviewRepository interface {
+	// GetReviews - отзывы альбома от новых к старым и общее их количество
+	GetReviews(albumID string, limit, offset int) ([]Review, int, error)
+	Create(review *Review) error
+	// Delete - удаляет отзыв покупателя к альбому
+	Delete(albumID, customerID string) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// SearchFilter - условия поиска альбомов (строится из строки запроса в сервисном слое)
+// Пустые поля не участвуют в поиске
+type SearchFilter struct {
+	Terms          []string // Слова/фразы, которые должны быть в исполнителе, названии, лейбле или каталожном номере
+	ExcludeTerms   []string // Слова/фразы, которых не должно быть в исполнителе, названии и жанре
+	ExcludeArtists []string // Исполнители, альбомы которых не ищутся (вхождение подстроки)
+	ExcludeGenres  []string // Жанры, альбомы которых не ищутся (вхождение подстроки)
+	Artist         string
+	Title          string
+	Genre          string
+	Label          string // Название лейбла (вхождение подстроки)
+	CatalogNumber  string // Каталожный номер (без учета регистра, пробелов и дефисов)
+	Condition      string
+	YearFrom       int
+	YearTo         int
+	PriceFrom      float64
+	PriceTo        float64
+	InStock        *bool
+}
+
+// SearchMeta - служебная информация о результатах поиска
+type SearchMeta struct {
+	Query      string   `json:"query"`
+	Total      int      `json:"total"` // Сколько всего альбомов нашлось (на странице - не больше limit)
+	Limit      int      `json:"limit"`
+	Offset     int      `json:"offset"`
+	DidYouMean []string `json:"did_you_mean,omitempty"` // Исправления запроса, если ничего не нашлось
+}
+
+// SearchResult - результат поиска альбомов
+type SearchResult struct {
+	Albums []Album    `json:"albums"`
+	Meta   SearchMeta `json:"meta"`
+}
+
+// AlbumSearchRepository - интерфейс поиска по каталогу
+type AlbumSearchRepository interface {
+	// Search - страница альбомов, подходящих под все условия фильтра, и общее количество таких альбомов
+	Search(filter SearchFilter, limit, offset int) ([]Album, int, error)
+	// SuggestCorrections - похожие на запрос исполнители и названия (для опечаток)
+	SuggestCorrections(query string, limit int) ([]string, error)
+}
+
+// ZeroResultSearch - запрос, по которому ничего не нашлось, за период (сигнал для закупок)
+type ZeroResultSearch struct {
+	Query          string    `json:"query"`
+	Searches       int64     `json:"searches"` // Сколько раз искали
+	LastSearchedAt time.Time `json:"last_searched_at"`
+}
+
+// ZeroResultSearchRepository - журнал поисков без результатов
+type ZeroResultSearchRepository interface {
+	// Record - учитывает поиск без результатов (query уже нормализован)
+	Record(query string, at time.Time) error
+	// List - самые частые запросы без результатов начиная с since
+	List(since time.Time, limit int) ([]ZeroResultSearch, error)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// SnapshotInfo - снимок каталога в хранилище
+type SnapshotInfo struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`             // Размер архива в байтах
+	Albums    int       `json:"albums,omitempty"` // Количество альбомов (известно только при создании)
+}
+
+// SnapshotChange - отличие текущего каталога от снимка для одного альбома
+type SnapshotChange struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Artist string   `json:"artist"`
+	Fields []string `json:"fields,omitempty"` // Какие поля изменятся (только для changed)
+}
+
+// SnapshotDiff - что произойдет с каталогом при восстановлении снимка
+type SnapshotDiff struct {
+	SnapshotID string           `json:"snapshot_id"`
+	Restored   []SnapshotChange `json:"restored"` // Альбомы, которых сейчас нет - будут созданы
+	Removed    []SnapshotChange `json:"removed"`  // Альбомы, которых нет в снимке - будут удалены
+	Changed    []SnapshotChange `json:"changed"`  // Альбомы, поля которых вернутся к значениям из снимка
+}
+
+// SnapshotRepository - загрузка снимка в промежуточную таблицу и его применение к каталогу
+type SnapshotRepository interface {
+	// Stage - загружает альбомы снимка в промежуточную таблицу и сравнивает с каталогом
+	Stage(albums []AdminAlbum) (*SnapshotDiff, error)
+	// Restore - загружает снимок и в той же транзакции заменяет им каталог и пишет запись аудита
+	Restore(albums []AdminAlbum, audit AuditEntry) error
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// AlbumStats - сводная статистика по каталогу
+type AlbumStats struct {
+	TotalAlbums   int       `json:"total_albums"`
+	InStockAlbums int       `json:"in_stock_albums"`
+	TotalArtists  int       `json:"total_artists"`
+	AvgPrice      float64   `json:"avg_price"`
+	MinPrice      float64   `json:"min_price"`
+	MaxPrice      float64   `json:"max_price"`
+	StockValue    float64   `json:"stock_value"`  // Суммарная стоимость всех экземпляров на складе
+	RefreshedAt   time.Time `json:"refreshed_at"` // Когда статистика была пересчитана
+}
+
+// AlbumStatsRepository - интерфейс для работы с предрассчитанной статистикой каталога
+type AlbumStatsRepository interface {
+	GetStats() (*AlbumStats, error)
+	RefreshStats() error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// SyncAlbum - альбом в ответе синхронизации с номером версии (номер последнего изменения)
+type SyncAlbum struct {
+	Album
+	Version int64 `json:"version"`
+}
+
+// MarshalJSON - поля альбома и версия одним объектом
+func (s SyncAlbum) MarshalJSON() ([]byte, error) {
+	return marshalAlbumWith(s.Album, struct {
+		Version int64 `json:"version"`
+	}{s.Version})
+}
+
+// SyncChange - одно изменение каталога: альбом создан/изменен (Album != nil) или удален
+type SyncChange struct {
+	Seq     int64
+	AlbumID string
+	Album   *SyncAlbum // nil - альбом удален
+}
+
+// SyncChecksum - контрольная сумма каталога для проверки расхождения офлайн-копии
+type SyncChecksum struct {
+	Count    int64  `json:"count"`
+	Checksum string `json:"checksum"` // md5 строк "id:version", отсортированных по id и соединенных запятой
+}
+
+// AlbumTombstone - след удаленного альбома
+type AlbumTombstone struct {
+	AlbumID   string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// AlbumTombstoneReader - поиск следов удаленных альбомов (для повторных запросов на удаление)
+type AlbumTombstoneReader interface {
+	// GetTombstone - последнее удаление альбома; nil, если альбом не удалялся или снова существует
+	GetTombstone(albumID string) (*AlbumTombstone, error)
+}
+
+// AlbumSyncRepository - интерфейс журнала изменений каталога
+type AlbumSyncRepository interface {
+	// GetChanges - изменения с номером больше after в порядке номеров
+	GetChanges(after int64, limit int) ([]SyncChange, error)
+	GetChecksum() (*SyncChecksum, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "errors"
+
+// Track - трек альбома
+type Track struct {
+	ID              int64  `json:"id"`
+	AlbumID         string `json:"album_id"`
+	Position        int    `json:"position"` // Порядковый номер на альбоме, с 1
+	Title           string `json:"title"`
+	DurationSeconds int    `json:"duration_seconds"` // 0 - длительность неизвестна
+}
+
+// ErrDuplicateTrackPosition - на этой позиции альбома уже есть трек
+var ErrDuplicateTrackPosition = errors.New("track position is already taken")
+
+// AlbumWithTracks - альбом вместе со списком треков (GET /albums/:id?include=tracks)
+type AlbumWithTracks struct {
+	Album
+	Tracks []Track `json:"tracks"`
+}
+
+// MarshalJSON - поля альбома и треки одним объектом
+func (a AlbumWithTracks) MarshalJSON() ([]byte, error) {
+	return marshalAlbumWith(a.Album, struct {
+		Tracks []Track `json:"tracks"`
+	}{a.Tracks})
+}
+
+// TrackRepository - интерфейс для работы с треками альбомов
+type TrackRepository interface {
+	// GetTracks - треки альбома по порядку
+	GetTracks(albumID string) ([]Track, error)
+	// ReplaceTracks - заменяет весь список треков альбома одной транзакцией
+	ReplaceTracks(albumID string, tracks []Track) error
+	// Create - добавляет трек; Position == 0 - в конец списка
+	Create(track *Track) error
+	Update(track *Track) error
+	Delete(albumID string, trackID int64) error
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// AlbumVariant - издание альбома: оригинальный тираж, переиздание на LP, CD и т.д.
+// Цена и количество на складе у каждого издания свои; Album.Price и Album.StockQuantity -
+// сводные значения (минимальная цена и сумма количеств), их пересчитывает репозиторий изданий
+type AlbumVariant struct {
+	ID            int64     `json:"id"`
+	AlbumID       string    `json:"album_id"`
+	Format        string    `json:"format"`        // Одно из VariantFormats
+	PressingYear  int       `json:"pressing_year"` // Год тиража; 0 - неизвестен
+	WeightGrams   int       `json:"weight_grams"`  // Вес пластинки (180 г); 0 - неизвестен
+	SpeedRPM      int       `json:"speed_rpm"`     // 33, 45 или 78; 0 - не винил или неизвестна
+	Price         float64   `json:"price"`
+	StockQuantity int       `json:"stock_quantity"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// VariantFormats - форматы изданий (как в CHECK колонки album_variants.format)
+var VariantFormats = []string{"lp", "2lp", "10in", "7in", "cd", "cassette"}
+
+// DefaultVariantFormat - формат издания, которое создается вместе с альбомом
+const DefaultVariantFormat = "lp"
+
+// VariantSpeeds - допустимые скорости винила
+var VariantSpeeds = []int{33, 45, 78}
+
+// IsVinylFormat - издание на виниле (у него бывают скорость и вес)
+func IsVinylFormat(format string) bool {
+	return format != "cd" && format != "cassette"
+}
+
+// ErrVariantRequired - у альбома несколько изданий: цену и количество нужно менять у конкретного издания
+var ErrVariantRequired = errors.New("album has several variants, change price and stock of a specific variant")
+
+// AlbumVariantRepository - интерфейс для работы с изданиями альбомов
+// Все изменения в одной транзакции пересчитывают цену и количество альбома и возвращают альбом после пересчета
+type AlbumVariantRepository interface {
+	// GetVariants - издания альбома в порядке добавления
+	GetVariants(albumID string) ([]AlbumVariant, error)
+	// CreateAlbum - создает альбом вместе с его первым изданием одной транзакцией
+	CreateAlbum(album *Album, variant *AlbumVariant) error
+	// UpdateAlbum - изменяет альбом и цену и количество издания (nil - без изданий) одной транзакцией,
+	// цена и количество альбома с изданиями пересчитываются по изданиям
+	UpdateAlbum(album *Album, variant *AlbumVariant) (*Album, error)
+	Create(variant *AlbumVariant) (*Album, error)
+	Update(variant *AlbumVariant) (*Album, error)
+	// Delete - удаляет издание; после удаления последнего цена и количество остаются у альбома
+	Delete(albumID string, variantID int64) (*Album, error)
+	// AdjustStock - атомарно меняет количество издания на delta (ErrInsufficientStock - не хватает экземпляров)
+	AdjustStock(albumID string, variantID int64, delta int) (*AlbumVariant, *Album, error)
+}
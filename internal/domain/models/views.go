@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// DailyViews - количество просмотров альбома за день
+type DailyViews struct {
+	Day   time.Time `json:"day"`
+	Views int64     `json:"views"`
+}
+
+// AlbumViews - статистика просмотров альбома (для админки)
+type AlbumViews struct {
+	AlbumID    string       `json:"album_id"`
+	TotalViews int64        `json:"total_views"`
+	Daily      []DailyViews `json:"daily"`
+}
+
+// TrendingAlbum - альбом с количеством просмотров за период
+type TrendingAlbum struct {
+	Album
+	Views int64 `json:"views"`
+}
+
+// MarshalJSON - поля альбома и количество просмотров одним объектом
+func (t TrendingAlbum) MarshalJSON() ([]byte, error) {
+	return marshalAlbumWith(t.Album, struct {
+		Views int64 `json:"views"`
+	}{t.Views})
+}
+
+// AlbumViewRepository - интерфейс хранилища просмотров альбомов
+type AlbumViewRepository interface {
+	// AddViews - прибавляет просмотры за день (albumID -> количество) одной пачкой
+	AddViews(day time.Time, counts map[string]int64) error
+	GetViews(albumID string, since time.Time) ([]DailyViews, error)
+	GetTrending(since time.Time, limit int) ([]TrendingAlbum, error)
+}
@@ -0,0 +1,153 @@
+package monitoring
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheMetrics - счетчики эффективности кэша в разрезе типов данных ("id", "artist", ...)
+type CacheMetrics struct {
+	mu        sync.RWMutex
+	counters  map[string]*cacheCounters
+	usage     atomic.Pointer[CacheUsage]          // Последняя оценка занятой кэшем памяти
+	reconcile atomic.Pointer[CacheReconciliation] // Последняя сверка кэша с базой
+}
+
+// CacheReconciliation - результат сверки выборки ключей кэша с базой данных
+type CacheReconciliation struct {
+	Sampled         int                            `json:"sampled"`
+	Divergent       int                            `json:"divergent"`
+	Healed          int                            `json:"healed"` // Удалено расходящихся ключей
+	DivergenceRatio float64                        `json:"divergence_ratio"`
+	Kinds           map[string]*ReconcileKindStats `json:"kinds"`
+	CheckedAt       time.Time                      `json:"checked_at"`
+}
+
+// ReconcileKindStats - результат сверки по одному типу данных
+type ReconcileKindStats struct {
+	Sampled   int `json:"sampled"`
+	Divergent int `json:"divergent"`
+}
+
+// CacheUsage - оценка памяти, занятой кэшем каталога в одном пространстве имен
+// Считается по выборке ключей, поэтому приблизительна
+type CacheUsage struct {
+	Namespace      string    `json:"namespace"`
+	Keys           int       `json:"keys"`
+	SampledKeys    int       `json:"sampled_keys"`
+	EstimatedBytes int64     `json:"estimated_bytes"`
+	MaxBytes       int64     `json:"max_bytes,omitempty"` // Лимит (0 - без лимита)
+	Flushed        bool      `json:"flushed,omitempty"`   // Кэш был сброшен из-за превышения лимита
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// cacheCounters - счетчики одного типа данных
+type cacheCounters struct {
+	hits      atomic.Int64
+	misses    atomic.Int64
+	coalesced atomic.Int64 // Запросы, которые дождались уже идущего запроса в БД вместо своего
+	stale     atomic.Int64 // Записи другой версии схемы кэша (считаются и промахами)
+}
+
+// CacheKindStats - снимок счетчиков одного типа данных
+type CacheKindStats struct {
+	Hits      int64   `json:"hits"`
+	Misses    int64   `json:"misses"`
+	Coalesced int64   `json:"coalesced"`
+	Stale     int64   `json:"stale"`
+	HitRatio  float64 `json:"hit_ratio"`
+}
+
+// NewCacheMetrics - конструктор счетчиков кэша
+func NewCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{counters: make(map[string]*cacheCounters)}
+}
+
+// Hit - данные найдены в кэше
+func (m *CacheMetrics) Hit(kind string) {
+	m.get(kind).hits.Add(1)
+}
+
+// Miss - данных в кэше нет, идем в базу
+func (m *CacheMetrics) Miss(kind string) {
+	m.get(kind).misses.Add(1)
+}
+
+// HitN, MissN - попадания и промахи пакетного чтения (несколько ключей за один запрос)
+func (m *CacheMetrics) HitN(kind string, n int) {
+	m.get(kind).hits.Add(int64(n))
+}
+
+func (m *CacheMetrics) MissN(kind string, n int) {
+	m.get(kind).misses.Add(int64(n))
+}
+
+// Coalesced - запрос объединен с уже выполняющимся запросом в базу
+func (m *CacheMetrics) Coalesced(kind string) {
+	m.get(kind).coalesced.Add(1)
+}
+
+// Stale - в кэше запись другой версии схемы; Miss для нее считается отдельно, при чтении из базы
+func (m *CacheMetrics) Stale(kind string) {
+	m.get(kind).stale.Add(1)
+}
+
+// Snapshot - возвращает текущие значения счетчиков
+func (m *CacheMetrics) Snapshot() map[string]CacheKindStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string]CacheKindStats, len(m.counters))
+	for kind, c := range m.counters {
+		stats := CacheKindStats{
+			Hits:      c.hits.Load(),
+			Misses:    c.misses.Load(),
+			Coalesced: c.coalesced.Load(),
+			Stale:     c.stale.Load(),
+		}
+		if total := stats.Hits + stats.Misses; total > 0 {
+			stats.HitRatio = float64(stats.Hits) / float64(total)
+		}
+		result[kind] = stats
+	}
+	return result
+}
+
+// SetUsage - сохраняет последнюю оценку памяти кэша
+func (m *CacheMetrics) SetUsage(usage *CacheUsage) {
+	m.usage.Store(usage)
+}
+
+// Usage - последняя оценка памяти кэша (nil - еще не оценивалась)
+func (m *CacheMetrics) Usage() *CacheUsage {
+	return m.usage.Load()
+}
+
+// SetReconciliation - сохраняет результат последней сверки кэша с базой
+func (m *CacheMetrics) SetReconciliation(report *CacheReconciliation) {
+	m.reconcile.Store(report)
+}
+
+// Reconciliation - результат последней сверки (nil - сверка еще не выполнялась)
+func (m *CacheMetrics) Reconciliation() *CacheReconciliation {
+	return m.reconcile.Load()
+}
+
+// get - возвращает счетчики типа данных, создавая их при первом обращении
+func (m *CacheMetrics) get(kind string) *cacheCounters {
+	m.mu.RLock()
+	c, ok := m.counters[kind]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok = m.counters[kind]; !ok {
+		c = &cacheCounters{}
+		m.counters[kind] = c
+	}
+	return c
+}
@@ -0,0 +1,181 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedValue - чем заменяются скрытые значения
+const redactedValue = "[REDACTED]"
+
+// CapturedRequest - сохраненные запрос и ответ для отладки
+type CapturedRequest struct {
+	RequestID       string            `json:"request_id"`
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           map[string]string `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	DurationMs      int64             `json:"duration_ms"`
+	Truncated       bool              `json:"truncated,omitempty"` // Тело обрезано по лимиту
+}
+
+// CaptureBuffer - кольцевой буфер последних сохраненных запросов
+// Хранится в памяти процесса: при переполнении старые записи вытесняются
+type CaptureBuffer struct {
+	mu      sync.RWMutex
+	entries []*CapturedRequest
+	next    int // Куда писать следующую запись
+}
+
+// NewCaptureBuffer - создает буфер на size записей
+func NewCaptureBuffer(size int) *CaptureBuffer {
+	return &CaptureBuffer{entries: make([]*CapturedRequest, max(size, 1))}
+}
+
+// Add - сохраняет запрос, вытесняя самый старый
+func (b *CaptureBuffer) Add(capture *CapturedRequest) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = capture
+	b.next = (b.next + 1) % len(b.entries)
+}
+
+// Get - находит сохраненный запрос по ID
+func (b *CaptureBuffer) Get(requestID string) (*CapturedRequest, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, capture := range b.entries {
+		if capture != nil && capture.RequestID == requestID {
+			return capture, true
+		}
+	}
+	return nil, false
+}
+
+// Recent - последние сохраненные запросы, новые первыми
+func (b *CaptureBuffer) Recent(limit int) []*CapturedRequest {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	recent := []*CapturedRequest{}
+	for i := 1; i <= len(b.entries) && len(recent) < limit; i++ {
+		capture := b.entries[(b.next-i+len(b.entries))%len(b.entries)]
+		if capture == nil {
+			break
+		}
+		recent = append(recent, capture)
+	}
+	return recent
+}
+
+// Redactor - скрывает персональные данные и секреты в сохраняемых запросах
+type Redactor struct {
+	fields  map[string]bool // Имена полей JSON и параметров запроса (в нижнем регистре)
+	headers map[string]bool // Имена заголовков (в каноническом виде)
+	masker  *Masker         // Маскирование email, токенов и номеров карт в остальных значениях (nil - нет)
+}
+
+// NewRedactor - создает правила скрытия по списку полей и заголовков
+func NewRedactor(fields, headers []string) *Redactor {
+	r := &Redactor{fields: make(map[string]bool), headers: make(map[string]bool)}
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = true
+	}
+	for _, header := range headers {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	return r
+}
+
+// SetMasker - включает маскирование логов и для записей: поля из списка маскирования
+// скрываются так же, как RedactFields, а в остальных значениях маскируются email, токены и номера карт
+func (r *Redactor) SetMasker(masker *Masker) {
+	r.masker = masker
+	for _, field := range masker.Fields() {
+		r.fields[field] = true
+	}
+}
+
+// Headers - заголовки со скрытыми значениями секретов
+func (r *Redactor) Headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			result[name] = redactedValue
+			continue
+		}
+		result[name] = r.masker.Mask(strings.Join(values, ", "))
+	}
+	return result
+}
+
+// Query - параметры запроса со скрытыми значениями
+func (r *Redactor) Query(query url.Values) map[string]string {
+	if len(query) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(query))
+	for name, values := range query {
+		if r.fields[strings.ToLower(name)] {
+			result[name] = redactedValue
+			continue
+		}
+		result[name] = r.masker.Mask(strings.Join(values, ","))
+	}
+	return result
+}
+
+// Body - тело со скрытыми полями
+// Разбираем только JSON: тела других форматов не сохраняем, так как не можем их проверить
+func (r *Redactor) Body(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !strings.Contains(contentType, "json") {
+		return "[non-JSON body omitted]"
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "[unparsable JSON body omitted]"
+	}
+
+	redacted, err := json.Marshal(r.redactValue(value))
+	if err != nil {
+		return "[unparsable JSON body omitted]"
+	}
+	return string(redacted)
+}
+
+// redactValue - рекурсивно заменяет значения скрываемых полей
+func (r *Redactor) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = r.redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactValue(item)
+		}
+	case string:
+		return r.masker.Mask(v)
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+package monitoring
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Шаблоны персональных данных и секретов, которые маскируются в любом тексте независимо от имени поля
+var (
+	emailPattern  = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(Bearer|Basic)\s+[\w.~+/-]+=*`)
+	jwtPattern    = regexp.MustCompile(`\beyJ[\w-]*\.[\w-]+\.[\w-]+`)
+	// Номер карты: 15, 16 или 19 цифр, можно с пробелами или дефисами; маскируется только при верной
+	// контрольной сумме (Луна). 12 и 13 цифр не проверяем - это штрихкоды UPC/EAN из логов приемки
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){14,18}\b`)
+)
+
+// Masker - маскирует персональные данные и секреты в логах: значения полей из списка
+// (field=value, "field": "value", field: value), а также email, токены и номера карт в любом месте строки
+// Подключается централизованно (log.SetOutput, вывод gin, записи debug capture), чтобы не зависеть
+// от аккуратности каждого вызова логирования
+type Masker struct {
+	fields  map[string]bool
+	pattern *regexp.Regexp // Значения полей из списка; nil - список пуст
+}
+
+// NewMasker - создает маскирование по списку полей (без учета регистра)
+func NewMasker(fields []string) *Masker {
+	m := &Masker{fields: make(map[string]bool, len(fields))}
+
+	var names []string
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || m.fields[field] {
+			continue
+		}
+		m.fields[field] = true
+		names = append(names, regexp.QuoteMeta(field))
+	}
+
+	if len(names) > 0 {
+		// Имя поля (возможно в кавычках), разделитель, значение в кавычках или до пробела/разделителя
+		m.pattern = regexp.MustCompile(`(?i)(\b(?:` + strings.Join(names, "|") + `)"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|[^\s,&;}\]]+)`)
+	}
+	return m
+}
+
+// Fields - маскируемые поля (в нижнем регистре)
+func (m *Masker) Fields() []string {
+	if m == nil {
+		return nil
+	}
+	fields := make([]string, 0, len(m.fields))
+	for field := range m.fields {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Mask - строка с замаскированными значениями; nil Masker возвращает строку как есть
+func (m *Masker) Mask(s string) string {
+	if m == nil {
+		return s
+	}
+
+	if m.pattern != nil {
+		s = m.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			parts := m.pattern.FindStringSubmatch(match)
+			if strings.HasPrefix(parts[2], `"`) {
+				return parts[1] + `"` + redactedValue + `"`
+			}
+			return parts[1] + redactedValue
+		})
+	}
+	s = bearerPattern.ReplaceAllString(s, "$1 "+redactedValue)
+	s = jwtPattern.ReplaceAllString(s, redactedValue)
+	s = emailPattern.ReplaceAllString(s, redactedValue)
+	s = cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if !luhnValid(match) {
+			return match
+		}
+		return redactedValue
+	})
+	return s
+}
+
+// Writer - оборачивает вывод логов маскированием
+// Пакет log пишет каждую запись одним вызовом Write, поэтому строки маскируются целиком
+func (m *Masker) Writer(w io.Writer) io.Writer {
+	if m == nil {
+		return w
+	}
+	return &maskingWriter{masker: m, w: w}
+}
+
+// maskingWriter - вывод логов с маскированием
+type maskingWriter struct {
+	masker *Masker
+	w      io.Writer
+}
+
+// Write - маскирует запись и передает ее дальше; возвращает длину исходной записи,
+// иначе log и gin сочтут запись неполной
+func (w *maskingWriter) Write(p []byte) (int, error) {
+	masked := w.masker.Mask(string(p))
+	if _, err := io.WriteString(w.w, masked); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// luhnValid - проверяет контрольную сумму номера карты (пробелы и дефисы пропускаются)
+func luhnValid(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
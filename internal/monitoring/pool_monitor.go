@@ -0,0 +1,160 @@
+// Пакет для мониторинга состояния инфраструктуры (пулы подключений и т.д.)
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/pkg/alert"
+	"go-music-shop/pkg/redis"
+	"log"
+	"sync"
+	"time"
+)
+
+// PostgresPoolStats - состояние пула подключений к PostgreSQL
+type PostgresPoolStats struct {
+	MaxOpen      int           `json:"max_open"`
+	Open         int           `json:"open"`
+	InUse        int           `json:"in_use"`
+	Idle         int           `json:"idle"`
+	WaitCount    int64         `json:"wait_count"`
+	WaitDuration time.Duration `json:"wait_duration_ns"`
+}
+
+// RedisPoolStats - состояние пула подключений к Redis
+type RedisPoolStats struct {
+	Total        uint32        `json:"total"`
+	Idle         uint32        `json:"idle"`
+	Hits         uint32        `json:"hits"`
+	Misses       uint32        `json:"misses"`
+	Timeouts     uint32        `json:"timeouts"`
+	WaitCount    uint32        `json:"wait_count"`
+	WaitDuration time.Duration `json:"wait_duration_ns"`
+}
+
+// PoolStats - общий снимок состояния пулов
+type PoolStats struct {
+	Postgres  PostgresPoolStats `json:"postgres"`
+	Redis     RedisPoolStats    `json:"redis"`
+	Saturated bool              `json:"saturated"` // Превышен порог ожидания на последней проверке
+}
+
+// PoolMonitor - периодически проверяет пулы и предупреждает об их исчерпании
+type PoolMonitor struct {
+	db            *sql.DB
+	redis         *redis.RedisClient
+	interval      time.Duration
+	waitThreshold time.Duration
+	alerts        *alert.Notifier // Оповещения в чаты команды (nil - только лог)
+
+	mu        sync.Mutex
+	last      PoolStats // Снимок на предыдущей проверке - считаем прирост ожидания за интервал
+	saturated bool
+}
+
+// NewPoolMonitor - конструктор монитора пулов
+func NewPoolMonitor(db *sql.DB, redisClient *redis.RedisClient, cfg config.MonitoringConfig) *PoolMonitor {
+	return &PoolMonitor{
+		db:            db,
+		redis:         redisClient,
+		interval:      time.Duration(cfg.PoolCheckInterval) * time.Second,
+		waitThreshold: time.Duration(cfg.PoolWaitThresholdMs) * time.Millisecond,
+	}
+}
+
+// SetNotifier - включает оповещения об исчерпании пулов
+func (m *PoolMonitor) SetNotifier(notifier *alert.Notifier) {
+	m.alerts = notifier
+}
+
+// Stats - возвращает текущее состояние пулов
+func (m *PoolMonitor) Stats() PoolStats {
+	stats := m.collect()
+
+	m.mu.Lock()
+	stats.Saturated = m.saturated
+	m.mu.Unlock()
+
+	return stats
+}
+
+// Start - запускает периодическую проверку пулов до отмены контекста
+func (m *PoolMonitor) Start(ctx context.Context) {
+	m.last = m.collect()
+
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check()
+			}
+		}
+	}()
+}
+
+// check - сравнивает текущий снимок с предыдущим и пишет предупреждение,
+// если за интервал запросы слишком долго ждали свободного подключения
+func (m *PoolMonitor) check() {
+	current := m.collect()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pgWait := current.Postgres.WaitDuration - m.last.Postgres.WaitDuration
+	redisWait := current.Redis.WaitDuration - m.last.Redis.WaitDuration
+	redisTimeouts := current.Redis.Timeouts - m.last.Redis.Timeouts
+
+	m.saturated = false
+
+	if pgWait > m.waitThreshold {
+		m.saturated = true
+		message := fmt.Sprintf("waited %s for connections in the last %s (in use %d/%d, waits %d)",
+			pgWait, m.interval, current.Postgres.InUse, current.Postgres.MaxOpen,
+			current.Postgres.WaitCount-m.last.Postgres.WaitCount)
+		log.Printf("WARNING: PostgreSQL pool saturated: %s", message)
+		m.alerts.Notify(alert.Alert{Type: alert.TypePoolSaturated, Key: "postgres", Title: "PostgreSQL pool saturated", Message: message})
+	}
+
+	if redisWait > m.waitThreshold || redisTimeouts > 0 {
+		m.saturated = true
+		message := fmt.Sprintf("waited %s for connections in the last %s (timeouts %d, total %d, idle %d)",
+			redisWait, m.interval, redisTimeouts, current.Redis.Total, current.Redis.Idle)
+		log.Printf("WARNING: Redis pool saturated: %s", message)
+		m.alerts.Notify(alert.Alert{Type: alert.TypePoolSaturated, Key: "redis", Title: "Redis pool saturated", Message: message})
+	}
+
+	m.last = current
+}
+
+// collect - собирает статистику пулов
+func (m *PoolMonitor) collect() PoolStats {
+	dbStats := m.db.Stats()
+	redisStats := m.redis.PoolStats()
+
+	return PoolStats{
+		Postgres: PostgresPoolStats{
+			MaxOpen:      dbStats.MaxOpenConnections,
+			Open:         dbStats.OpenConnections,
+			InUse:        dbStats.InUse,
+			Idle:         dbStats.Idle,
+			WaitCount:    dbStats.WaitCount,
+			WaitDuration: dbStats.WaitDuration,
+		},
+		Redis: RedisPoolStats{
+			Total:        redisStats.TotalConns,
+			Idle:         redisStats.IdleConns,
+			Hits:         redisStats.Hits,
+			Misses:       redisStats.Misses,
+			Timeouts:     redisStats.Timeouts,
+			WaitCount:    redisStats.WaitCount,
+			WaitDuration: time.Duration(redisStats.WaitDurationNs),
+		},
+	}
+}
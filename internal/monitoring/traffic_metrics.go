@@ -0,0 +1,70 @@
+package monitoring
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// TrafficMetrics - счетчики публичного трафика: все запросы и отклоненные лимитом частоты
+// Во время распродажи по ним видно, насколько трафик выше обычного
+type TrafficMetrics struct {
+	requests    atomic.Int64
+	rateLimited atomic.Int64
+	last        atomic.Pointer[TrafficRate] // Последний рассчитанный интервал
+}
+
+// TrafficStats - снимок счетчиков трафика
+type TrafficStats struct {
+	Requests    int64        `json:"requests"`
+	RateLimited int64        `json:"rate_limited"`
+	LastPeriod  *TrafficRate `json:"last_period,omitempty"`
+}
+
+// TrafficRate - трафик за один интервал
+type TrafficRate struct {
+	Requests          int64     `json:"requests"`
+	RateLimited       int64     `json:"rate_limited"`
+	RequestsPerSecond float64   `json:"requests_per_second"`
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+}
+
+// NewTrafficMetrics - конструктор счетчиков трафика
+func NewTrafficMetrics() *TrafficMetrics {
+	return &TrafficMetrics{}
+}
+
+// Request - учитывает запрос
+func (m *TrafficMetrics) Request() {
+	m.requests.Add(1)
+}
+
+// RateLimited - учитывает запрос, отклоненный лимитом частоты
+func (m *TrafficMetrics) RateLimited() {
+	m.rateLimited.Add(1)
+}
+
+// Snapshot - текущие значения счетчиков
+func (m *TrafficMetrics) Snapshot() TrafficStats {
+	return TrafficStats{
+		Requests:    m.requests.Load(),
+		RateLimited: m.rateLimited.Load(),
+		LastPeriod:  m.last.Load(),
+	}
+}
+
+// Measure - считает трафик с момента предыдущего снимка prev и запоминает его как последний интервал
+func (m *TrafficMetrics) Measure(prev TrafficStats, from time.Time) (TrafficStats, *TrafficRate) {
+	now := m.Snapshot()
+	rate := &TrafficRate{
+		Requests:    now.Requests - prev.Requests,
+		RateLimited: now.RateLimited - prev.RateLimited,
+		From:        from,
+		To:          time.Now(),
+	}
+	if seconds := rate.To.Sub(from).Seconds(); seconds > 0 {
+		rate.RequestsPerSecond = float64(rate.Requests) / seconds
+	}
+	m.last.Store(rate)
+	return now, rate
+}
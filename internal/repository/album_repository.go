@@ -2,8 +2,12 @@
 package repository
 
 import (
+	"cmp"
+	"crypto/rand"
 	"fmt"
 	"go-music-shop/internal/domain/models"
+	"iter"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,16 +25,16 @@ func NewMemoryAlbumRepository() *MemoryAlbumRepository {
 	return &MemoryAlbumRepository{
 		albums: []domain.Album{
 			{
-				ID:        "1",
-				Title:     "Blue Train",
-				Artist:    "John Coltrane",
-				Price:     56.99,
-				Year:      1957,
-				Genre:     "Hard Bop",
-				Condition: "mint",
-				InStock:   true,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+				ID:            "1",
+				Title:         "Blue Train",
+				Artist:        "John Coltrane",
+				Price:         56.99,
+				Year:          1957,
+				Genre:         "Hard Bop",
+				Condition:     "mint",
+				StockQuantity: 1,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
 			},
 			//TODO: ...
 		}, // Явная инициализация mu: sync.RWMutex{} не требуется — она произойдет автоматически.
@@ -44,6 +48,67 @@ func (r *MemoryAlbumRepository) GetAll() ([]domain.Album, error) {
 	return r.albums, nil
 }
 
+// GetPage - страница альбомов, подходящих под фильтр, и общее количество таких альбомов
+func (r *MemoryAlbumRepository) GetPage(filter domain.AlbumFilter, limit, offset int) ([]domain.Album, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []domain.Album
+	for _, album := range r.albums {
+		if filter.Match(album) {
+			matched = append(matched, album)
+		}
+	}
+
+	sortAlbums(matched, filter.Sort)
+	total := len(matched) // Как и в PostgreSQL - размер всего списка, без учета курсора
+
+	if filter.After != nil {
+		matched = slices.DeleteFunc(matched, func(a domain.Album) bool { return !filter.After.Before(a) })
+	}
+
+	start := min(offset, len(matched))
+	end := min(start+limit, len(matched))
+	return matched[start:end], total, nil
+}
+
+// sortAlbums - сортирует альбомы так же, как PostgresAlbumRepository.GetPage:
+// по заданным полям, по умолчанию сначала новые, при равенстве - по id
+func sortAlbums(albums []domain.Album, order []domain.AlbumSort) {
+	if len(order) == 0 {
+		order = []domain.AlbumSort{{Field: "created_at", Desc: true}, {Field: "id", Desc: true}}
+	}
+
+	slices.SortStableFunc(albums, func(a, b domain.Album) int {
+		for _, s := range order {
+			var c int
+			switch s.Field {
+			case "title":
+				c = strings.Compare(a.Title, b.Title)
+			case "artist":
+				c = strings.Compare(a.Artist, b.Artist)
+			case "price":
+				c = cmp.Compare(a.Price, b.Price)
+			case "year":
+				c = cmp.Compare(a.Year, b.Year)
+			case "created_at":
+				c = a.CreatedAt.Compare(b.CreatedAt)
+			case "updated_at":
+				c = a.UpdatedAt.Compare(b.UpdatedAt)
+			case "id":
+				c = strings.Compare(a.ID, b.ID)
+			}
+			if s.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
 // GetByID - находит альбом по ID
 func (r *MemoryAlbumRepository) GetByID(id string) (*domain.Album, error) {
 	r.mu.RLock()         // Захватываем блокировку на чтение
@@ -58,6 +123,20 @@ func (r *MemoryAlbumRepository) GetByID(id string) (*domain.Album, error) {
 	return nil, fmt.Errorf("album not found")
 }
 
+// GetByIDs - находит альбомы по списку ID
+func (r *MemoryAlbumRepository) GetByIDs(ids []string) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var albums []domain.Album
+	for _, album := range r.albums {
+		if slices.Contains(ids, album.ID) {
+			albums = append(albums, album)
+		}
+	}
+	return albums, nil
+}
+
 // Create - добавляет новый альбом
 func (r *MemoryAlbumRepository) Create(album *domain.Album) error {
 	r.mu.Lock()         // Захватываем эксклюзивную блокировку на запись
@@ -91,6 +170,27 @@ func (r *MemoryAlbumRepository) Update(album *domain.Album) error {
 	return fmt.Errorf("album with ID %s not found", album.ID)
 }
 
+// AdjustStock - меняет количество на складе на delta
+func (r *MemoryAlbumRepository) AdjustStock(id string, delta int) (*domain.Album, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, a := range r.albums {
+		if a.ID == id {
+			if a.StockQuantity+delta < 0 {
+				return nil, domain.ErrInsufficientStock
+			}
+			r.albums[i].StockQuantity += delta
+			r.albums[i].UpdatedAt = time.Now()
+
+			album := r.albums[i]
+			return &album, nil
+		}
+	}
+
+	return nil, fmt.Errorf("album with ID %s not found", id)
+}
+
 // Delete - удаляет альбом по ID
 func (r *MemoryAlbumRepository) Delete(id string) error {
 	r.mu.Lock()
@@ -134,7 +234,7 @@ func (r *MemoryAlbumRepository) GetInStock() ([]domain.Album, error) {
 	var albumsInStock []domain.Album
 
 	for _, album := range r.albums {
-		if album.InStock {
+		if album.InStock() {
 			albumsInStock = append(albumsInStock, album)
 		}
 	}
@@ -142,7 +242,50 @@ func (r *MemoryAlbumRepository) GetInStock() ([]domain.Album, error) {
 	return albumsInStock, nil
 }
 
-// generateID - генерирует уникальный id
-func generateID() string {
+// IterateAll - последовательно отдает все альбомы
+func (r *MemoryAlbumRepository) IterateAll() iter.Seq2[domain.Album, error] {
+	return func(yield func(domain.Album, error) bool) {
+		// Копируем слайс, чтобы не держать блокировку пока потребитель обрабатывает альбомы
+		r.mu.RLock()
+		albums := slices.Clone(r.albums)
+		r.mu.RUnlock()
+
+		for _, album := range albums {
+			if !yield(album, nil) {
+				return
+			}
+		}
+	}
+}
+
+// generateID - генерирует уникальный id по выбранной стратегии
+// Задается при старте через SetIDStrategy
+var generateID = timestampID
+
+// SetIDStrategy - выбирает формат id новых альбомов:
+// "timestamp" - время в наносекундах (по умолчанию), "uuid" - случайный UUID v4
+func SetIDStrategy(strategy string) error {
+	switch strategy {
+	case "", "timestamp":
+		generateID = timestampID
+	case "uuid":
+		generateID = uuidID
+	default:
+		return fmt.Errorf("unknown ID strategy %q", strategy)
+	}
+	return nil
+}
+
+// timestampID - id из текущего времени
+func timestampID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
+
+// uuidID - случайный UUID v4 (не раскрывает порядок и время создания записей)
+func uuidID() string {
+	var b [16]byte
+	rand.Read(b[:])         // Никогда не возвращает ошибку
+	b[6] = b[6]&0x0f | 0x40 // Версия 4
+	b[8] = b[8]&0x3f | 0x80 // Вариант RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/monitoring"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StartReconciler - периодически сверяет выборку ключей кэша с базой данных
+// При heal == true расходящиеся ключи удаляются (следующий запрос возьмет данные из базы)
+func (c *CachedAlbumRepository) StartReconciler(ctx context.Context, interval time.Duration, sampleSize int, heal bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.metrics.SetReconciliation(c.Reconcile(ctx, sampleSize, heal))
+			}
+		}
+	}()
+}
+
+// cacheKey - ключ кэша с его типом данных и идентификатором
+type cacheKey struct {
+	key      string
+	dataType string
+	id       string
+}
+
+// Reconcile - сверяет случайную выборку ключей кэша с базой и возвращает отчет о расхождениях
+// Изменение в момент проверки может дать ложное расхождение - поэтому важна доля, а не единичные случаи
+func (c *CachedAlbumRepository) Reconcile(ctx context.Context, sampleSize int, heal bool) *monitoring.CacheReconciliation {
+	report := &monitoring.CacheReconciliation{
+		CheckedAt: time.Now().UTC(),
+		Kinds:     make(map[string]*monitoring.ReconcileKindStats),
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, 10*c.timeOut)
+	defer cancel()
+
+	var keys []cacheKey
+	for _, dataType := range cacheDataTypes {
+		prefix := c.generateCacheKey(dataType, "")
+		typeKeys, err := c.redis.ScanKeys(scanCtx, prefix+"*")
+		if err != nil {
+			log.Printf("scanning cache keys error: %v", err)
+			return report
+		}
+		for _, key := range typeKeys {
+			keys = append(keys, cacheKey{key: key, dataType: dataType, id: strings.TrimPrefix(key, prefix)})
+		}
+	}
+
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	for _, key := range keys[:min(len(keys), sampleSize)] {
+		divergent, ok := c.checkKey(ctx, key)
+		if !ok {
+			continue // Ключ истек или база недоступна - не считаем
+		}
+
+		stats := report.Kinds[key.dataType]
+		if stats == nil {
+			stats = &monitoring.ReconcileKindStats{}
+			report.Kinds[key.dataType] = stats
+		}
+		stats.Sampled++
+		report.Sampled++
+		if !divergent {
+			continue
+		}
+
+		stats.Divergent++
+		report.Divergent++
+		log.Printf("WARNING: cache key %s diverges from database", key.key)
+		if heal {
+			c.invalidateCache(key.dataType, key.id)
+			report.Healed++
+		}
+	}
+
+	if report.Sampled > 0 {
+		report.DivergenceRatio = float64(report.Divergent) / float64(report.Sampled)
+	}
+	return report
+}
+
+// checkKey - сравнивает значение ключа с базой; ok == false - сравнить не удалось
+func (c *CachedAlbumRepository) checkKey(ctx context.Context, key cacheKey) (divergent, ok bool) {
+	readCtx, cancel := context.WithTimeout(ctx, c.timeOut)
+	defer cancel()
+
+	data, err := c.redis.GetBytes(readCtx, key.key)
+	if err != nil || len(data) == 0 {
+		return false, false
+	}
+	// Запись другой версии схемы не читается и будет перезаписана - сравнивать ее не с чем
+	data, ok = c.openEntry(data)
+	if !ok {
+		return false, false
+	}
+
+	// Альбом по ID хранится объектом, остальные типы - списком альбомов
+	if key.dataType == "id" {
+		var cached domain.Album
+		if err := json.Unmarshal(data, &cached); err != nil {
+			return true, true // Нечитаемое значение в кэше - тоже расхождение
+		}
+		stored, err := c.repo.GetByID(key.id)
+		if err != nil {
+			// Альбома нет в базе - ключ устарел; другие ошибки (база недоступна) не считаем
+			notFound := isNotFound(err)
+			return notFound, notFound
+		}
+		return !sameAlbum(cached, *stored), true
+	}
+
+	var cached []domain.Album
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return true, true
+	}
+
+	var stored []domain.Album
+	switch key.dataType {
+	case "artist":
+		stored, err = c.repo.GetByArtist(key.id)
+	case "stock":
+		stored, err = c.repo.GetInStock()
+	case "all":
+		stored, err = c.repo.GetAll()
+	}
+	if err != nil {
+		return false, false
+	}
+
+	if len(cached) != len(stored) {
+		return true, true
+	}
+	// Порядок при равных ключах сортировки (год у исполнителя) не определен - сравниваем без учета порядка
+	byID := func(a, b domain.Album) int { return strings.Compare(a.ID, b.ID) }
+	slices.SortFunc(cached, byID)
+	slices.SortFunc(stored, byID)
+	for i := range cached {
+		if !sameAlbum(cached[i], stored[i]) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// sameAlbum - совпадают ли альбомы из кэша и базы
+// Время сравниваем с точностью до микросекунд: столько хранит PostgreSQL, а в кэш
+// только что созданного альбома попадает время приложения с наносекундами
+func sameAlbum(a, b domain.Album) bool {
+	sameTime := func(x, y time.Time) bool {
+		return x.Truncate(time.Microsecond).Equal(y.Truncate(time.Microsecond))
+	}
+	return a.ID == b.ID && a.Title == b.Title && a.Artist == b.Artist && a.Price == b.Price &&
+		a.Year == b.Year && a.Genre == b.Genre && a.Condition == b.Condition && a.StockQuantity == b.StockQuantity &&
+		a.AverageRating == b.AverageRating && a.ReviewCount == b.ReviewCount &&
+		a.LabelID == b.LabelID && a.CatalogNumber == b.CatalogNumber && a.Description == b.Description && a.CoverURL == b.CoverURL &&
+		sameTime(a.CreatedAt, b.CreatedAt) && sameTime(a.UpdatedAt, b.UpdatedAt)
+}
+
+// isNotFound - ошибка "альбом не найден" от репозитория
+// Репозитории не используют отдельную ошибку для этого случая, поэтому проверяем текст
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
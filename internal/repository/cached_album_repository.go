@@ -2,34 +2,165 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/monitoring"
 	"go-music-shop/pkg/redis"
+	"iter"
 	"log"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// artistRecentWriteWindow - сколько после изменения альбомов исполнителя он считается "горячим"
+	artistRecentWriteWindow = time.Hour
+	// artistShortTTL - время жизни кэша исполнителя, альбомы которого недавно менялись
+	artistShortTTL = 2 * time.Minute
+	// artistLongTTL - время жизни кэша исполнителя без недавних изменений
+	// Безопасно, потому что любое изменение его альбомов сразу инвалидирует кэш
+	artistLongTTL = 30 * time.Minute
+	// defaultStockTTL - время жизни кэша альбомов в наличии (наличие меняется часто)
+	defaultStockTTL = 30 * time.Second
+	// albumCacheSchemaVersion - версия формата альбомов в кэше; увеличивается при каждом изменении
+	// полей domain.Album, иначе старые записи молча разберутся в новую структуру лишь частично
+	albumCacheSchemaVersion = 3
 )
 
 // CachedAlbumRepository - декоратор, который добавляет кэширование к любому репозиторию
 // Используем паттерн Decorator чтобы не изменять существующий код
 type CachedAlbumRepository struct {
-	repo    domain.AlbumRepository // Оригинальный репозиторий (PostgreSQL)
-	redis   *redis.RedisClient     // Redis клиент для кэширования
-	timeOut time.Duration          // Таймаут для операций с Redis
+	repo    domain.AlbumRepository   // Оригинальный репозиторий (PostgreSQL)
+	redis   *redis.RedisClient       // Redis клиент для кэширования
+	timeOut time.Duration            // Таймаут для операций с Redis
+	metrics *monitoring.CacheMetrics // Счетчики попаданий/промахов кэша
+	group   singleflight.Group       // Объединяет одновременные запросы в БД за одними данными
+	// namespace - префикс ключей (отдельный для каждого магазина), чтобы кэши магазинов
+	// не пересекались и сбрасывались независимо
+	namespace string
+	// stockTTL - текущее время жизни кэша наличия (короче на время распродажи)
+	stockTTL atomic.Int64
+	// entryHeader - заголовок записей кэша с версией схемы; записи с другим заголовком считаются промахом
+	entryHeader []byte
 }
 
 // NewCachedAlbumRepository - конструктор кэшированного репозитория
-func NewCachedAlbumRepository(repo domain.AlbumRepository, redisClient *redis.RedisClient) *CachedAlbumRepository {
+func NewCachedAlbumRepository(repo domain.AlbumRepository, redisClient *redis.RedisClient, metrics *monitoring.CacheMetrics) *CachedAlbumRepository {
 	return &CachedAlbumRepository{
-		repo:    repo,
-		redis:   redisClient,
-		timeOut: 2 * time.Second, // 2 секунды таймаут для Redis операций
+		repo:        repo,
+		redis:       redisClient,
+		timeOut:     2 * time.Second, // 2 секунды таймаут для Redis операций
+		metrics:     metrics,
+		entryHeader: cacheEntryHeader(""),
+	}
+}
+
+// SetNamespace - задает префикс ключей кэша; вызывается при старте, до обработки запросов
+func (c *CachedAlbumRepository) SetNamespace(namespace string) {
+	c.namespace = namespace
+}
+
+// SetSchemaVersion - добавляет к версии схемы кэша версию от деплоя (REDIS_CACHE_SCHEMA_VERSION);
+// вызывается при старте, до обработки запросов
+// Смена версии безопасно сбрасывает весь кэш: записи прежней версии не читаются и перезаписываются
+// при следующем промахе, а не удаляются разом (без всплеска запросов в базу)
+func (c *CachedAlbumRepository) SetSchemaVersion(deployVersion string) {
+	c.entryHeader = cacheEntryHeader(deployVersion)
+}
+
+// cacheEntryHeader - заголовок записи кэша: "#schema=1\n" или "#schema=1.<версия деплоя>\n"
+// JSON не начинается с "#", поэтому записи без заголовка (до появления версий) тоже не совпадут
+func cacheEntryHeader(deployVersion string) []byte {
+	version := strconv.Itoa(albumCacheSchemaVersion)
+	if deployVersion != "" {
+		version += "." + deployVersion
+	}
+	return []byte("#schema=" + version + "\n")
+}
+
+// openEntry - данные записи кэша без заголовка; ok == false - запись другой версии схемы
+func (c *CachedAlbumRepository) openEntry(data []byte) ([]byte, bool) {
+	return bytes.CutPrefix(data, c.entryHeader)
+}
+
+// readEntry - читает запись кэша; nil - записи нет или она другой версии схемы
+// (такая запись считается промахом и перезаписывается данными из базы)
+func (c *CachedAlbumRepository) readEntry(ctx context.Context, key, kind string) []byte {
+	data, err := c.redis.GetBytes(ctx, key)
+	if err != nil {
+		log.Printf("reading from cache error: %v", err)
+		return nil
 	}
+	return c.checkEntry(data, kind)
+}
+
+// checkEntry - данные прочитанной записи без заголовка; записи другой версии схемы учитываются в метриках
+func (c *CachedAlbumRepository) checkEntry(data []byte, kind string) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	payload, ok := c.openEntry(data)
+	if !ok {
+		c.metrics.Stale(kind)
+		return nil
+	}
+	return payload
+}
+
+// SetStockTTL - меняет время жизни кэша альбомов в наличии (0 - стандартное)
+// Уже закэшированный список сбрасывается, чтобы новое время жизни действовало сразу
+func (c *CachedAlbumRepository) SetStockTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultStockTTL
+	}
+	if time.Duration(c.stockTTL.Swap(int64(ttl))) != ttl {
+		c.invalidateCache("stock", "")
+	}
+}
+
+// getStockTTL - время жизни кэша наличия
+func (c *CachedAlbumRepository) getStockTTL() time.Duration {
+	if ttl := time.Duration(c.stockTTL.Load()); ttl > 0 {
+		return ttl
+	}
+	return defaultStockTTL
+}
+
+// bufferPool - переиспользуемые буферы для сериализации данных перед записью в кэш,
+// чтобы не выделять новый буфер на каждую запись
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// setJSON - сериализует значение в JSON через буфер из пула и сохраняет в кэш с заголовком версии
+func (c *CachedAlbumRepository) setJSON(ctx context.Context, key string, value any, ttl time.Duration) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+
+	buf.Write(c.entryHeader)
+	if err := json.NewEncoder(buf).Encode(value); err != nil {
+		return fmt.Errorf("encoding cache value error: %w", err)
+	}
+
+	// Encode добавляет перевод строки - в кэш кладем ровно тот JSON, который отдаем клиенту
+	return c.redis.Set(ctx, key, bytes.TrimSuffix(buf.Bytes(), []byte("\n")), ttl)
 }
 
 // generateCacheKey - генерирует ключ для кэша на основе типа данных и ID
 func (c *CachedAlbumRepository) generateCacheKey(dataType string, id string) string {
+	if c.namespace != "" {
+		return fmt.Sprintf("%s:album:%s:%s", c.namespace, dataType, id)
+	}
 	return fmt.Sprintf("album:%s:%s", dataType, id)
 }
 
@@ -41,18 +172,15 @@ func (c *CachedAlbumRepository) GetAll() ([]domain.Album, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
 	defer cancel()
 
-	// Пытаемся получить данные из кэша
-	cachedData, err := c.redis.Get(ctx, cacheKey)
-	if err != nil {
-		log.Printf("reading from cache error: %v", err)
-		// Продолжаем без кэша - получаем данные из базы
-	}
+	// Пытаемся получить данные из кэша (при ошибке продолжаем без кэша - получаем данные из базы)
+	cachedData := c.readEntry(ctx, cacheKey, "all")
 
 	// Если данные есть в кэше - возвращаем их
-	if cachedData != "" {
+	if cachedData != nil {
 		var albums []domain.Album
-		if err := json.Unmarshal([]byte(cachedData), &albums); err == nil {
+		if err := json.Unmarshal(cachedData, &albums); err == nil {
 			log.Println("data from cache has been delivered (all albums)")
+			c.metrics.Hit("all")
 			return albums, nil
 		} else {
 			log.Printf("parsing cached data error: %v", err)
@@ -60,6 +188,7 @@ func (c *CachedAlbumRepository) GetAll() ([]domain.Album, error) {
 	}
 
 	// Если данных нет в кэше - получаем из базы
+	c.metrics.Miss("all")
 	albums, err := c.repo.GetAll()
 	if err != nil {
 		return nil, err
@@ -68,13 +197,11 @@ func (c *CachedAlbumRepository) GetAll() ([]domain.Album, error) {
 	// Сохраняем в кэш асинхронно (не блокируем ответ)
 	go func() {
 		ctx := context.Background()
-		if data, err := json.Marshal(albums); err == nil {
-			// Сохраняем на 1 минуту для списка всех альбомов
-			if err := c.redis.Set(ctx, cacheKey, string(data), time.Minute); err != nil {
-				log.Printf("saving in cache error: %v", err)
-			} else {
-				log.Println("data has been saved in cache (all albums)")
-			}
+		// Сохраняем на 1 минуту для списка всех альбомов
+		if err := c.setJSON(ctx, cacheKey, albums, time.Minute); err != nil {
+			log.Printf("saving in cache error: %v", err)
+		} else {
+			log.Println("data has been saved in cache (all albums)")
 		}
 	}()
 
@@ -89,18 +216,15 @@ func (c *CachedAlbumRepository) GetByID(id string) (*domain.Album, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
 	defer cancel()
 
-	// Пытаемся получить данные из кэша
-	cachedData, err := c.redis.Get(ctx, cacheKey)
-	if err != nil {
-		log.Printf("reading from cache error: %v", err)
-		// Продолжаем без кэша - получаем данные из базы
-	}
+	// Пытаемся получить данные из кэша (при ошибке продолжаем без кэша - получаем данные из базы)
+	cachedData := c.readEntry(ctx, cacheKey, "id")
 
 	// Если данные есть в кэше - возвращаем их
-	if cachedData != "" {
+	if cachedData != nil {
 		var album domain.Album
-		if err := json.Unmarshal([]byte(cachedData), &album); err == nil {
+		if err := json.Unmarshal(cachedData, &album); err == nil {
 			log.Printf("data from cache has been delivered (album by id)")
+			c.metrics.Hit("id")
 			return &album, nil
 		} else {
 			log.Printf("parsing cache data error: %v", err)
@@ -108,6 +232,7 @@ func (c *CachedAlbumRepository) GetByID(id string) (*domain.Album, error) {
 	}
 
 	// Если данных нет в кэше - получаем из базы
+	c.metrics.Miss("id")
 	album, err := c.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -116,17 +241,109 @@ func (c *CachedAlbumRepository) GetByID(id string) (*domain.Album, error) {
 	// Сохраняем в кэш асинхронно (не блокируем ответ)
 	go func() {
 		ctx := context.Background()
-		if data, err := json.Marshal(album); err == nil {
-			// Сохраняем на 5 минут для отдельного альбома
-			if err := c.redis.Set(ctx, cacheKey, string(data), 5*time.Minute); err != nil {
+		// Сохраняем на 5 минут для отдельного альбома
+		if err := c.setJSON(ctx, cacheKey, album, 5*time.Minute); err != nil {
+			log.Printf("saving in cache error: %v", err)
+		} else {
+			log.Println("data has been saved in cache (album by id)")
+		}
+	}()
+
+	return album, nil
+}
+
+// GetByIDs - берет из кэша все найденные там альбомы одним запросом MGET,
+// остальные - одним запросом в базу, и кладет их в кэш
+func (c *CachedAlbumRepository) GetByIDs(ids []string) ([]domain.Album, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = c.generateCacheKey("id", id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
+	defer cancel()
+
+	cached, err := c.redis.MGetBytes(ctx, keys...)
+	if err != nil {
+		log.Printf("reading from cache error: %v", err)
+		cached = make([][]byte, len(ids)) // Продолжаем без кэша
+	}
+
+	albums := make([]domain.Album, 0, len(ids))
+	var missing []string
+	for i, data := range cached {
+		var album domain.Album
+		if data = c.checkEntry(data, "id"); data != nil && json.Unmarshal(data, &album) == nil {
+			albums = append(albums, album)
+			continue
+		}
+		missing = append(missing, ids[i])
+	}
+
+	c.metrics.HitN("id", len(albums))
+	if len(missing) == 0 {
+		return albums, nil
+	}
+	c.metrics.MissN("id", len(missing))
+
+	loaded, err := c.repo.GetByIDs(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ctx := context.Background()
+		for _, album := range loaded {
+			if err := c.setJSON(ctx, c.generateCacheKey("id", album.ID), album, 5*time.Minute); err != nil {
 				log.Printf("saving in cache error: %v", err)
-			} else {
-				log.Println("data has been saved in cache (album by id)")
+				return
 			}
 		}
 	}()
 
-	return album, nil
+	return append(albums, loaded...), nil
+}
+
+// GetRawByID - возвращает альбом в виде готового JSON, как он хранится в кэше
+// При попадании в кэш байты (без заголовка версии) отдаются без разбора JSON и повторной сериализации
+func (c *CachedAlbumRepository) GetRawByID(id string) ([]byte, error) {
+	cacheKey := c.generateCacheKey("id", id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
+	defer cancel()
+
+	if data := c.readEntry(ctx, cacheKey, "id"); data != nil {
+		c.metrics.Hit("id")
+		return data, nil
+	}
+
+	// Если данных нет в кэше - получаем из базы и кэшируем готовый JSON
+	c.metrics.Miss("id")
+	album, err := c.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(album)
+	if err != nil {
+		return nil, fmt.Errorf("encoding album error: %w", err)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
+		defer cancel()
+
+		entry := append(slices.Clip(c.entryHeader), data...)
+		if err := c.redis.Set(ctx, cacheKey, entry, 5*time.Minute); err != nil {
+			log.Printf("saving in cache error: %v", err)
+		}
+	}()
+
+	return data, nil
 }
 
 // Create - создает альбом БЕЗ удаления кэша всех альбомов
@@ -139,9 +356,9 @@ func (c *CachedAlbumRepository) Create(album *domain.Album) error {
 
 	// Инвалидируем кэши, которые зависят от этого альбома
 	go func() {
-		c.invalidateCache("artist", album.Artist) // Кэш альбомов этого исполнителя
-		c.invalidateCache("stock", "")            // Кэш альбомов в наличии
-		c.cacheAlbum(album)                       // Кэшируем новый альбом
+		c.invalidateArtist(album.Artist) // Кэш альбомов этого исполнителя
+		c.invalidateCache("stock", "")   // Кэш альбомов в наличии
+		c.cacheAlbum(album)              // Кэшируем новый альбом
 	}()
 
 	return nil
@@ -152,12 +369,10 @@ func (c *CachedAlbumRepository) cacheAlbum(album *domain.Album) {
 	cacheKey := c.generateCacheKey("id", album.ID)
 	ctx := context.Background()
 
-	if data, err := json.Marshal(album); err == nil {
-		if err := c.redis.Set(ctx, cacheKey, string(data), 5*time.Minute); err != nil {
-			log.Printf("⚠️ Ошибка кэширования альбома: %v", err)
-		} else {
-			log.Printf("💾 Новый альбом %s закэширован", album.ID)
-		}
+	if err := c.setJSON(ctx, cacheKey, album, 5*time.Minute); err != nil {
+		log.Printf("⚠️ Ошибка кэширования альбома: %v", err)
+	} else {
+		log.Printf("💾 Новый альбом %s закэширован", album.ID)
 	}
 }
 
@@ -176,17 +391,33 @@ func (c *CachedAlbumRepository) Update(album *domain.Album) error {
 		c.invalidateCache("id", album.ID)
 
 		if oldAlbum != nil {
-			c.invalidateCache("artist", oldAlbum.Artist) // Старый исполнитель
+			c.invalidateArtist(oldAlbum.Artist) // Старый исполнитель
 		}
 
-		c.invalidateCache("artist", album.Artist) // Новый исполнитель
-		c.invalidateCache("stock", "")            // Кэш наличия
+		c.invalidateArtist(album.Artist) // Новый исполнитель
+		c.invalidateCache("stock", "")   // Кэш наличия
 
 	}()
 
 	return nil
 }
 
+// AdjustStock - меняет количество на складе и инвалидирует кэш альбома и списка в наличии
+func (c *CachedAlbumRepository) AdjustStock(id string, delta int) (*domain.Album, error) {
+	album, err := c.repo.AdjustStock(id, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		c.invalidateCache("id", id)
+		c.invalidateArtist(album.Artist)
+		c.invalidateCache("stock", "")
+	}()
+
+	return album, nil
+}
+
 // Delete - удаляет альбом и инвалидирует только его кэш
 func (c *CachedAlbumRepository) Delete(id string) error {
 	// Получаем альбом перед удалением чтобы знать исполнителя
@@ -200,7 +431,7 @@ func (c *CachedAlbumRepository) Delete(id string) error {
 	go func() {
 		c.invalidateCache("id", id)
 		if album != nil {
-			c.invalidateCache("artist", album.Artist) // Инвалидируем кэш исполнителя
+			c.invalidateArtist(album.Artist) // Инвалидируем кэш исполнителя
 		}
 		c.invalidateCache("stock", "") // Инвалидируем кэш наличия
 	}()
@@ -208,6 +439,36 @@ func (c *CachedAlbumRepository) Delete(id string) error {
 	return nil
 }
 
+// InvalidateAlbum - удаляет кэш альбома и списков, в которые он входит,
+// после изменения альбома в обход репозитория (рейтинг по отзывам, лейбл)
+func (c *CachedAlbumRepository) InvalidateAlbum(id string) {
+	album, _ := c.repo.GetByID(id)
+
+	go func() {
+		c.invalidateCache("id", id)
+		if album != nil {
+			c.invalidateArtist(album.Artist)
+		}
+		c.invalidateCache("stock", "")
+	}()
+}
+
+// InvalidateAlbums - удаляет кэш альбомов и их исполнителей по уже известным данным, без чтения из базы
+// Нужен, когда альбом из базы уже не прочитать (архивирован) или исполнитель сменился
+func (c *CachedAlbumRepository) InvalidateAlbums(albums []domain.Album) {
+	go func() {
+		var artists []string
+		for _, album := range albums {
+			c.invalidateCache("id", album.ID)
+			if !slices.Contains(artists, album.Artist) {
+				artists = append(artists, album.Artist)
+				c.invalidateArtist(album.Artist)
+			}
+		}
+		c.invalidateCache("stock", "")
+	}()
+}
+
 // invalidateCache - удаляет данные из кэша
 func (c *CachedAlbumRepository) invalidateCache(dataType string, id string) {
 	cacheKey := c.generateCacheKey(dataType, id)
@@ -220,6 +481,132 @@ func (c *CachedAlbumRepository) invalidateCache(dataType string, id string) {
 	}
 }
 
+// cacheDataTypes - типы данных, которые кэширует репозиторий
+// Перечисляем явно: под префиксом "album:" в Redis лежат и чужие ключи (подсказки, просмотры)
+var cacheDataTypes = []string{"id", "artist", "all", "stock"}
+
+// InvalidateAll - удаляет весь кэш альбомов (после массовых изменений в обход репозитория)
+func (c *CachedAlbumRepository) InvalidateAll() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*c.timeOut)
+	defer cancel()
+
+	for _, dataType := range cacheDataTypes {
+		keys, err := c.redis.ScanKeys(ctx, c.generateCacheKey(dataType, "*"))
+		if err != nil {
+			log.Printf("scanning cache keys error: %v", err)
+			continue
+		}
+		for _, key := range keys {
+			if err := c.redis.Delete(ctx, key); err != nil {
+				log.Printf("Ошибка инвалидации кэша %s: %v", key, err)
+			}
+		}
+	}
+}
+
+// usageSampleSize - сколько ключей проверяем через MEMORY USAGE при оценке памяти кэша
+const usageSampleSize = 200
+
+// EstimateUsage - оценивает память, занятую кэшем каталога в своем пространстве имен
+// Размер считается по случайной выборке ключей и экстраполируется на все ключи
+func (c *CachedAlbumRepository) EstimateUsage(ctx context.Context) (*monitoring.CacheUsage, error) {
+	var keys []string
+	for _, dataType := range cacheDataTypes {
+		typeKeys, err := c.redis.ScanKeys(ctx, c.generateCacheKey(dataType, "*"))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, typeKeys...)
+	}
+
+	usage := &monitoring.CacheUsage{Namespace: c.namespace, Keys: len(keys), CheckedAt: time.Now().UTC()}
+	if len(keys) == 0 {
+		return usage, nil
+	}
+
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	sample := keys[:min(len(keys), usageSampleSize)]
+
+	var sampledBytes int64
+	for _, key := range sample {
+		n, err := c.redis.MemoryUsage(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		sampledBytes += n
+	}
+
+	usage.SampledKeys = len(sample)
+	usage.EstimatedBytes = sampledBytes * int64(len(keys)) / int64(len(sample))
+	return usage, nil
+}
+
+// StartQuotaEnforcer - периодически оценивает память кэша и сбрасывает его при превышении лимита,
+// чтобы кэш одного магазина не вытеснял из Redis горячие ключи других
+// Оценка сохраняется в метриках кэша даже без лимита (maxBytes == 0)
+func (c *CachedAlbumRepository) StartQuotaEnforcer(ctx context.Context, interval time.Duration, maxBytes int64) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.enforceQuota(ctx, maxBytes)
+			}
+		}
+	}()
+}
+
+// enforceQuota - одна проверка лимита памяти кэша
+func (c *CachedAlbumRepository) enforceQuota(ctx context.Context, maxBytes int64) {
+	ctx, cancel := context.WithTimeout(ctx, 10*c.timeOut)
+	defer cancel()
+
+	usage, err := c.EstimateUsage(ctx)
+	if err != nil {
+		log.Printf("estimating cache usage error: %v", err)
+		return
+	}
+
+	usage.MaxBytes = maxBytes
+	if maxBytes > 0 && usage.EstimatedBytes > maxBytes {
+		log.Printf("WARNING: catalog cache %q uses ~%d bytes (limit %d), flushing it", c.namespace, usage.EstimatedBytes, maxBytes)
+		c.InvalidateAll()
+		usage.Flushed = true
+	}
+	c.metrics.SetUsage(usage)
+}
+
+// invalidateArtist - удаляет кэш исполнителя и помечает его как недавно измененного,
+// чтобы следующие записи кэша этого исполнителя жили меньше
+func (c *CachedAlbumRepository) invalidateArtist(artist string) {
+	c.invalidateCache("artist", artist)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
+	defer cancel()
+
+	markerKey := c.generateCacheKey("artist-write", artist)
+	if err := c.redis.Set(ctx, markerKey, time.Now().Unix(), artistRecentWriteWindow); err != nil {
+		log.Printf("saving artist write marker error: %v", err)
+	}
+}
+
+// artistTTL - выбирает время жизни кэша исполнителя:
+// короткое если его альбомы недавно менялись, длинное - если нет
+func (c *CachedAlbumRepository) artistTTL(ctx context.Context, artist string) time.Duration {
+	recentlyChanged, err := c.redis.Exists(ctx, c.generateCacheKey("artist-write", artist))
+	if err != nil || recentlyChanged {
+		return artistShortTTL
+	}
+	return artistLongTTL
+}
+
+// GetByArtist - получает альбомы исполнителя с кэшированием
+// Страницы популярных исполнителей запрашиваются очень часто, поэтому при промахе
+// одновременные запросы объединяются (singleflight) и в базу уходит только один
 func (c *CachedAlbumRepository) GetByArtist(artist string) ([]domain.Album, error) {
 	cacheKey := c.generateCacheKey("artist", artist)
 
@@ -227,44 +614,52 @@ func (c *CachedAlbumRepository) GetByArtist(artist string) ([]domain.Album, erro
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
 	defer cancel()
 
-	// Пытаемся получить данные из кэша
-	cachedData, err := c.redis.Get(ctx, cacheKey)
-	if err != nil {
-		log.Printf("reading from cache error: %v", err)
-		// Продолжаем без кэша - получаем данные из базы
-	}
+	// Пытаемся получить данные из кэша (при ошибке продолжаем без кэша - получаем данные из базы)
+	cachedData := c.readEntry(ctx, cacheKey, "artist")
 
 	// Если данные есть в кэше - возвращаем их
-	if cachedData != "" {
+	if cachedData != nil {
 		var albums []domain.Album
-		if err := json.Unmarshal([]byte(cachedData), &albums); err == nil {
+		if err := json.Unmarshal(cachedData, &albums); err == nil {
 			log.Printf("data from cache has been delivered (albums by artist %s)", artist)
+			c.metrics.Hit("artist")
 			return albums, nil
 		} else {
 			log.Printf("parsing cache data error: %v", err)
 		}
 	}
 
-	// Если данных нет в кэше - получаем из базы
-	albums, err := c.repo.GetByArtist(artist)
-	if err != nil {
-		return nil, err
-	}
+	// Если данных нет в кэше - получаем из базы (один запрос на всех ожидающих)
+	c.metrics.Miss("artist")
+	result, err, shared := c.group.Do(cacheKey, func() (any, error) {
+		albums, err := c.repo.GetByArtist(artist)
+		if err != nil {
+			return nil, err
+		}
 
-	// Сохраняем в кэш асинхронно (не блокируем ответ)
-	go func() {
-		ctx := context.Background()
-		if data, err := json.Marshal(albums); err == nil {
-			// Сохраняем на 2 минуты
-			if err := c.redis.Set(ctx, cacheKey, string(data), 2*time.Minute); err != nil {
+		// Сохраняем в кэш асинхронно (не блокируем ответ)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeOut)
+			defer cancel()
+
+			ttl := c.artistTTL(ctx, artist)
+			if err := c.setJSON(ctx, cacheKey, albums, ttl); err != nil {
 				log.Printf("saving in cache error: %v", err)
 			} else {
-				log.Printf("data has been saved in cache (albums by artist %s)", artist)
+				log.Printf("data has been saved in cache (albums by artist %s, ttl %s)", artist, ttl)
 			}
-		}
-	}()
+		}()
 
-	return albums, nil
+		return albums, nil
+	})
+	if shared {
+		c.metrics.Coalesced("artist")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]domain.Album), nil
 }
 
 func (c *CachedAlbumRepository) GetInStock() ([]domain.Album, error) {
@@ -274,16 +669,14 @@ func (c *CachedAlbumRepository) GetInStock() ([]domain.Album, error) {
 	defer cancel()
 
 	// Пытаемся получить из кеша
-	cachedData, err := c.redis.Get(ctx, cacheKey)
-	if err != nil {
-		log.Printf("reading from cache error: %v", err)
-	}
+	cachedData := c.readEntry(ctx, cacheKey, "stock")
 
 	// Если данные есть в кэше - возвращаем их
-	if cachedData != "" {
+	if cachedData != nil {
 		var albums []domain.Album
-		if err := json.Unmarshal([]byte(cachedData), &albums); err == nil {
+		if err := json.Unmarshal(cachedData, &albums); err == nil {
 			log.Printf("data from cache has been delivered (albums in stock)")
+			c.metrics.Hit("stock")
 			return albums, nil
 		} else {
 			log.Printf("parsing from cache error: %v", err)
@@ -291,22 +684,33 @@ func (c *CachedAlbumRepository) GetInStock() ([]domain.Album, error) {
 	}
 
 	// Если данных нет в кэше - загружаем из бд
+	c.metrics.Miss("stock")
 	albums, err := c.repo.GetInStock()
 	if err != nil {
 		return nil, err
 	}
 
-	// Сохраняем в кэш асинхронно на 30 секунд (т.к часто меняются)
+	// Сохраняем в кэш асинхронно на короткое время (т.к часто меняются)
+	ttl := c.getStockTTL()
 	go func() {
 		ctx := context.Background()
-		if data, err := json.Marshal(albums); err == nil {
-			if err := c.redis.Set(ctx, cacheKey, string(data), 30*time.Second); err != nil {
-				log.Printf("saving in cache error: %v", err)
-			} else {
-				log.Printf("data has been saved in cache (albums in stock)")
-			}
+		if err := c.setJSON(ctx, cacheKey, albums, ttl); err != nil {
+			log.Printf("saving in cache error: %v", err)
+		} else {
+			log.Printf("data has been saved in cache (albums in stock)")
 		}
 	}()
 
 	return albums, nil
 }
+
+// GetPage - страницы читаются напрямую из базы: LIMIT/OFFSET по индексу дешевле,
+// чем кэшировать каждую комбинацию фильтра, limit и offset и сбрасывать их все при любом изменении
+func (c *CachedAlbumRepository) GetPage(filter domain.AlbumFilter, limit, offset int) ([]domain.Album, int, error) {
+	return c.repo.GetPage(filter, limit, offset)
+}
+
+// IterateAll - потоковое чтение всегда идет напрямую в базу (весь каталог в кэш не кладем)
+func (c *CachedAlbumRepository) IterateAll() iter.Seq2[domain.Album, error] {
+	return c.repo.IterateAll()
+}
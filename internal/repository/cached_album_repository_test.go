@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/internal/monitoring"
+	"go-music-shop/pkg/redis"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cacheHitAllocsBudget - сколько выделений памяти допускается на попадание в кэш GetAlbumByID
+// Сейчас 26: 18 - клиент Redis (команда и ответ), остальное - контекст с таймаутом и ключ кэша
+// Бюджет меняется осознанно, вместе с изменением горячего пути
+const cacheHitAllocsBudget = 26
+
+// BenchmarkGetAlbumByIDCacheHit - попадание в кэш на горячем пути GET /albums/:id
+func BenchmarkGetAlbumByIDCacheHit(b *testing.B) {
+	repo, id := newCachedAlbumRepositoryWithHit(b)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := repo.GetRawByID(id); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestGetAlbumByIDCacheHitAllocs - защищает горячий путь от лишних выделений памяти
+func TestGetAlbumByIDCacheHitAllocs(t *testing.T) {
+	repo, id := newCachedAlbumRepositoryWithHit(t)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := repo.GetRawByID(id); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > cacheHitAllocsBudget {
+		t.Fatalf("GetRawByID cache hit: %.0f allocations, budget is %d", allocs, cacheHitAllocsBudget)
+	}
+}
+
+// newCachedAlbumRepositoryWithHit - кэширующий репозиторий поверх тестового Redis с альбомом в кэше
+// Альбом кладется через setJSON - тем же путем (с буфером из bufferPool), что и после промаха
+func newCachedAlbumRepositoryWithHit(tb testing.TB) (*CachedAlbumRepository, string) {
+	tb.Helper()
+
+	repo := NewCachedAlbumRepository(NewMemoryAlbumRepository(), startFakeRedis(tb), monitoring.NewCacheMetrics())
+	album := domain.Album{
+		ID:            "a1b2c3",
+		Title:         "Kind of Blue",
+		Artist:        "Miles Davis",
+		Price:         56.99,
+		Year:          1959,
+		Genre:         "Modal Jazz",
+		Condition:     "VG+",
+		StockQuantity: 3,
+		CreatedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if err := repo.setJSON(context.Background(), repo.generateCacheKey("id", album.ID), album, time.Minute); err != nil {
+		tb.Fatal(err)
+	}
+	return repo, album.ID
+}
+
+// startFakeRedis - Redis в памяти с командами, которые нужны кэширующему репозиторию (GET, SET)
+// Настоящий Redis в тестах не нужен: проверяется код репозитория, а не сервер
+func startFakeRedis(tb testing.TB) *redis.RedisClient {
+	tb.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	data := map[string]string{}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeRedis(conn, &mu, data)
+		}
+	}()
+
+	cfg := &config.Config{}
+	cfg.Redis.Host, cfg.Redis.Port, _ = net.SplitHostPort(ln.Addr().String())
+	client, err := redis.NewRedisClient(cfg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { client.Close() })
+	return client
+}
+
+// serveFakeRedis - отвечает на команды одного подключения по протоколу RESP2
+func serveFakeRedis(conn net.Conn, mu *sync.Mutex, data map[string]string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	for {
+		args, err := readFakeRedisCommand(reader)
+		if err != nil {
+			return
+		}
+
+		mu.Lock()
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			writer.WriteString("+PONG\r\n")
+		case "GET":
+			if value, ok := data[args[1]]; ok {
+				fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(value), value)
+			} else {
+				writer.WriteString("$-1\r\n")
+			}
+		case "SET":
+			data[args[1]] = args[2]
+			writer.WriteString("+OK\r\n")
+		case "DEL":
+			delete(data, args[1])
+			writer.WriteString(":1\r\n")
+		case "CLIENT", "SELECT":
+			writer.WriteString("+OK\r\n")
+		default: // HELLO и прочее - клиент переходит на RESP2
+			fmt.Fprintf(writer, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		mu.Unlock()
+
+		if reader.Buffered() == 0 { // Конвейер команд - один сброс на все ответы
+			if err := writer.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readFakeRedisCommand - читает команду: массив строк *N\r\n$len\r\narg\r\n...
+func readFakeRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid command header %q", line)
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument header %q", line)
+		}
+		arg := make([]byte, size+2) // С \r\n
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:size])
+	}
+	return args, nil
+}
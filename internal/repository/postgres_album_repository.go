@@ -5,8 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"go-music-shop/internal/domain/models"
+	"iter"
 	"log"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // PostgresAlbumRepository - реализация репозитория для PostgreSQL
@@ -26,8 +30,8 @@ func (r *PostgresAlbumRepository) GetAll() ([]domain.Album, error) {
 	// SQL запрос для получения всех альбомов
 	// $1, $2... - это placeholders для параметров (в этом запросе их нет)
 
-	query := `SELECT id, title, artist, price, year, genre, condition, in_stock, created_at, updated_at 
-    		FROM albums ORDER BY created_at DESC`
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at 
+    		FROM albums WHERE archived_at IS NULL ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -51,7 +55,13 @@ func (r *PostgresAlbumRepository) GetAll() ([]domain.Album, error) {
 			&album.Year,
 			&album.Genre,
 			&album.Condition,
-			&album.InStock,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
 			&album.CreatedAt,
 			&album.UpdatedAt,
 		)
@@ -70,10 +80,186 @@ func (r *PostgresAlbumRepository) GetAll() ([]domain.Album, error) {
 	return albums, nil
 }
 
+// GetByIDs - альбомы по списку id одним запросом (WHERE id = ANY)
+func (r *PostgresAlbumRepository) GetByIDs(ids []string) ([]domain.Album, error) {
+	rows, err := r.db.Query(`SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+		FROM albums WHERE id = ANY($1) AND archived_at IS NULL`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get albums by ids: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.Album
+	for rows.Next() {
+		var album domain.Album
+		err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.Artist,
+			&album.Price,
+			&album.Year,
+			&album.Genre,
+			&album.Condition,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan album: %w", err)
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return albums, nil
+}
+
+// GetPage - страница альбомов, подходящих под фильтр (LIMIT/OFFSET в SQL), и общее количество таких альбомов
+// id в сортировке делает порядок однозначным: альбомы с одинаковым created_at не перескакивают между страницами
+func (r *PostgresAlbumRepository) GetPage(filter domain.AlbumFilter, limit, offset int) ([]domain.Album, int, error) {
+	where, args := albumFilterWhere(filter)
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM albums`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count albums: %w", err)
+	}
+
+	orderBy, err := albumOrderBy(filter.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Курсор: продолжение списка после последнего альбома предыдущей страницы (по индексу, без OFFSET)
+	// Общее количество считается без него - это размер всего списка, а не остатка
+	if filter.After != nil {
+		where += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)+1, len(args)+2)
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	query := fmt.Sprintf(`SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+		FROM albums%s ORDER BY %s
+		LIMIT $%d OFFSET $%d`, where, orderBy, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get albums page: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.Album
+	for rows.Next() {
+		var album domain.Album
+		err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.Artist,
+			&album.Price,
+			&album.Year,
+			&album.Genre,
+			&album.Condition,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan album: %w", err)
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, total, nil
+}
+
+// albumFilterWhere - условие WHERE для фильтра и его параметры; архивные альбомы не подходят ни под какой фильтр
+// Значения передаются только параметрами ($1, $2...), в текст запроса попадают лишь имена колонок
+func albumFilterWhere(filter domain.AlbumFilter) (string, []any) {
+	conditions := []string{"archived_at IS NULL"}
+	var args []any
+
+	// arg - добавляет параметр запроса и возвращает его placeholder ($1, $2...)
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Genre != "" {
+		conditions = append(conditions, "LOWER(genre) = LOWER("+arg(filter.Genre)+")")
+	}
+	if filter.Condition != "" {
+		conditions = append(conditions, "condition = "+arg(filter.Condition))
+	}
+	if filter.YearFrom != 0 {
+		conditions = append(conditions, "year >= "+arg(filter.YearFrom))
+	}
+	if filter.YearTo != 0 {
+		conditions = append(conditions, "year <= "+arg(filter.YearTo))
+	}
+	if filter.PriceMin != 0 {
+		conditions = append(conditions, "price >= "+arg(filter.PriceMin))
+	}
+	if filter.PriceMax != 0 {
+		conditions = append(conditions, "price <= "+arg(filter.PriceMax))
+	}
+	if filter.InStock != nil {
+		conditions = append(conditions, "(stock_quantity > 0) = "+arg(*filter.InStock))
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// albumSortColumns - колонки для сортировки; в ORDER BY попадают только они, а не текст от клиента
+var albumSortColumns = map[string]string{
+	"title":      "title",
+	"artist":     "artist",
+	"price":      "price",
+	"year":       "year",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// albumOrderBy - ORDER BY для сортировки (по умолчанию сначала новые), id в конце делает порядок однозначным
+// Порядок по умолчанию совпадает с условием курсора (created_at, id) < (...) и индексом idx_albums_created_at_id
+func albumOrderBy(order []domain.AlbumSort) (string, error) {
+	if len(order) == 0 {
+		return "created_at DESC, id DESC", nil
+	}
+
+	parts := make([]string, 0, len(order)+1)
+	for _, s := range order {
+		column, ok := albumSortColumns[s.Field]
+		if !ok {
+			return "", fmt.Errorf("unsupported sort field %q", s.Field)
+		}
+		if s.Desc {
+			column += " DESC"
+		}
+		parts = append(parts, column)
+	}
+	return strings.Join(append(parts, "id"), ", "), nil
+}
+
 // GetByID - находит ОДИН альбом по его ID
 func (r *PostgresAlbumRepository) GetByID(id string) (*domain.Album, error) {
-	query := `SELECT id, title, artist, price, year, genre, condition, in_stock, created_at, updated_at 
-    		FROM albums WHERE id = $1`
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at 
+    		FROM albums WHERE id = $1 AND archived_at IS NULL`
 
 	var album domain.Album
 
@@ -87,7 +273,13 @@ func (r *PostgresAlbumRepository) GetByID(id string) (*domain.Album, error) {
 		&album.Year,
 		&album.Genre,
 		&album.Condition,
-		&album.InStock,
+		&album.StockQuantity,
+		&album.AverageRating,
+		&album.ReviewCount,
+		&album.LabelID,
+		&album.CatalogNumber,
+		&album.Description,
+		&album.CoverURL,
 		&album.CreatedAt,
 		&album.UpdatedAt,
 	)
@@ -106,8 +298,23 @@ func (r *PostgresAlbumRepository) GetByID(id string) (*domain.Album, error) {
 
 // Create - создает НОВЫЙ альбом в базе данных
 func (r *PostgresAlbumRepository) Create(album *domain.Album) error {
-	query := `INSERT INTO albums (id, title, artist, price, year, genre, condition, in_stock, created_at, updated_at)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	if err := insertAlbum(r.db, album); err != nil {
+		return err
+	}
+
+	log.Printf("Created album with ID: %s", album.ID)
+	return nil
+}
+
+// execer - *sql.DB или *sql.Tx: запрос можно выполнить и отдельно, и в транзакции
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// insertAlbum - вставляет альбом с новым ID и временем создания
+func insertAlbum(db execer, album *domain.Album) error {
+	query := `INSERT INTO albums (id, title, artist, price, year, genre, condition, stock_quantity, catalog_number, description, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	// Заполняем технические поля которые не приходят от пользователя
 	album.ID = generateID()
@@ -115,8 +322,8 @@ func (r *PostgresAlbumRepository) Create(album *domain.Album) error {
 	album.UpdatedAt = time.Now()
 
 	// db.Exec выполняет запрос НЕ возвращающий строки (INSERT, UPDATE, DELETE)
-	// Передаем все 10 параметров в правильном порядке
-	_, err := r.db.Exec(
+	// Передаем все 12 параметров в правильном порядке
+	_, err := db.Exec(
 		query,
 		album.ID,
 		album.Title,
@@ -125,7 +332,9 @@ func (r *PostgresAlbumRepository) Create(album *domain.Album) error {
 		album.Year,
 		album.Genre,
 		album.Condition,
-		album.InStock,
+		album.StockQuantity,
+		album.CatalogNumber,
+		album.Description,
 		album.CreatedAt,
 		album.UpdatedAt,
 	)
@@ -133,21 +342,26 @@ func (r *PostgresAlbumRepository) Create(album *domain.Album) error {
 	if err != nil {
 		return fmt.Errorf("failed to create album: %w", err)
 	}
-
-	log.Printf("Created album with ID: %s", album.ID)
 	return nil
 }
 
+// Update - изменяет альбом; у альбома с изданиями цена и количество - сводные по изданиям
+// и пересчитываются в том же UPDATE, значения из album записываются только альбому без изданий
 func (r *PostgresAlbumRepository) Update(album *domain.Album) error {
-	query := `UPDATE albums SET title = $1, artist = $2, price = $3, year = $4, genre = $5, condition = $6, in_stock = $7, updated_at = $8
-		WHERE id = $9`
+	query := `UPDATE albums SET title = $1, artist = $2,
+			price = COALESCE((SELECT MIN(price) FROM album_variants WHERE album_id = $11), $3),
+			year = $4, genre = $5, condition = $6,
+			stock_quantity = COALESCE((SELECT SUM(stock_quantity) FROM album_variants WHERE album_id = $11), $7),
+			catalog_number = $8, description = $9, updated_at = $10
+		WHERE id = $11 AND archived_at IS NULL
+		RETURNING price, stock_quantity`
 
 	// Обновляем время последнего изменения
 	album.UpdatedAt = time.Now()
 
-	// db.Exec выполняет запрос НЕ возвращающий строки (INSERT, UPDATE, DELETE)
+	// QueryRow - UPDATE возвращает итоговые цену и количество (у альбома с изданиями они могут отличаться от переданных)
 	// Передаем все параметры в правильном порядке
-	result, err := r.db.Exec(
+	err := r.db.QueryRow(
 		query,
 		album.Title,
 		album.Artist,
@@ -155,28 +369,68 @@ func (r *PostgresAlbumRepository) Update(album *domain.Album) error {
 		album.Year,
 		album.Genre,
 		album.Condition,
-		album.InStock,
+		album.StockQuantity,
+		album.CatalogNumber,
+		album.Description,
 		album.UpdatedAt,
 		album.ID,
-	)
+	).Scan(&album.Price, &album.StockQuantity)
 
+	// Если ни одна строка не обновлена - значит альбом с таким ID не найден
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("album with ID %s not found", album.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update album: %w", err)
 	}
 
-	// Проверяем был ли вообще обновлен какой-либо альбом
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("updating rows error: %w", err)
-	}
+	log.Printf("Updated album with ID: %s", album.ID)
+	return nil
+}
 
-	// Если ни одна строка не обновлена - значит альбом с таким ID не найден
-	if rowsAffected == 0 {
-		return fmt.Errorf("album with ID %s not found", album.ID)
+// AdjustStock - меняет количество на складе одним UPDATE: параллельные приходы и списания не теряются,
+// а списать больше, чем есть, не дает условие в WHERE (и CHECK на колонке)
+func (r *PostgresAlbumRepository) AdjustStock(id string, delta int) (*domain.Album, error) {
+	query := `UPDATE albums SET stock_quantity = stock_quantity + $1, updated_at = $2
+		WHERE id = $3 AND archived_at IS NULL AND stock_quantity + $1 >= 0
+		RETURNING id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at`
+
+	var album domain.Album
+	err := r.db.QueryRow(query, delta, time.Now(), id).Scan(
+		&album.ID,
+		&album.Title,
+		&album.Artist,
+		&album.Price,
+		&album.Year,
+		&album.Genre,
+		&album.Condition,
+		&album.StockQuantity,
+		&album.AverageRating,
+		&album.ReviewCount,
+		&album.LabelID,
+		&album.CatalogNumber,
+		&album.Description,
+		&album.CoverURL,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		// Ни одна строка не обновлена: альбома нет или на складе не хватает экземпляров
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM albums WHERE id = $1 AND archived_at IS NULL)`, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to adjust stock: %w", err)
+		}
+		if exists {
+			return nil, domain.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("album with ID %s not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
 	}
 
-	log.Printf("Updated album with ID: %s", album.ID)
-	return nil
+	log.Printf("Adjusted stock of album %s by %d", id, delta)
+	return &album, nil
 }
 
 func (r *PostgresAlbumRepository) Delete(id string) error {
@@ -204,8 +458,8 @@ func (r *PostgresAlbumRepository) Delete(id string) error {
 }
 
 func (r *PostgresAlbumRepository) GetByArtist(artist string) ([]domain.Album, error) {
-	query := `SELECT id, title, artist, price, year, genre, condition, in_stock, created_at, updated_at 
-    		FROM albums WHERE artist = $1
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at 
+    		FROM albums WHERE artist = $1 AND archived_at IS NULL
 			ORDER BY year DESC`
 
 	rows, err := r.db.Query(query, artist)
@@ -230,7 +484,13 @@ func (r *PostgresAlbumRepository) GetByArtist(artist string) ([]domain.Album, er
 			&album.Year,
 			&album.Genre,
 			&album.Condition,
-			&album.InStock,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
 			&album.CreatedAt,
 			&album.UpdatedAt,
 		)
@@ -251,8 +511,8 @@ func (r *PostgresAlbumRepository) GetByArtist(artist string) ([]domain.Album, er
 }
 
 func (r *PostgresAlbumRepository) GetInStock() ([]domain.Album, error) {
-	query := `SELECT id, title, artist, price, year, genre, condition, in_stock, created_at, updated_at
-	FROM albums WHERE in_stock = true
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+	FROM albums WHERE stock_quantity > 0 AND archived_at IS NULL
 	ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(query)
@@ -274,7 +534,13 @@ func (r *PostgresAlbumRepository) GetInStock() ([]domain.Album, error) {
 			&album.Year,
 			&album.Genre,
 			&album.Condition,
-			&album.InStock,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
 			&album.CreatedAt,
 			&album.UpdatedAt,
 		)
@@ -292,3 +558,55 @@ func (r *PostgresAlbumRepository) GetInStock() ([]domain.Album, error) {
 
 	return albums, nil
 }
+
+// IterateAll - построчно читает все альбомы из базы и отдает их через yield
+// В отличие от GetAll не собирает результат в слайс - в памяти держится одна строка
+func (r *PostgresAlbumRepository) IterateAll() iter.Seq2[domain.Album, error] {
+	return func(yield func(domain.Album, error) bool) {
+		query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+			FROM albums WHERE archived_at IS NULL ORDER BY created_at DESC`
+
+		rows, err := r.db.Query(query)
+		if err != nil {
+			yield(domain.Album{}, fmt.Errorf("failed to get albums: %w", err))
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var album domain.Album
+
+			err := rows.Scan(
+				&album.ID,
+				&album.Title,
+				&album.Artist,
+				&album.Price,
+				&album.Year,
+				&album.Genre,
+				&album.Condition,
+				&album.StockQuantity,
+				&album.AverageRating,
+				&album.ReviewCount,
+				&album.LabelID,
+				&album.CatalogNumber,
+				&album.Description,
+				&album.CoverURL,
+				&album.CreatedAt,
+				&album.UpdatedAt,
+			)
+			if err != nil {
+				yield(domain.Album{}, fmt.Errorf("failed to scan album: %w", err))
+				return
+			}
+
+			// Потребитель прекратил чтение - закрываем курсор
+			if !yield(album, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(domain.Album{}, fmt.Errorf("rows iteration error: %w", err))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresArchiveRepository - архивирование альбомов (колонка albums.archived_at)
+type PostgresArchiveRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresArchiveRepository - конструктор репозитория архивирования
+func NewPostgresArchiveRepository(db *sql.DB) *PostgresArchiveRepository {
+	return &PostgresArchiveRepository{db: db}
+}
+
+// ArchiveMatching - архивирует альбомы под фильтром и пишет аудит одной транзакцией
+// Количество сверяется по строкам, которые UPDATE действительно изменил: альбом, добавленный или
+// измененный между подсчетом у клиента и запросом, не будет тихо заархивирован вместе с остальными
+func (r *PostgresArchiveRepository) ArchiveMatching(filter domain.AlbumFilter, expected int, audit domain.AuditEntry) ([]domain.Album, error) {
+	details, err := json.Marshal(audit.Details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if audit.Details == nil {
+		details = []byte("{}")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает; при несовпадении количества откатывает архивирование
+
+	where, args := albumFilterWhere(filter)
+	query := fmt.Sprintf(`UPDATE albums SET archived_at = $%[1]d, updated_at = $%[1]d%s
+		RETURNING id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at`,
+		len(args)+1, where)
+
+	rows, err := tx.Query(query, append(args, time.Now())...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.Album
+	for rows.Next() {
+		var album domain.Album
+		err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.Artist,
+			&album.Price,
+			&album.Year,
+			&album.Genre,
+			&album.Condition,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan album: %w", err)
+		}
+		albums = append(albums, album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	if len(albums) != expected {
+		return nil, &domain.ArchiveCountMismatchError{Expected: expected, Matched: len(albums)}
+	}
+
+	ids := make([]string, len(albums))
+	for i, album := range albums {
+		ids[i] = album.ID
+	}
+	_, err = tx.Exec(`INSERT INTO audit_log (action, entity_type, entity_id, details)
+		SELECT $1, $2, unnest($3::text[]), $4`,
+		audit.Action, audit.EntityType, pq.Array(ids), details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record audit entries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit archive: %w", err)
+	}
+	return albums, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+)
+
+// PostgresAuditRepository - журнал аудита в таблице audit_log
+type PostgresAuditRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAuditRepository - конструктор репозитория аудита
+func NewPostgresAuditRepository(db *sql.DB) *PostgresAuditRepository {
+	return &PostgresAuditRepository{db: db}
+}
+
+// Record - добавляет запись в журнал
+func (r *PostgresAuditRepository) Record(entry domain.AuditEntry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if entry.Details == nil {
+		details = []byte("{}")
+	}
+
+	_, err = r.db.Exec(`INSERT INTO audit_log (action, entity_type, entity_id, details) VALUES ($1, $2, $3, $4)`,
+		entry.Action, entry.EntityType, entry.EntityID, details)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// List - возвращает последние записи журнала, новые первыми
+func (r *PostgresAuditRepository) List(entityType, entityID string, limit int) ([]domain.AuditEntry, error) {
+	query := `SELECT id, created_at, action, entity_type, entity_id, details
+		FROM audit_log
+		WHERE ($1 = '' OR entity_type = $1) AND ($2 = '' OR entity_id = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, entityType, entityID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.AuditEntry{}
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var details []byte
+
+		err := rows.Scan(&entry.ID, &entry.CreatedAt, &entry.Action, &entry.EntityType, &entry.EntityID, &details)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if err := json.Unmarshal(details, &entry.Details); err != nil {
+			return nil, fmt.Errorf("failed to decode audit details: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+)
+
+// PostgresBinRepository - места хранения пластинок на складе (колонка albums.bin_code)
+type PostgresBinRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBinRepository - конструктор репозитория мест хранения
+func NewPostgresBinRepository(db *sql.DB) *PostgresBinRepository {
+	return &PostgresBinRepository{db: db}
+}
+
+// SetBin - назначает альбому место хранения ("" - снять с места)
+func (r *PostgresBinRepository) SetBin(id, binCode string) error {
+	result, err := r.db.Exec(`UPDATE albums SET bin_code = NULLIF($1, '') WHERE id = $2 AND archived_at IS NULL`, binCode, id)
+	if err != nil {
+		return fmt.Errorf("failed to set bin: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// GetByBin - возвращает альбомы, лежащие в указанном месте
+func (r *PostgresBinRepository) GetByBin(binCode string) ([]domain.AdminAlbum, error) {
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at, cost_price, bin_code, condition_notes
+		FROM albums WHERE bin_code = $1 AND archived_at IS NULL ORDER BY artist, title`
+
+	rows, err := r.db.Query(query, binCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bin contents: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.AdminAlbum
+	for rows.Next() {
+		var album domain.AdminAlbum
+		if err := scanAdminAlbum(rows, &album); err != nil {
+			return nil, err
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, nil
+}
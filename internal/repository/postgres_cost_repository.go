@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+)
+
+// PostgresCostRepository - закупочные цены альбомов (колонка albums.cost_price)
+// Основной репозиторий альбомов эту колонку не читает и не пишет
+type PostgresCostRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCostRepository - конструктор репозитория закупочных цен
+func NewPostgresCostRepository(db *sql.DB) *PostgresCostRepository {
+	return &PostgresCostRepository{db: db}
+}
+
+// GetAll - возвращает все альбомы с закупочными ценами
+func (r *PostgresCostRepository) GetAll() ([]domain.AdminAlbum, error) {
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at, cost_price, bin_code, condition_notes
+		FROM albums WHERE archived_at IS NULL ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get albums with cost: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.AdminAlbum
+	for rows.Next() {
+		var album domain.AdminAlbum
+		if err := scanAdminAlbum(rows, &album); err != nil {
+			return nil, err
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, nil
+}
+
+// GetByID - возвращает альбом с закупочной ценой
+func (r *PostgresCostRepository) GetByID(id string) (*domain.AdminAlbum, error) {
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at, cost_price, bin_code, condition_notes
+		FROM albums WHERE id = $1 AND archived_at IS NULL`
+
+	var album domain.AdminAlbum
+	err := scanAdminAlbum(r.db.QueryRow(query, id), &album)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("album with ID %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+// SetCostPrice - задает закупочную цену (nil - очистить)
+func (r *PostgresCostRepository) SetCostPrice(id string, costPrice *float64) error {
+	result, err := r.db.Exec(`UPDATE albums SET cost_price = $1 WHERE id = $2 AND archived_at IS NULL`, costPrice, id)
+	if err != nil {
+		return fmt.Errorf("failed to set cost price: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// SetConditionNotes - задает внутренние заметки о состоянии экземпляра ("" - очистить)
+func (r *PostgresCostRepository) SetConditionNotes(id, notes string) error {
+	result, err := r.db.Exec(`UPDATE albums SET condition_notes = $1 WHERE id = $2 AND archived_at IS NULL`, notes, id)
+	if err != nil {
+		return fmt.Errorf("failed to set condition notes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// GetValuation - считает стоимость склада по ценам продажи и закупки
+func (r *PostgresCostRepository) GetValuation() (*domain.StockValuation, error) {
+	query := `SELECT
+			COUNT(*),
+			COALESCE(SUM(stock_quantity), 0),
+			COALESCE(SUM(price * stock_quantity), 0),
+			COALESCE(SUM(cost_price * stock_quantity), 0),
+			COALESCE(SUM((price - cost_price) * stock_quantity), 0),
+			COUNT(*) FILTER (WHERE cost_price IS NULL)
+		FROM albums WHERE stock_quantity > 0 AND archived_at IS NULL`
+
+	var valuation domain.StockValuation
+	err := r.db.QueryRow(query).Scan(
+		&valuation.InStockAlbums,
+		&valuation.InStockCopies,
+		&valuation.RetailValue,
+		&valuation.CostValue,
+		&valuation.Margin,
+		&valuation.MissingCostData,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock valuation: %w", err)
+	}
+
+	return &valuation, nil
+}
+
+// scanAdminAlbum - читает строку альбома со складскими данными и считает маржу
+// Порядок колонок: как в основном репозитории, затем cost_price, bin_code, condition_notes
+func scanAdminAlbum(row interface{ Scan(...any) error }, album *domain.AdminAlbum) error {
+	var costPrice sql.NullFloat64
+	var binCode sql.NullString
+
+	err := row.Scan(
+		&album.ID,
+		&album.Title,
+		&album.Artist,
+		&album.Price,
+		&album.Year,
+		&album.Genre,
+		&album.Condition,
+		&album.StockQuantity,
+		&album.AverageRating,
+		&album.ReviewCount,
+		&album.LabelID,
+		&album.CatalogNumber,
+		&album.Description,
+		&album.CoverURL,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+		&costPrice,
+		&binCode,
+		&album.ConditionNotes,
+	)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to scan album: %w", err)
+	}
+
+	if costPrice.Valid {
+		album.CostPrice = &costPrice.Float64
+	}
+	album.BinCode = binCode.String
+	album.CalculateMargin()
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// PostgresCoverRepository - адреса обложек альбомов (колонка albums.cover_url)
+type PostgresCoverRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCoverRepository - конструктор репозитория обложек
+func NewPostgresCoverRepository(db *sql.DB) *PostgresCoverRepository {
+	return &PostgresCoverRepository{db: db}
+}
+
+// SetCoverURL - заменяет адрес обложки и возвращает прежний, чтобы удалить старый файл из хранилища
+// Строка альбома блокируется: при параллельных загрузках каждая получит адрес, который заменила именно она
+func (r *PostgresCoverRepository) SetCoverURL(albumID, coverURL string) (string, *domain.Album, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	var previous string
+	err = tx.QueryRow(`SELECT cover_url FROM albums WHERE id = $1 AND archived_at IS NULL FOR UPDATE`, albumID).Scan(&previous)
+	if err == sql.ErrNoRows {
+		return "", nil, fmt.Errorf("album with ID %s not found", albumID)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get cover: %w", err)
+	}
+
+	query := `UPDATE albums SET cover_url = $1, updated_at = $2 WHERE id = $3
+		RETURNING id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at`
+
+	var album domain.Album
+	err = tx.QueryRow(query, coverURL, time.Now(), albumID).Scan(
+		&album.ID,
+		&album.Title,
+		&album.Artist,
+		&album.Price,
+		&album.Year,
+		&album.Genre,
+		&album.Condition,
+		&album.StockQuantity,
+		&album.AverageRating,
+		&album.ReviewCount,
+		&album.LabelID,
+		&album.CatalogNumber,
+		&album.Description,
+		&album.CoverURL,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to set cover: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", nil, fmt.Errorf("failed to commit cover: %w", err)
+	}
+	return previous, &album, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresExternalIDRepository - внешние id альбомов (таблица album_external_ids)
+type PostgresExternalIDRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresExternalIDRepository - конструктор репозитория внешних id
+func NewPostgresExternalIDRepository(db *sql.DB) *PostgresExternalIDRepository {
+	return &PostgresExternalIDRepository{db: db}
+}
+
+// GetExternalIDs - внешние id альбома (система -> id)
+func (r *PostgresExternalIDRepository) GetExternalIDs(albumID string) (map[string]string, error) {
+	rows, err := r.db.Query(`SELECT system, external_id FROM album_external_ids WHERE album_id = $1`, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external ids: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]string)
+	for rows.Next() {
+		var system, externalID string
+		if err := rows.Scan(&system, &externalID); err != nil {
+			return nil, fmt.Errorf("failed to scan external id: %w", err)
+		}
+		ids[system] = externalID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// SetExternalID - привязывает внешний id к альбому (заменяет прежний id той же системы)
+func (r *PostgresExternalIDRepository) SetExternalID(albumID, system, externalID string) error {
+	query := `INSERT INTO album_external_ids (album_id, system, external_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (album_id, system) DO UPDATE SET external_id = EXCLUDED.external_id`
+
+	if _, err := r.db.Exec(query, albumID, system, externalID); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return fmt.Errorf("%s id %s is already linked to another album", system, externalID)
+			case "foreign_key_violation":
+				return fmt.Errorf("album with ID %s not found", albumID)
+			}
+		}
+		return fmt.Errorf("failed to set external id: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExternalID - отвязывает внешний id системы от альбома
+func (r *PostgresExternalIDRepository) DeleteExternalID(albumID, system string) error {
+	_, err := r.db.Exec(`DELETE FROM album_external_ids WHERE album_id = $1 AND system = $2`, albumID, system)
+	if err != nil {
+		return fmt.Errorf("failed to delete external id: %w", err)
+	}
+	return nil
+}
+
+// FindAlbumIDs - наши id альбомов по списку внешних id одним запросом
+func (r *PostgresExternalIDRepository) FindAlbumIDs(system string, externalIDs []string) (map[string]string, error) {
+	rows, err := r.db.Query(`SELECT external_id, album_id FROM album_external_ids WHERE system = $1 AND external_id = ANY($2)`,
+		system, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find albums by external ids: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]string)
+	for rows.Next() {
+		var externalID, albumID string
+		if err := rows.Scan(&externalID, &albumID); err != nil {
+			return nil, fmt.Errorf("failed to scan external id: %w", err)
+		}
+		ids[externalID] = albumID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return ids, nil
+}
+
+// FindAlbumID - наш id альбома по внешнему id
+func (r *PostgresExternalIDRepository) FindAlbumID(system, externalID string) (string, error) {
+	var albumID string
+	err := r.db.QueryRow(`SELECT album_id FROM album_external_ids WHERE system = $1 AND external_id = $2`,
+		system, externalID).Scan(&albumID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("album not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find album by external id: %w", err)
+	}
+	return albumID, nil
+}
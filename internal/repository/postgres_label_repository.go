@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresLabelRepository - лейблы звукозаписи и их связь с альбомами
+type PostgresLabelRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLabelRepository - конструктор репозитория лейблов
+func NewPostgresLabelRepository(db *sql.DB) *PostgresLabelRepository {
+	return &PostgresLabelRepository{db: db}
+}
+
+// labelColumns - колонки лейбла с количеством альбомов (порядок совпадает с scanLabel)
+const labelColumns = `l.id, l.name, COALESCE(l.country, ''), COALESCE(l.founded_year, 0), l.created_at,
+	(SELECT COUNT(*) FROM albums a WHERE a.label_id = l.id AND a.archived_at IS NULL)`
+
+// scanLabel - читает лейбл из строки результата
+func scanLabel(row interface{ Scan(...any) error }, label *domain.Label) error {
+	return row.Scan(&label.ID, &label.Name, &label.Country, &label.FoundedYear, &label.CreatedAt, &label.AlbumCount)
+}
+
+// GetAll - все лейблы по алфавиту
+func (r *PostgresLabelRepository) GetAll() ([]domain.Label, error) {
+	rows, err := r.db.Query(`SELECT ` + labelColumns + ` FROM labels l ORDER BY LOWER(l.name)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []domain.Label
+	for rows.Next() {
+		var label domain.Label
+		if err := scanLabel(rows, &label); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return labels, nil
+}
+
+// GetByID - лейбл по ID
+func (r *PostgresLabelRepository) GetByID(id string) (*domain.Label, error) {
+	var label domain.Label
+	err := scanLabel(r.db.QueryRow(`SELECT `+labelColumns+` FROM labels l WHERE l.id = $1`, id), &label)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("label not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	return &label, nil
+}
+
+// Create - создает лейбл
+func (r *PostgresLabelRepository) Create(label *domain.Label) error {
+	label.ID = generateID()
+	label.CreatedAt = time.Now()
+
+	_, err := r.db.Exec(`INSERT INTO labels (id, name, country, founded_year, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, 0), $5)`,
+		label.ID, label.Name, label.Country, label.FoundedYear, label.CreatedAt)
+	if err != nil {
+		return labelWriteError("create", label.Name, err)
+	}
+	return nil
+}
+
+// Update - изменяет лейбл
+func (r *PostgresLabelRepository) Update(label *domain.Label) error {
+	result, err := r.db.Exec(`UPDATE labels SET name = $1, country = NULLIF($2, ''), founded_year = NULLIF($3, 0)
+		WHERE id = $4`,
+		label.Name, label.Country, label.FoundedYear, label.ID)
+	if err != nil {
+		return labelWriteError("update", label.Name, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("label with ID %s not found", label.ID)
+	}
+	return nil
+}
+
+// Delete - удаляет лейбл (альбомы остаются без лейбла)
+func (r *PostgresLabelRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM labels WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("label with ID %s not found", id)
+	}
+	return nil
+}
+
+// GetAlbums - альбомы лейбла
+func (r *PostgresLabelRepository) GetAlbums(labelID string) ([]domain.Album, error) {
+	query := `SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+		FROM albums WHERE label_id = $1 AND archived_at IS NULL ORDER BY year, artist, title`
+
+	rows, err := r.db.Query(query, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.Album
+	for rows.Next() {
+		var album domain.Album
+		err := rows.Scan(&album.ID, &album.Title, &album.Artist, &album.Price, &album.Year,
+			&album.Genre, &album.Condition, &album.StockQuantity, &album.AverageRating, &album.ReviewCount, &album.LabelID, &album.CatalogNumber, &album.Description, &album.CoverURL, &album.CreatedAt, &album.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan album: %w", err)
+		}
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, nil
+}
+
+// SetAlbumLabel - назначает альбому лейбл ("" - снять лейбл)
+func (r *PostgresLabelRepository) SetAlbumLabel(albumID, labelID string) error {
+	result, err := r.db.Exec(`UPDATE albums SET label_id = NULLIF($1, '') WHERE id = $2 AND archived_at IS NULL`, labelID, albumID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation" {
+			return fmt.Errorf("label with ID %s not found", labelID)
+		}
+		return fmt.Errorf("failed to set album label: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", albumID)
+	}
+	return nil
+}
+
+// labelWriteError - понятная ошибка для дубликата имени лейбла
+func labelWriteError(action, name string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return fmt.Errorf("label %q already exists", name)
+	}
+	return fmt.Errorf("failed to %s label: %w", action, err)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresLocalizationRepository - переводы названий и примечаний альбомов (колонка albums.localized)
+type PostgresLocalizationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLocalizationRepository - конструктор репозитория переводов
+func NewPostgresLocalizationRepository(db *sql.DB) *PostgresLocalizationRepository {
+	return &PostgresLocalizationRepository{db: db}
+}
+
+// GetLocalizations - переводы альбомов по id; несуществующих альбомов в ответе нет
+func (r *PostgresLocalizationRepository) GetLocalizations(albumIDs []string) (map[string]domain.AlbumLocalizations, error) {
+	rows, err := r.db.Query(`SELECT id, localized FROM albums WHERE id = ANY($1) AND archived_at IS NULL`, pq.Array(albumIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get localizations: %w", err)
+	}
+	defer rows.Close()
+
+	localizations := make(map[string]domain.AlbumLocalizations, len(albumIDs))
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan localizations: %w", err)
+		}
+
+		var localized domain.AlbumLocalizations
+		if err := json.Unmarshal(data, &localized); err != nil {
+			return nil, fmt.Errorf("failed to decode localizations of album %s: %w", id, err)
+		}
+		if localized == nil {
+			localized = domain.AlbumLocalizations{}
+		}
+		localizations[id] = localized
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return localizations, nil
+}
+
+// SetLocalization - добавляет или заменяет перевод альбома на одном языке
+func (r *PostgresLocalizationRepository) SetLocalization(albumID, language string, localization domain.AlbumLocalization) error {
+	data, err := json.Marshal(localization)
+	if err != nil {
+		return fmt.Errorf("failed to encode localization: %w", err)
+	}
+
+	query := `UPDATE albums SET localized = localized || jsonb_build_object($1::text, $2::jsonb), updated_at = $3
+		WHERE id = $4 AND archived_at IS NULL`
+	result, err := r.db.Exec(query, language, string(data), time.Now(), albumID)
+	if err != nil {
+		return fmt.Errorf("failed to set localization: %w", err)
+	}
+	return albumAffected(result, albumID)
+}
+
+// DeleteLocalization - удаляет перевод альбома на одном языке (отсутствующий перевод - не ошибка)
+func (r *PostgresLocalizationRepository) DeleteLocalization(albumID, language string) error {
+	query := `UPDATE albums SET localized = localized - $1::text, updated_at = $2 WHERE id = $3 AND archived_at IS NULL`
+	result, err := r.db.Exec(query, language, time.Now(), albumID)
+	if err != nil {
+		return fmt.Errorf("failed to delete localization: %w", err)
+	}
+	return albumAffected(result, albumID)
+}
+
+// albumAffected - ошибка "not found", если UPDATE не нашел альбом
+func albumAffected(result sql.Result, albumID string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("updating rows error: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", albumID)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresNoteRepository - внутренние заметки к альбомам (таблица album_notes)
+type PostgresNoteRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresNoteRepository - конструктор репозитория заметок
+func NewPostgresNoteRepository(db *sql.DB) *PostgresNoteRepository {
+	return &PostgresNoteRepository{db: db}
+}
+
+// GetNotes - заметки альбома от старых к новым
+func (r *PostgresNoteRepository) GetNotes(albumID string) ([]domain.AlbumNote, error) {
+	query := `SELECT id, album_id, author, body, mentions, created_at
+		FROM album_notes WHERE album_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Query(query, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []domain.AlbumNote{}
+	for rows.Next() {
+		var note domain.AlbumNote
+		err := rows.Scan(&note.ID, &note.AlbumID, &note.Author, &note.Body, pq.Array(&note.Mentions), &note.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		notes = append(notes, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return notes, nil
+}
+
+// Create - добавляет заметку, заполняет id и время создания
+func (r *PostgresNoteRepository) Create(note *domain.AlbumNote) error {
+	query := `INSERT INTO album_notes (album_id, author, body, mentions)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, note.AlbumID, note.Author, note.Body, pq.Array(note.Mentions)).
+		Scan(&note.ID, &note.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation" {
+			return fmt.Errorf("album with ID %s not found", note.AlbumID)
+		}
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - удаляет заметку альбома
+func (r *PostgresNoteRepository) Delete(albumID string, noteID int64) error {
+	result, err := r.db.Exec(`DELETE FROM album_notes WHERE album_id = $1 AND id = $2`, albumID, noteID)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting rows error: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("note with ID %d not found", noteID)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// PostgresReleaseRepository - даты выхода альбомов (колонка albums.release_date)
+type PostgresReleaseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReleaseRepository - конструктор репозитория дат выхода
+func NewPostgresReleaseRepository(db *sql.DB) *PostgresReleaseRepository {
+	return &PostgresReleaseRepository{db: db}
+}
+
+// SetReleaseDate - задает дату выхода альбома (nil - снять дату)
+func (r *PostgresReleaseRepository) SetReleaseDate(id string, date *time.Time) error {
+	var value any
+	if date != nil {
+		value = date.Format(time.DateOnly)
+	}
+
+	result, err := r.db.Exec(`UPDATE albums SET release_date = $1 WHERE id = $2 AND archived_at IS NULL`, value, id)
+	if err != nil {
+		return fmt.Errorf("failed to set release date: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("album with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// GetReleases - релизы начиная с даты, в порядке выхода
+func (r *PostgresReleaseRepository) GetReleases(filter domain.ReleaseFilter) ([]domain.Release, error) {
+	query := `SELECT id, title, artist, COALESCE(genre, ''), release_date
+		FROM albums
+		WHERE release_date >= $1 AND archived_at IS NULL
+			AND ($2 = '' OR LOWER(artist) = LOWER($2))
+			AND ($3 = '' OR LOWER(genre) = LOWER($3))
+		ORDER BY release_date, artist, title
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, filter.From.Format(time.DateOnly), filter.Artist, filter.Genre, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+	defer rows.Close()
+
+	var releases []domain.Release
+	for rows.Next() {
+		var release domain.Release
+		if err := rows.Scan(&release.AlbumID, &release.Title, &release.Artist, &release.Genre, &release.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("failed to scan release: %w", err)
+		}
+		releases = append(releases, release)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return releases, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresReviewRepository - отзывы покупателей к альбомам (таблица reviews)
+type PostgresReviewRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReviewRepository - конструктор репозитория отзывов
+func NewPostgresReviewRepository(db *sql.DB) *PostgresReviewRepository {
+	return &PostgresReviewRepository{db: db}
+}
+
+// GetReviews - отзывы альбома от новых к старым и общее их количество
+func (r *PostgresReviewRepository) GetReviews(albumID string, limit, offset int) ([]domain.Review, int, error) {
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM reviews WHERE album_id = $1`, albumID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	query := `SELECT id, album_id, customer_id, rating, text, created_at
+		FROM reviews WHERE album_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(query, albumID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []domain.Review{}
+	for rows.Next() {
+		var review domain.Review
+		err := rows.Scan(&review.ID, &review.AlbumID, &review.CustomerID, &review.Rating, &review.Text, &review.CreatedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+// Create - добавляет отзыв и пересчитывает рейтинг альбома одной транзакцией
+func (r *PostgresReviewRepository) Create(review *domain.Review) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	if err := lockAlbum(tx, review.AlbumID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO reviews (album_id, customer_id, rating, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err = tx.QueryRow(query, review.AlbumID, review.CustomerID, review.Rating, review.Text).
+		Scan(&review.ID, &review.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+			return domain.ErrDuplicateReview
+		}
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	if err := updateAlbumRating(tx, review.AlbumID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review: %w", err)
+	}
+	return nil
+}
+
+// Delete - удаляет отзыв покупателя к альбому и пересчитывает рейтинг альбома
+func (r *PostgresReviewRepository) Delete(albumID, customerID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAlbum(tx, albumID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM reviews WHERE album_id = $1 AND customer_id = $2`, albumID, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting rows error: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("review of album %s not found", albumID)
+	}
+
+	if err := updateAlbumRating(tx, albumID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review deletion: %w", err)
+	}
+	return nil
+}
+
+// lockAlbum - блокирует строку альбома до конца транзакции: параллельные отзывы
+// к одному альбому пересчитывают рейтинг по очереди и не затирают друг друга
+func lockAlbum(tx *sql.Tx, albumID string) error {
+	var id string
+	err := tx.QueryRow(`SELECT id FROM albums WHERE id = $1 AND archived_at IS NULL FOR UPDATE`, albumID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("album with ID %s not found", albumID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock album: %w", err)
+	}
+	return nil
+}
+
+// updateAlbumRating - пересчитывает среднюю оценку и число отзывов альбома
+func updateAlbumRating(tx *sql.Tx, albumID string) error {
+	_, err := tx.Exec(`UPDATE albums SET
+			review_count = (SELECT COUNT(*) FROM reviews WHERE album_id = $1),
+			average_rating = COALESCE((SELECT ROUND(AVG(rating), 2) FROM reviews WHERE album_id = $1), 0)
+		WHERE id = $1`, albumID)
+	if err != nil {
+		return fmt.Errorf("failed to update album rating: %w", err)
+	}
+	return nil
+}
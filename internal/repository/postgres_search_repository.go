@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strings"
+)
+
+// PostgresSearchRepository - поиск по каталогу в PostgreSQL
+// Использует триграммные индексы pg_trgm для ILIKE и поиска похожих строк
+type PostgresSearchRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSearchRepository - конструктор репозитория поиска
+func NewPostgresSearchRepository(db *sql.DB) *PostgresSearchRepository {
+	return &PostgresSearchRepository{db: db}
+}
+
+// Search - страница альбомов, подходящих под все условия фильтра (LIMIT/OFFSET в SQL),
+// и общее количество таких альбомов
+// Текстовые условия сравниваются без учета регистра по вхождению подстроки
+func (r *PostgresSearchRepository) Search(filter domain.SearchFilter, limit, offset int) ([]domain.Album, int, error) {
+	where, args := searchWhere(filter)
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM albums WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count found albums: %w", err)
+	}
+
+	// id в сортировке делает порядок однозначным: альбомы одного исполнителя и года не перескакивают между страницами
+	sqlQuery := fmt.Sprintf(`SELECT id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at
+		FROM albums WHERE %s ORDER BY artist, year, id
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(sqlQuery, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.Album
+	for rows.Next() {
+		var album domain.Album
+
+		err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.Artist,
+			&album.Price,
+			&album.Year,
+			&album.Genre,
+			&album.Condition,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan album: %w", err)
+		}
+
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, total, nil
+}
+
+// searchWhere - условие WHERE для фильтра поиска и его параметры
+func searchWhere(filter domain.SearchFilter) (string, []any) {
+	conditions := []string{"archived_at IS NULL"} // Архивные альбомы не ищутся
+	var args []any
+
+	// arg - добавляет параметр запроса и возвращает его placeholder ($1, $2...)
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	contains := func(s string) string {
+		return arg("%" + escapeLike(s) + "%")
+	}
+
+	// Слова ищутся и в лейбле с каталожным номером: "Blue Note BLP 1577" находит пластинку по ним,
+	// название - и в переводах: японское издание находится и по кандзи, и по ромадзи; а также в описании
+	for _, term := range filter.Terms {
+		p := contains(term)
+		condition := fmt.Sprintf("artist ILIKE %s OR title ILIKE %s OR %s ILIKE %s OR description ILIKE %s OR label_id IN (SELECT id FROM labels WHERE name ILIKE %s)",
+			p, p, localizedTitlesColumn, p, p, p)
+		if key := catalogNumberKey(term); key != "" {
+			condition += fmt.Sprintf(" OR %s LIKE %s", catalogNumberKeyColumn, arg("%"+key+"%"))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	for _, term := range filter.ExcludeTerms {
+		p := contains(term)
+		conditions = append(conditions, fmt.Sprintf("NOT (artist ILIKE %s OR title ILIKE %s OR COALESCE(genre, '') ILIKE %s)", p, p, p))
+	}
+	for _, artist := range filter.ExcludeArtists {
+		conditions = append(conditions, "artist NOT ILIKE "+contains(artist))
+	}
+	for _, genre := range filter.ExcludeGenres {
+		conditions = append(conditions, "COALESCE(genre, '') NOT ILIKE "+contains(genre))
+	}
+	if filter.Artist != "" {
+		conditions = append(conditions, "artist ILIKE "+contains(filter.Artist))
+	}
+	if filter.Title != "" {
+		p := contains(filter.Title)
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE %s OR %s ILIKE %s)", p, localizedTitlesColumn, p))
+	}
+	if filter.Genre != "" {
+		conditions = append(conditions, "genre ILIKE "+contains(filter.Genre))
+	}
+	if filter.Label != "" {
+		conditions = append(conditions, "label_id IN (SELECT id FROM labels WHERE name ILIKE "+contains(filter.Label)+")")
+	}
+	if key := catalogNumberKey(filter.CatalogNumber); key != "" {
+		conditions = append(conditions, catalogNumberKeyColumn+" LIKE "+arg("%"+key+"%"))
+	}
+	if filter.Condition != "" {
+		conditions = append(conditions, "condition = "+arg(filter.Condition))
+	}
+	if filter.YearFrom != 0 {
+		conditions = append(conditions, "year >= "+arg(filter.YearFrom))
+	}
+	if filter.YearTo != 0 {
+		conditions = append(conditions, "year <= "+arg(filter.YearTo))
+	}
+	if filter.PriceFrom != 0 {
+		conditions = append(conditions, "price >= "+arg(filter.PriceFrom))
+	}
+	if filter.PriceTo != 0 {
+		conditions = append(conditions, "price <= "+arg(filter.PriceTo))
+	}
+	if filter.InStock != nil {
+		conditions = append(conditions, "(stock_quantity > 0) = "+arg(*filter.InStock))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// SuggestCorrections - возвращает исполнителей и названия, похожие на запрос (триграммное сходство)
+func (r *PostgresSearchRepository) SuggestCorrections(query string, limit int) ([]string, error) {
+	sqlQuery := `SELECT term FROM (
+			SELECT artist AS term FROM albums WHERE archived_at IS NULL
+			UNION
+			SELECT title FROM albums WHERE archived_at IS NULL
+		) terms
+		WHERE term % $1
+		ORDER BY similarity(term, $1) DESC, term
+		LIMIT $2`
+
+	rows, err := r.db.Query(sqlQuery, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var terms []string
+	for rows.Next() {
+		var term string
+		if err := rows.Scan(&term); err != nil {
+			return nil, fmt.Errorf("failed to scan correction: %w", err)
+		}
+		terms = append(terms, term)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return terms, nil
+}
+
+// localizedTitlesColumn - переведенные названия альбома одной строкой (индекс idx_albums_localized_titles_trgm)
+const localizedTitlesColumn = `(jsonb_path_query_array(localized, '$.*.title')::text)`
+
+// catalogNumberKeyColumn - каталожный номер без регистра и разделителей (индекс idx_albums_catalog_number_trgm)
+const catalogNumberKeyColumn = `regexp_replace(LOWER(catalog_number), '[^a-z0-9]', '', 'g')`
+
+// catalogNumberKey - номер в том же виде, что catalogNumberKeyColumn: "BLP-1577" -> "blp1577"
+// Остаются только латинские буквы и цифры, поэтому экранировать LIKE не нужно
+func catalogNumberKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return -1
+	}, s)
+}
+
+// escapeLike - экранирует спецсимволы LIKE, чтобы они искались как обычные символы
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
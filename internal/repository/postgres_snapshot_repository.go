@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// snapshotColumns - колонки albums, которые сохраняются в снимке и восстанавливаются из него
+var snapshotColumns = []string{
+	"id", "title", "artist", "price", "year", "genre", "condition", "stock_quantity",
+	"created_at", "updated_at", "cost_price", "bin_code",
+}
+
+// PostgresSnapshotRepository - восстановление каталога из снимка через таблицу albums_restore_staging
+type PostgresSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSnapshotRepository - конструктор репозитория восстановления
+func NewPostgresSnapshotRepository(db *sql.DB) *PostgresSnapshotRepository {
+	return &PostgresSnapshotRepository{db: db}
+}
+
+// Stage - загружает снимок в промежуточную таблицу и возвращает отличия от текущего каталога
+// Таблица остается заполненной, чтобы ее можно было изучить вручную
+func (r *PostgresSnapshotRepository) Stage(albums []domain.AdminAlbum) (*domain.SnapshotDiff, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := loadStaging(tx, albums); err != nil {
+		return nil, err
+	}
+
+	diff := &domain.SnapshotDiff{}
+
+	diff.Restored, err = queryChanges(tx, `SELECT s.id, s.title, s.artist, '{}'::text[]
+		FROM albums_restore_staging s LEFT JOIN albums a ON a.id = s.id AND a.archived_at IS NULL
+		WHERE a.id IS NULL ORDER BY s.artist, s.title`)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Removed, err = queryChanges(tx, `SELECT a.id, a.title, a.artist, '{}'::text[]
+		FROM albums a LEFT JOIN albums_restore_staging s ON s.id = a.id
+		WHERE s.id IS NULL AND a.archived_at IS NULL ORDER BY a.artist, a.title`)
+	if err != nil {
+		return nil, err
+	}
+
+	// Для каждого альбома собираем имена отличающихся полей
+	var fieldChecks []string
+	for _, column := range snapshotColumns[1:] {
+		fieldChecks = append(fieldChecks, fmt.Sprintf("CASE WHEN a.%[1]s IS DISTINCT FROM s.%[1]s THEN '%[1]s' END", column))
+	}
+	diff.Changed, err = queryChanges(tx, `SELECT * FROM (
+			SELECT a.id, a.title, a.artist, array_remove(ARRAY[`+strings.Join(fieldChecks, ", ")+`], NULL) AS fields
+			FROM albums a JOIN albums_restore_staging s ON s.id = a.id
+			WHERE a.archived_at IS NULL
+		) changes
+		WHERE cardinality(fields) > 0 ORDER BY artist, title`)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit staging: %w", err)
+	}
+	return diff, nil
+}
+
+// Restore - заменяет каталог снимком и пишет аудит одной транзакцией
+// Альбомы, которых нет в снимке, удаляются (архивные остаются в архиве); остальные создаются или перезаписываются,
+// архивные альбомы из снимка возвращаются в каталог
+func (r *PostgresSnapshotRepository) Restore(albums []domain.AdminAlbum, audit domain.AuditEntry) error {
+	details, err := json.Marshal(audit.Details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if audit.Details == nil {
+		details = []byte("{}")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := loadStaging(tx, albums); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM albums WHERE archived_at IS NULL AND id NOT IN (SELECT id FROM albums_restore_staging)`); err != nil {
+		return fmt.Errorf("failed to remove albums missing from snapshot: %w", err)
+	}
+
+	var updates []string
+	for _, column := range snapshotColumns[1:] {
+		updates = append(updates, column+" = EXCLUDED."+column)
+	}
+	updates = append(updates, "archived_at = NULL")
+	columns := strings.Join(snapshotColumns, ", ")
+	_, err = tx.Exec(`INSERT INTO albums (` + columns + `)
+		SELECT ` + columns + ` FROM albums_restore_staging
+		ON CONFLICT (id) DO UPDATE SET ` + strings.Join(updates, ", "))
+	if err != nil {
+		return fmt.Errorf("failed to restore albums from snapshot: %w", err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO audit_log (action, entity_type, entity_id, details) VALUES ($1, $2, $3, $4)`,
+		audit.Action, audit.EntityType, audit.EntityID, details)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return nil
+}
+
+// loadStaging - очищает промежуточную таблицу и загружает в нее альбомы снимка
+func loadStaging(tx *sql.Tx, albums []domain.AdminAlbum) error {
+	if _, err := tx.Exec(`TRUNCATE albums_restore_staging`); err != nil {
+		return fmt.Errorf("failed to clear restore staging: %w", err)
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("albums_restore_staging", snapshotColumns...))
+	if err != nil {
+		return fmt.Errorf("failed to prepare staging load: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, album := range albums {
+		_, err := stmt.Exec(
+			album.ID,
+			album.Title,
+			album.Artist,
+			album.Price,
+			album.Year,
+			album.Genre,
+			album.Condition,
+			album.StockQuantity,
+			album.CreatedAt,
+			album.UpdatedAt,
+			album.CostPrice,
+			sql.NullString{String: album.BinCode, Valid: album.BinCode != ""},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to stage album %s: %w", album.ID, err)
+		}
+	}
+
+	// Пустой Exec завершает COPY
+	if _, err := stmt.Exec(); err != nil {
+		return fmt.Errorf("failed to load restore staging: %w", err)
+	}
+	return nil
+}
+
+// queryChanges - выполняет запрос, возвращающий (id, title, artist, fields)
+func queryChanges(tx *sql.Tx, query string) ([]domain.SnapshotChange, error) {
+	rows, err := tx.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare snapshot: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.SnapshotChange{}
+	for rows.Next() {
+		var change domain.SnapshotChange
+		if err := rows.Scan(&change.ID, &change.Title, &change.Artist, pq.Array(&change.Fields)); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
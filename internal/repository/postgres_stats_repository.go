@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+)
+
+// PostgresStatsRepository - читает статистику каталога из материализованного представления album_stats
+type PostgresStatsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresStatsRepository - конструктор репозитория статистики
+func NewPostgresStatsRepository(db *sql.DB) *PostgresStatsRepository {
+	return &PostgresStatsRepository{db: db}
+}
+
+// GetStats - возвращает последнюю рассчитанную статистику (без агрегации по таблице albums)
+func (r *PostgresStatsRepository) GetStats() (*domain.AlbumStats, error) {
+	query := `SELECT total_albums, in_stock_albums, total_artists, avg_price, min_price, max_price, stock_value, refreshed_at
+		FROM album_stats WHERE id = 1`
+
+	var stats domain.AlbumStats
+	err := r.db.QueryRow(query).Scan(
+		&stats.TotalAlbums,
+		&stats.InStockAlbums,
+		&stats.TotalArtists,
+		&stats.AvgPrice,
+		&stats.MinPrice,
+		&stats.MaxPrice,
+		&stats.StockValue,
+		&stats.RefreshedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("album stats are not calculated yet")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get album stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// RefreshStats - пересчитывает материализованное представление
+// CONCURRENTLY позволяет читать старую статистику во время пересчета
+func (r *PostgresStatsRepository) RefreshStats() error {
+	if _, err := r.db.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY album_stats`); err != nil {
+		return fmt.Errorf("failed to refresh album stats: %w", err)
+	}
+	return nil
+}
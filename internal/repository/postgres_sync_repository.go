@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+)
+
+// PostgresSyncRepository - журнал изменений каталога (albums.change_seq и album_tombstones)
+type PostgresSyncRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSyncRepository - конструктор репозитория синхронизации
+func NewPostgresSyncRepository(db *sql.DB) *PostgresSyncRepository {
+	return &PostgresSyncRepository{db: db}
+}
+
+// GetChanges - изменения с номером больше after в порядке номеров
+// Альбом присоединяется только к своему последнему изменению, поэтому каждый живой альбом
+// встречается в журнале один раз, а удаленный - только в виде tombstone
+// Архивирование тоже меняет номер альбома, и клиент получает его как удаление
+func (r *PostgresSyncRepository) GetChanges(after int64, limit int) ([]domain.SyncChange, error) {
+	query := `SELECT c.seq, c.id,
+			a.id, a.title, a.artist, a.price, a.year, a.genre, a.condition, a.stock_quantity, a.average_rating, a.review_count, a.label_id, a.catalog_number, a.description, a.cover_url, a.created_at, a.updated_at
+		FROM (
+			SELECT change_seq AS seq, id FROM albums WHERE change_seq > $1
+			UNION ALL
+			SELECT seq, album_id FROM album_tombstones WHERE seq > $1
+		) c
+		LEFT JOIN albums a ON a.id = c.id AND a.change_seq = c.seq AND a.archived_at IS NULL
+		ORDER BY c.seq
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.SyncChange
+	for rows.Next() {
+		var (
+			change                         domain.SyncChange
+			id, title, artist, genre, cond sql.NullString
+			labelID, catalogNumber         sql.NullString
+			description, coverURL          sql.NullString
+			price                          sql.NullFloat64
+			year                           sql.NullInt64
+			stockQuantity, reviewCount     sql.NullInt64
+			averageRating                  sql.NullFloat64
+			createdAt, updatedAt           sql.NullTime
+		)
+		err := rows.Scan(&change.Seq, &change.AlbumID,
+			&id, &title, &artist, &price, &year, &genre, &cond, &stockQuantity, &averageRating, &reviewCount, &labelID, &catalogNumber, &description, &coverURL, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+
+		if id.Valid {
+			change.Album = &domain.SyncAlbum{
+				Album: domain.Album{
+					ID:            id.String,
+					Title:         title.String,
+					Artist:        artist.String,
+					Price:         price.Float64,
+					Year:          int(year.Int64),
+					Genre:         genre.String,
+					Condition:     cond.String,
+					StockQuantity: int(stockQuantity.Int64),
+					AverageRating: averageRating.Float64,
+					ReviewCount:   int(reviewCount.Int64),
+					LabelID:       labelID.String,
+					CatalogNumber: catalogNumber.String,
+					Description:   description.String,
+					CoverURL:      coverURL.String,
+					CreatedAt:     createdAt.Time,
+					UpdatedAt:     updatedAt.Time,
+				},
+				Version: change.Seq,
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetChecksum - контрольная сумма всего каталога
+// Сортируем в "C" collation: порядок должен совпадать с побайтовой сортировкой на клиенте
+func (r *PostgresSyncRepository) GetChecksum() (*domain.SyncChecksum, error) {
+	query := `SELECT COUNT(*), md5(COALESCE(string_agg(id || ':' || change_seq, ',' ORDER BY id COLLATE "C"), ''))
+		FROM albums WHERE archived_at IS NULL`
+
+	var checksum domain.SyncChecksum
+	if err := r.db.QueryRow(query).Scan(&checksum.Count, &checksum.Checksum); err != nil {
+		return nil, fmt.Errorf("failed to get checksum: %w", err)
+	}
+	return &checksum, nil
+}
+
+// GetTombstone - последнее удаление альбома; nil, если альбом не удалялся или снова существует
+func (r *PostgresSyncRepository) GetTombstone(albumID string) (*domain.AlbumTombstone, error) {
+	query := `SELECT album_id, deleted_at FROM album_tombstones
+		WHERE album_id = $1 AND NOT EXISTS (SELECT 1 FROM albums WHERE id = $1)
+		ORDER BY seq DESC
+		LIMIT 1`
+
+	var tombstone domain.AlbumTombstone
+	err := r.db.QueryRow(query, albumID).Scan(&tombstone.AlbumID, &tombstone.DeletedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tombstone: %w", err)
+	}
+
+	return &tombstone, nil
+}
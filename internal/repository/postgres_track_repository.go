@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresTrackRepository - треки альбомов (таблица tracks)
+type PostgresTrackRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTrackRepository - конструктор репозитория треков
+func NewPostgresTrackRepository(db *sql.DB) *PostgresTrackRepository {
+	return &PostgresTrackRepository{db: db}
+}
+
+// GetTracks - треки альбома по порядку
+func (r *PostgresTrackRepository) GetTracks(albumID string) ([]domain.Track, error) {
+	query := `SELECT id, album_id, position, title, duration_seconds
+		FROM tracks WHERE album_id = $1 ORDER BY position`
+
+	rows, err := r.db.Query(query, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracks: %w", err)
+	}
+	defer rows.Close()
+
+	tracks := []domain.Track{}
+	for rows.Next() {
+		var track domain.Track
+		if err := rows.Scan(&track.ID, &track.AlbumID, &track.Position, &track.Title, &track.DurationSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan track: %w", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return tracks, nil
+}
+
+// ReplaceTracks - заменяет весь список треков альбома одной транзакцией
+// Строка альбома блокируется, чтобы параллельные изменения списка не перемешались
+func (r *PostgresTrackRepository) ReplaceTracks(albumID string, tracks []domain.Track) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	if err := lockAlbum(tx, albumID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM tracks WHERE album_id = $1`, albumID); err != nil {
+		return fmt.Errorf("failed to delete tracks: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO tracks (album_id, position, title, duration_seconds)
+		VALUES ($1, $2, $3, $4) RETURNING id`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare track insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := range tracks {
+		track := &tracks[i]
+		track.AlbumID = albumID
+		err := stmt.QueryRow(albumID, track.Position, track.Title, track.DurationSeconds).Scan(&track.ID)
+		if err != nil {
+			return trackWriteError("create", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tracks: %w", err)
+	}
+	return nil
+}
+
+// Create - добавляет трек; Position == 0 - в конец списка
+func (r *PostgresTrackRepository) Create(track *domain.Track) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка альбома не дает двум запросам занять одну и ту же "последнюю" позицию
+	if err := lockAlbum(tx, track.AlbumID); err != nil {
+		return err
+	}
+
+	if track.Position == 0 {
+		err := tx.QueryRow(`SELECT COALESCE(MAX(position), 0) + 1 FROM tracks WHERE album_id = $1`, track.AlbumID).
+			Scan(&track.Position)
+		if err != nil {
+			return fmt.Errorf("failed to get next track position: %w", err)
+		}
+	}
+
+	query := `INSERT INTO tracks (album_id, position, title, duration_seconds)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err = tx.QueryRow(query, track.AlbumID, track.Position, track.Title, track.DurationSeconds).Scan(&track.ID)
+	if err != nil {
+		return trackWriteError("create", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit track: %w", err)
+	}
+	return nil
+}
+
+// Update - изменяет позицию, название и длительность трека
+func (r *PostgresTrackRepository) Update(track *domain.Track) error {
+	query := `UPDATE tracks SET position = $1, title = $2, duration_seconds = $3
+		WHERE id = $4 AND album_id = $5`
+
+	result, err := r.db.Exec(query, track.Position, track.Title, track.DurationSeconds, track.ID, track.AlbumID)
+	if err != nil {
+		return trackWriteError("update", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("updating rows error: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("track %d of album %s not found", track.ID, track.AlbumID)
+	}
+	return nil
+}
+
+// Delete - удаляет трек альбома; позиции остальных треков не сдвигаются
+func (r *PostgresTrackRepository) Delete(albumID string, trackID int64) error {
+	result, err := r.db.Exec(`DELETE FROM tracks WHERE id = $1 AND album_id = $2`, trackID, albumID)
+	if err != nil {
+		return fmt.Errorf("failed to delete track: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting rows error: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("track %d of album %s not found", trackID, albumID)
+	}
+	return nil
+}
+
+// trackWriteError - понятная ошибка для занятой позиции трека
+func trackWriteError(action string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrDuplicateTrackPosition
+	}
+	return fmt.Errorf("failed to %s track: %w", action, err)
+}
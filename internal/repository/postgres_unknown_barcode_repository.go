@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// PostgresUnknownBarcodeRepository - очередь неизвестных штрихкодов (таблица unknown_barcodes)
+type PostgresUnknownBarcodeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUnknownBarcodeRepository - конструктор репозитория неизвестных штрихкодов
+func NewPostgresUnknownBarcodeRepository(db *sql.DB) *PostgresUnknownBarcodeRepository {
+	return &PostgresUnknownBarcodeRepository{db: db}
+}
+
+// Record - добавляет скан одним upsert: приемка не ждет лишних запросов
+func (r *PostgresUnknownBarcodeRepository) Record(barcode, condition string, price *float64, at time.Time) error {
+	_, err := r.db.Exec(`INSERT INTO unknown_barcodes (barcode, condition, price, scans, first_scanned_at, last_scanned_at)
+		VALUES ($1, NULLIF($2, ''), $3, 1, $4, $4)
+		ON CONFLICT (barcode) DO UPDATE SET
+			scans = unknown_barcodes.scans + 1,
+			condition = COALESCE(EXCLUDED.condition, unknown_barcodes.condition),
+			price = COALESCE(EXCLUDED.price, unknown_barcodes.price),
+			last_scanned_at = EXCLUDED.last_scanned_at`,
+		barcode, condition, price, at)
+	if err != nil {
+		return fmt.Errorf("failed to record unknown barcode: %w", err)
+	}
+	return nil
+}
+
+// List - штрихкоды в статусе status (пусто - все) от давно отсканированных к новым
+func (r *PostgresUnknownBarcodeRepository) List(status string, limit int) ([]domain.UnknownBarcode, error) {
+	query := `SELECT barcode, COALESCE(condition, ''), price, scans, first_scanned_at, last_scanned_at, status,
+			COALESCE(discogs_release_id, 0), COALESCE(title, ''), COALESCE(artist, ''), COALESCE(year, 0), COALESCE(genre, ''),
+			looked_up_at
+		FROM unknown_barcodes
+		WHERE $1 = '' OR status = $1
+		ORDER BY first_scanned_at, barcode
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unknown barcodes: %w", err)
+	}
+	defer rows.Close()
+
+	barcodes := []domain.UnknownBarcode{}
+	for rows.Next() {
+		var (
+			b          domain.UnknownBarcode
+			price      sql.NullFloat64
+			lookedUpAt sql.NullTime
+		)
+		err := rows.Scan(&b.Barcode, &b.Condition, &price, &b.Scans, &b.FirstScannedAt, &b.LastScannedAt, &b.Status,
+			&b.DiscogsReleaseID, &b.Title, &b.Artist, &b.Year, &b.Genre, &lookedUpAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unknown barcode: %w", err)
+		}
+		if price.Valid {
+			b.Price = &price.Float64
+		}
+		if lookedUpAt.Valid {
+			b.LookedUpAt = &lookedUpAt.Time
+		}
+		barcodes = append(barcodes, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return barcodes, nil
+}
+
+// SaveLookup - сохраняет результат поиска в Discogs
+func (r *PostgresUnknownBarcodeRepository) SaveLookup(b domain.UnknownBarcode) error {
+	_, err := r.db.Exec(`UPDATE unknown_barcodes SET status = $1, discogs_release_id = NULLIF($2, 0),
+			title = NULLIF($3, ''), artist = NULLIF($4, ''), year = NULLIF($5, 0), genre = NULLIF($6, ''), looked_up_at = $7
+		WHERE barcode = $8`,
+		b.Status, b.DiscogsReleaseID, b.Title, b.Artist, b.Year, b.Genre, b.LookedUpAt, b.Barcode)
+	if err != nil {
+		return fmt.Errorf("failed to save barcode lookup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"log"
+	"time"
+)
+
+// variantColumns - колонки издания в порядке scanVariant
+const variantColumns = `id, album_id, format, pressing_year, weight_grams, speed_rpm, price, stock_quantity, created_at, updated_at`
+
+// scanVariant - читает издание из строки результата
+func scanVariant(row interface{ Scan(...any) error }, variant *domain.AlbumVariant) error {
+	return row.Scan(
+		&variant.ID,
+		&variant.AlbumID,
+		&variant.Format,
+		&variant.PressingYear,
+		&variant.WeightGrams,
+		&variant.SpeedRPM,
+		&variant.Price,
+		&variant.StockQuantity,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+}
+
+// PostgresVariantRepository - издания альбомов (таблица album_variants)
+type PostgresVariantRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresVariantRepository - конструктор репозитория изданий
+func NewPostgresVariantRepository(db *sql.DB) *PostgresVariantRepository {
+	return &PostgresVariantRepository{db: db}
+}
+
+// GetVariants - издания альбома в порядке добавления
+func (r *PostgresVariantRepository) GetVariants(albumID string) ([]domain.AlbumVariant, error) {
+	query := `SELECT ` + variantColumns + ` FROM album_variants WHERE album_id = $1 ORDER BY id`
+
+	rows, err := r.db.Query(query, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := []domain.AlbumVariant{}
+	for rows.Next() {
+		var variant domain.AlbumVariant
+		if err := scanVariant(rows, &variant); err != nil {
+			return nil, fmt.Errorf("failed to scan variant: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return variants, nil
+}
+
+// CreateAlbum - создает альбом вместе с его первым изданием одной транзакцией
+// (альбом без издания не остается, если издание не удалось сохранить)
+func (r *PostgresVariantRepository) CreateAlbum(album *domain.Album, variant *domain.AlbumVariant) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	if err := insertAlbum(tx, album); err != nil {
+		return err
+	}
+
+	variant.AlbumID = album.ID
+	query := `INSERT INTO album_variants (album_id, format, pressing_year, weight_grams, speed_rpm, price, stock_quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + variantColumns
+	err = scanVariant(tx.QueryRow(query, variant.AlbumID, variant.Format, variant.PressingYear,
+		variant.WeightGrams, variant.SpeedRPM, variant.Price, variant.StockQuantity), variant)
+	if err != nil {
+		return fmt.Errorf("failed to create variant: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit album: %w", err)
+	}
+	log.Printf("Created album with ID: %s", album.ID)
+	return nil
+}
+
+// UpdateAlbum - изменяет альбом и цену и количество издания variant (nil - издания не меняются)
+// одной транзакцией; цена и количество альбома с изданиями затем пересчитываются по изданиям,
+// значения из album остаются только у альбома без изданий
+func (r *PostgresVariantRepository) UpdateAlbum(album *domain.Album, variant *domain.AlbumVariant) (*domain.Album, error) {
+	return r.write(album.ID, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`UPDATE albums SET title = $1, artist = $2, price = $3, year = $4, genre = $5, condition = $6,
+				stock_quantity = $7, catalog_number = $8, description = $9
+			WHERE id = $10`,
+			album.Title, album.Artist, album.Price, album.Year, album.Genre, album.Condition,
+			album.StockQuantity, album.CatalogNumber, album.Description, album.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update album: %w", err)
+		}
+		if variant == nil {
+			return nil
+		}
+
+		result, err := tx.Exec(`UPDATE album_variants SET price = $1, stock_quantity = $2, updated_at = $3
+			WHERE id = $4 AND album_id = $5`,
+			variant.Price, variant.StockQuantity, time.Now(), variant.ID, album.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update variant: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("updating rows error: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("variant %d of album %s not found", variant.ID, album.ID)
+		}
+		return nil
+	})
+}
+
+// Create - добавляет издание и пересчитывает цену и количество альбома
+func (r *PostgresVariantRepository) Create(variant *domain.AlbumVariant) (*domain.Album, error) {
+	return r.write(variant.AlbumID, func(tx *sql.Tx) error {
+		query := `INSERT INTO album_variants (album_id, format, pressing_year, weight_grams, speed_rpm, price, stock_quantity)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING ` + variantColumns
+
+		err := scanVariant(tx.QueryRow(query, variant.AlbumID, variant.Format, variant.PressingYear,
+			variant.WeightGrams, variant.SpeedRPM, variant.Price, variant.StockQuantity), variant)
+		if err != nil {
+			return fmt.Errorf("failed to create variant: %w", err)
+		}
+		return nil
+	})
+}
+
+// Update - изменяет издание и пересчитывает цену и количество альбома
+func (r *PostgresVariantRepository) Update(variant *domain.AlbumVariant) (*domain.Album, error) {
+	return r.write(variant.AlbumID, func(tx *sql.Tx) error {
+		query := `UPDATE album_variants SET format = $1, pressing_year = $2, weight_grams = $3, speed_rpm = $4,
+				price = $5, stock_quantity = $6, updated_at = $7
+			WHERE id = $8 AND album_id = $9
+			RETURNING ` + variantColumns
+
+		err := scanVariant(tx.QueryRow(query, variant.Format, variant.PressingYear, variant.WeightGrams, variant.SpeedRPM,
+			variant.Price, variant.StockQuantity, time.Now(), variant.ID, variant.AlbumID), variant)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("variant %d of album %s not found", variant.ID, variant.AlbumID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update variant: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete - удаляет издание; после удаления последнего цена и количество остаются у альбома
+func (r *PostgresVariantRepository) Delete(albumID string, variantID int64) (*domain.Album, error) {
+	return r.write(albumID, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM album_variants WHERE id = $1 AND album_id = $2`, variantID, albumID)
+		if err != nil {
+			return fmt.Errorf("failed to delete variant: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("deleting rows error: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("variant %d of album %s not found", variantID, albumID)
+		}
+		return nil
+	})
+}
+
+// AdjustStock - меняет количество издания на delta; списать больше, чем есть, не дает условие в WHERE
+func (r *PostgresVariantRepository) AdjustStock(albumID string, variantID int64, delta int) (*domain.AlbumVariant, *domain.Album, error) {
+	var variant domain.AlbumVariant
+	album, err := r.write(albumID, func(tx *sql.Tx) error {
+		query := `UPDATE album_variants SET stock_quantity = stock_quantity + $1, updated_at = $2
+			WHERE id = $3 AND album_id = $4 AND stock_quantity + $1 >= 0
+			RETURNING ` + variantColumns
+
+		err := scanVariant(tx.QueryRow(query, delta, time.Now(), variantID, albumID), &variant)
+		if err == sql.ErrNoRows {
+			// Ни одна строка не обновлена: издания нет или на складе не хватает экземпляров
+			var exists bool
+			err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM album_variants WHERE id = $1 AND album_id = $2)`,
+				variantID, albumID).Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("failed to adjust variant stock: %w", err)
+			}
+			if exists {
+				return domain.ErrInsufficientStock
+			}
+			return fmt.Errorf("variant %d of album %s not found", variantID, albumID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to adjust variant stock: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &variant, album, nil
+}
+
+// write - изменяет издания альбома и пересчитывает альбом одной транзакцией
+// Строка альбома блокируется, чтобы параллельные изменения изданий не дали неверную сумму
+func (r *PostgresVariantRepository) write(albumID string, change func(tx *sql.Tx) error) (*domain.Album, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	if err := lockAlbum(tx, albumID); err != nil {
+		return nil, err
+	}
+	if err := change(tx); err != nil {
+		return nil, err
+	}
+
+	album, err := updateAlbumFromVariants(tx, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit variants: %w", err)
+	}
+	return album, nil
+}
+
+// updateAlbumFromVariants - пересчитывает цену (минимальная по изданиям) и количество (сумма) альбома
+// Без изданий значения альбома не меняются
+func updateAlbumFromVariants(tx *sql.Tx, albumID string) (*domain.Album, error) {
+	query := `UPDATE albums SET
+			price = COALESCE((SELECT MIN(price) FROM album_variants WHERE album_id = $1), price),
+			stock_quantity = COALESCE((SELECT SUM(stock_quantity) FROM album_variants WHERE album_id = $1), stock_quantity),
+			updated_at = $2
+		WHERE id = $1
+		RETURNING id, title, artist, price, year, genre, condition, stock_quantity, average_rating, review_count, COALESCE(label_id, ''), catalog_number, description, cover_url, created_at, updated_at`
+
+	var album domain.Album
+	err := tx.QueryRow(query, albumID, time.Now()).Scan(
+		&album.ID,
+		&album.Title,
+		&album.Artist,
+		&album.Price,
+		&album.Year,
+		&album.Genre,
+		&album.Condition,
+		&album.StockQuantity,
+		&album.AverageRating,
+		&album.ReviewCount,
+		&album.LabelID,
+		&album.CatalogNumber,
+		&album.Description,
+		&album.CoverURL,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update album from variants: %w", err)
+	}
+	return &album, nil
+}
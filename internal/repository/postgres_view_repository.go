@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// PostgresViewRepository - хранит просмотры альбомов по дням в таблице album_views
+type PostgresViewRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresViewRepository - конструктор репозитория просмотров
+func NewPostgresViewRepository(db *sql.DB) *PostgresViewRepository {
+	return &PostgresViewRepository{db: db}
+}
+
+// AddViews - прибавляет просмотры за день одной транзакцией
+func (r *PostgresViewRepository) AddViews(day time.Time, counts map[string]int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // После Commit ничего не делает
+
+	// Удаленные альбомы пропускаем (WHERE EXISTS), чтобы не нарушать внешний ключ
+	stmt, err := tx.Prepare(`INSERT INTO album_views (album_id, day, views)
+		SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM albums WHERE id = $1 AND archived_at IS NULL)
+		ON CONFLICT (album_id, day) DO UPDATE SET views = album_views.views + EXCLUDED.views`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare views upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for albumID, views := range counts {
+		if _, err := stmt.Exec(albumID, day, views); err != nil {
+			return fmt.Errorf("failed to add views for album %s: %w", albumID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit views: %w", err)
+	}
+	return nil
+}
+
+// GetViews - возвращает просмотры альбома по дням начиная с since
+func (r *PostgresViewRepository) GetViews(albumID string, since time.Time) ([]domain.DailyViews, error) {
+	query := `SELECT day, views FROM album_views
+		WHERE album_id = $1 AND day >= $2
+		ORDER BY day DESC`
+
+	rows, err := r.db.Query(query, albumID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get album views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []domain.DailyViews
+	for rows.Next() {
+		var v domain.DailyViews
+		if err := rows.Scan(&v.Day, &v.Views); err != nil {
+			return nil, fmt.Errorf("failed to scan album views: %w", err)
+		}
+		views = append(views, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return views, nil
+}
+
+// GetTrending - возвращает самые просматриваемые альбомы начиная с since
+func (r *PostgresViewRepository) GetTrending(since time.Time, limit int) ([]domain.TrendingAlbum, error) {
+	query := `SELECT a.id, a.title, a.artist, a.price, a.year, a.genre, a.condition, a.stock_quantity, a.average_rating, a.review_count, COALESCE(a.label_id, ''), a.catalog_number, a.description, a.cover_url, a.created_at, a.updated_at,
+			SUM(v.views) AS total_views
+		FROM album_views v
+		JOIN albums a ON a.id = v.album_id
+		WHERE v.day >= $1 AND a.archived_at IS NULL
+		GROUP BY a.id
+		ORDER BY total_views DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []domain.TrendingAlbum
+	for rows.Next() {
+		var album domain.TrendingAlbum
+
+		err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.Artist,
+			&album.Price,
+			&album.Year,
+			&album.Genre,
+			&album.Condition,
+			&album.StockQuantity,
+			&album.AverageRating,
+			&album.ReviewCount,
+			&album.LabelID,
+			&album.CatalogNumber,
+			&album.Description,
+			&album.CoverURL,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+			&album.Views,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trending album: %w", err)
+		}
+
+		albums = append(albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return albums, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// PostgresZeroResultRepository - поиски без результатов по дням в таблице zero_result_searches
+type PostgresZeroResultRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresZeroResultRepository - конструктор репозитория поисков без результатов
+func NewPostgresZeroResultRepository(db *sql.DB) *PostgresZeroResultRepository {
+	return &PostgresZeroResultRepository{db: db}
+}
+
+// Record - прибавляет поиск к счетчику запроса за день
+func (r *PostgresZeroResultRepository) Record(query string, at time.Time) error {
+	_, err := r.db.Exec(`INSERT INTO zero_result_searches (day, query, searches, last_searched_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (day, query) DO UPDATE SET
+			searches = zero_result_searches.searches + 1,
+			last_searched_at = GREATEST(zero_result_searches.last_searched_at, EXCLUDED.last_searched_at)`,
+		at.UTC().Truncate(24*time.Hour), query, at)
+	if err != nil {
+		return fmt.Errorf("failed to record zero-result search: %w", err)
+	}
+	return nil
+}
+
+// List - самые частые запросы без результатов начиная с since
+func (r *PostgresZeroResultRepository) List(since time.Time, limit int) ([]domain.ZeroResultSearch, error) {
+	query := `SELECT query, SUM(searches), MAX(last_searched_at)
+		FROM zero_result_searches
+		WHERE day >= $1
+		GROUP BY query
+		ORDER BY SUM(searches) DESC, MAX(last_searched_at) DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zero-result searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []domain.ZeroResultSearch{}
+	for rows.Next() {
+		var s domain.ZeroResultSearch
+		if err := rows.Scan(&s.Query, &s.Searches, &s.LastSearchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan zero-result search: %w", err)
+		}
+		searches = append(searches, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return searches, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"slices"
+)
+
+// ErrInvalidArchive - запрос на архивирование без фильтра или без ожидаемого количества альбомов
+var ErrInvalidArchive = errors.New("invalid archive request")
+
+// ArchiveAlbums - архивирует все альбомы под фильтром списка (мягкое удаление)
+// expected - сколько альбомов клиент ожидает заархивировать (обычно X-Total-Count того же списка):
+// если под фильтр подходит другое количество, ничего не меняется и возвращается *domain.ArchiveCountMismatchError
+func (s *AlbumService) ArchiveAlbums(filter domain.AlbumFilter, expected int, reason, actor string) ([]domain.Album, error) {
+	if s.archive == nil {
+		return nil, fmt.Errorf("album archiving is not configured")
+	}
+	if err := validateAlbumFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	conditions := albumFilterDetails(filter)
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("%w: at least one filter is required, archiving the whole catalog is not allowed", ErrInvalidArchive)
+	}
+	if expected <= 0 {
+		return nil, fmt.Errorf("%w: expected_count must be positive", ErrInvalidArchive)
+	}
+
+	details := map[string]any{
+		"filter":         conditions,
+		"expected_count": expected,
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	if actor != "" {
+		details["actor"] = actor
+	}
+
+	albums, err := s.archive.ArchiveMatching(filter, expected, domain.AuditEntry{
+		Action:     "album_archived",
+		EntityType: "album",
+		Details:    details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Архивный альбом из базы уже не прочитать - кэш сбрасывается по возвращенным данным
+	s.invalidateAlbums(albums...)
+
+	var artists []string
+	ids := make([]string, 0, len(albums))
+	for i := range albums {
+		album := &albums[i]
+		s.notify(album, nil)
+
+		ids = append(ids, album.ID)
+		if !slices.Contains(artists, album.Artist) {
+			artists = append(artists, album.Artist)
+		}
+	}
+	s.purgeCDN(ids, artists)
+
+	return albums, nil
+}
+
+// albumFilterDetails - заданные условия фильтра для журнала аудита
+func albumFilterDetails(filter domain.AlbumFilter) map[string]any {
+	details := map[string]any{}
+	if filter.Genre != "" {
+		details["genre"] = filter.Genre
+	}
+	if filter.Condition != "" {
+		details["condition"] = filter.Condition
+	}
+	if filter.YearFrom != 0 {
+		details["year_from"] = filter.YearFrom
+	}
+	if filter.YearTo != 0 {
+		details["year_to"] = filter.YearTo
+	}
+	if filter.PriceMin != 0 {
+		details["price_min"] = filter.PriceMin
+	}
+	if filter.PriceMax != 0 {
+		details["price_max"] = filter.PriceMax
+	}
+	if filter.InStock != nil {
+		details["in_stock"] = *filter.InStock
+	}
+	return details
+}
@@ -2,18 +2,92 @@
 package service
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/cdn"
+	"go-music-shop/pkg/sanitize"
+	"iter"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// CatalogListener - получает уведомления об изменениях альбомов (поисковые индексы и т.п.)
+type CatalogListener interface {
+	// AlbumChanged - вызывается после записи: old == nil при создании, updated == nil при удалении
+	AlbumChanged(old, updated *domain.Album)
+}
+
 // AlbumService - сервис для работы с альбомами
 type AlbumService struct {
-	repo domain.AlbumRepository
+	repo      domain.AlbumRepository
+	purger    cdn.Purger        // Очистка кэша CDN после изменения альбомов
+	listeners []CatalogListener // Подписчики на изменения каталога
+	priceGuard *PriceGuard      // Проверка подозрительных цен (nil - без проверок)
+	tombstones domain.AlbumTombstoneReader // Следы удаленных альбомов (nil - повторное удаление считается ошибкой)
+	audit domain.AuditRepository // Журнал приходов и списаний со склада (nil - не записываются)
+	variants domain.AlbumVariantRepository // Издания альбомов (nil - цена и количество хранятся только в альбоме)
+	archive domain.AlbumArchiveRepository // Архивирование альбомов по фильтру (nil - выключено)
+}
+
+// AlbumDeletedError - альбом уже был удален раньше (повторный запрос на удаление)
+type AlbumDeletedError struct {
+	Tombstone domain.AlbumTombstone
+}
+
+func (e *AlbumDeletedError) Error() string {
+	return fmt.Sprintf("album with ID %s was already deleted at %s",
+		e.Tombstone.AlbumID, e.Tombstone.DeletedAt.UTC().Format(time.RFC3339))
 }
 
 // NewAlbumService - конструктор сервиса
-func NewAlbumService(repo domain.AlbumRepository) *AlbumService {
-	return &AlbumService{repo: repo}
+func NewAlbumService(repo domain.AlbumRepository, purger cdn.Purger) *AlbumService {
+	return &AlbumService{repo: repo, purger: purger}
+}
+
+// Subscribe - подписывает listener на изменения каталога
+// Вызывается при старте, до обработки запросов
+func (s *AlbumService) Subscribe(listener CatalogListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// SetPriceGuard - включает проверку подозрительных изменений цены
+func (s *AlbumService) SetPriceGuard(guard *PriceGuard) {
+	s.priceGuard = guard
+}
+
+// SetTombstoneReader - включает распознавание повторного удаления альбома
+func (s *AlbumService) SetTombstoneReader(tombstones domain.AlbumTombstoneReader) {
+	s.tombstones = tombstones
+}
+
+// SetAuditLog - включает запись приходов и списаний со склада в журнал аудита
+func (s *AlbumService) SetAuditLog(audit domain.AuditRepository) {
+	s.audit = audit
+}
+
+// SetVariantRepository - включает издания альбомов: цена и количество ведутся по изданиям
+func (s *AlbumService) SetVariantRepository(variants domain.AlbumVariantRepository) {
+	s.variants = variants
+}
+
+// SetArchiveRepository - включает массовое архивирование альбомов
+func (s *AlbumService) SetArchiveRepository(archive domain.AlbumArchiveRepository) {
+	s.archive = archive
+}
+
+// notify - сообщает подписчикам об изменении альбома
+func (s *AlbumService) notify(old, updated *domain.Album) {
+	for _, listener := range s.listeners {
+		listener.AlbumChanged(old, updated)
+	}
 }
 
 // GetAllAlbums - возвращает все альбомы
@@ -21,6 +95,129 @@ func (s *AlbumService) GetAllAlbums() ([]domain.Album, error) {
 	return s.repo.GetAll()
 }
 
+// MaxAlbumsPageSize - максимальный размер страницы списка альбомов
+const MaxAlbumsPageSize = 500
+
+// ErrInvalidAlbumFilter - недопустимые параметры списка альбомов (фильтр, сортировка, курсор, limit, offset)
+var ErrInvalidAlbumFilter = errors.New("invalid album filter")
+
+// albumConditions - допустимые состояния альбома (как в ограничении колонки albums.condition)
+var albumConditions = []string{"mint", "very good", "good", "fair", "poor"}
+
+// AlbumPage - страница списка альбомов
+type AlbumPage struct {
+	Albums     []domain.Album
+	Total      int    // Сколько всего альбомов подходит под фильтр
+	NextCursor string // Курсор следующей страницы ("" - страниц больше нет или задана сортировка)
+}
+
+// GetAlbumsPage - возвращает страницу альбомов, подходящих под фильтр
+// Следующую страницу можно запросить по offset или, в порядке по умолчанию, по курсору из NextCursor:
+// курсор не замедляется на дальних страницах большого каталога и не пропускает альбомы при вставках
+func (s *AlbumService) GetAlbumsPage(filter domain.AlbumFilter, cursor string, limit, offset int) (*AlbumPage, error) {
+	if limit <= 0 || limit > MaxAlbumsPageSize {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAlbumFilter, MaxAlbumsPageSize)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("%w: offset cannot be negative", ErrInvalidAlbumFilter)
+	}
+	if err := validateAlbumFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		if offset > 0 || len(filter.Sort) > 0 {
+			return nil, fmt.Errorf("%w: cursor cannot be combined with offset or sort", ErrInvalidAlbumFilter)
+		}
+		after, err := decodeAlbumCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	// Читаем на один альбом больше, чтобы узнать, есть ли следующая страница
+	albums, total, err := s.repo.GetPage(filter, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AlbumPage{Albums: albums, Total: total}
+	if len(albums) > limit {
+		page.Albums = albums[:limit]
+		if len(filter.Sort) == 0 {
+			page.NextCursor = encodeAlbumCursor(albums[limit-1])
+		}
+	}
+	return page, nil
+}
+
+// encodeAlbumCursor - непрозрачный для клиента курсор: created_at (в микросекундах, как в PostgreSQL) и id
+func encodeAlbumCursor(album domain.Album) string {
+	raw := fmt.Sprintf("%d:%s", album.CreatedAt.UnixMicro(), album.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeAlbumCursor - разбирает курсор из encodeAlbumCursor
+func decodeAlbumCursor(cursor string) (*domain.AlbumCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidAlbumFilter)
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	n, err := strconv.ParseInt(micros, 10, 64)
+	if !ok || err != nil || id == "" {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidAlbumFilter)
+	}
+
+	return &domain.AlbumCursor{CreatedAt: time.UnixMicro(n).UTC(), ID: id}, nil
+}
+
+// ParseAlbumSort - разбирает параметр сортировки списка: "price,-year" (минус - по убыванию)
+func ParseAlbumSort(value string) ([]domain.AlbumSort, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var order []domain.AlbumSort
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		field, desc := strings.CutPrefix(part, "-")
+		field = strings.ToLower(field)
+
+		if !slices.Contains(domain.AlbumSortFields, field) {
+			return nil, fmt.Errorf("%w: cannot sort by %q, expected one of: %s",
+				ErrInvalidAlbumFilter, part, strings.Join(domain.AlbumSortFields, ", "))
+		}
+		if slices.ContainsFunc(order, func(s domain.AlbumSort) bool { return s.Field == field }) {
+			return nil, fmt.Errorf("%w: sort field %q is repeated", ErrInvalidAlbumFilter, field)
+		}
+		order = append(order, domain.AlbumSort{Field: field, Desc: desc})
+	}
+	return order, nil
+}
+
+// validateAlbumFilter - проверяет фильтр и приводит состояние к нижнему регистру
+func validateAlbumFilter(filter *domain.AlbumFilter) error {
+	filter.Genre = strings.TrimSpace(filter.Genre)
+	filter.Condition = strings.ToLower(strings.TrimSpace(filter.Condition))
+
+	switch {
+	case filter.Condition != "" && !slices.Contains(albumConditions, filter.Condition):
+		return fmt.Errorf("%w: condition must be one of: %s", ErrInvalidAlbumFilter, strings.Join(albumConditions, ", "))
+	case filter.YearFrom < 0 || filter.YearTo < 0:
+		return fmt.Errorf("%w: year cannot be negative", ErrInvalidAlbumFilter)
+	case filter.YearTo != 0 && filter.YearFrom > filter.YearTo:
+		return fmt.Errorf("%w: year_from is greater than year_to", ErrInvalidAlbumFilter)
+	case filter.PriceMin < 0 || filter.PriceMax < 0:
+		return fmt.Errorf("%w: price cannot be negative", ErrInvalidAlbumFilter)
+	case filter.PriceMax != 0 && filter.PriceMin > filter.PriceMax:
+		return fmt.Errorf("%w: price_min is greater than price_max", ErrInvalidAlbumFilter)
+	}
+	return nil
+}
+
 // GetAlbumByID - возвращает альбом по ID
 func (s *AlbumService) GetAlbumByID(id string) (*domain.Album, error) {
 	if id == "" {
@@ -29,20 +226,76 @@ func (s *AlbumService) GetAlbumByID(id string) (*domain.Album, error) {
 	return s.repo.GetByID(id)
 }
 
+// GetAlbumsByIDs - альбомы по списку id одним запросом (из кэша, недостающие - из базы)
+func (s *AlbumService) GetAlbumsByIDs(ids []string) ([]domain.Album, error) {
+	if len(ids) == 0 {
+		return []domain.Album{}, nil
+	}
+	return s.repo.GetByIDs(ids)
+}
+
+// GetAlbumJSONByID - возвращает альбом по ID сразу в виде JSON
+// Если репозиторий умеет отдавать готовые байты (кэш) - используем их без повторной сериализации
+func (s *AlbumService) GetAlbumJSONByID(id string) ([]byte, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	if raw, ok := s.repo.(domain.RawAlbumReader); ok {
+		return raw.GetRawByID(id)
+	}
+
+	album, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(album)
+}
+
 // CreateAlbum - создает новый альбом с валидацией
-func (s *AlbumService) CreateAlbum(album *domain.Album) error {
+func (s *AlbumService) CreateAlbum(album *domain.Album, opts ...WriteOption) error {
 	if album.Title == "" {
 		return fmt.Errorf("title cannot be empty")
 	}
 	if album.Price < 0 {
 		return fmt.Errorf("price cannot be negative")
 	}
+	if album.StockQuantity < 0 {
+		return fmt.Errorf("stock quantity cannot be negative")
+	}
+	if err := normalizeCatalogNumber(album); err != nil {
+		return err
+	}
+	if err := sanitizeDescription(album); err != nil {
+		return err
+	}
+
+	// Рейтинг считается по отзывам, клиент его не задает; лейбл и обложка назначаются отдельно
+	album.AverageRating, album.ReviewCount = 0, 0
+	album.LabelID, album.CoverURL = "", ""
+
+	o := applyWriteOptions(opts)
+	if o.legacyStock {
+		album.StockQuantity = legacyStockQuantity(o.inStock, 0)
+	}
+
+	if s.priceGuard != nil {
+		if err := s.priceGuard.Check(album, nil, o.confirmPriceChange); err != nil {
+			return err
+		}
+	}
 
-	return s.repo.Create(album)
+	if err := s.createAlbum(album); err != nil {
+		return err
+	}
+
+	s.purgeCDN([]string{album.ID}, []string{album.Artist})
+	s.notify(nil, album)
+	return nil
 }
 
 // UpdateAlbum - обновляет поля альбома с валидацией
-func (s *AlbumService) UpdateAlbum(album *domain.Album) error {
+func (s *AlbumService) UpdateAlbum(album *domain.Album, opts ...WriteOption) error {
 	if album.ID == "" {
 		return fmt.Errorf("id cannot be empty")
 	}
@@ -52,6 +305,15 @@ func (s *AlbumService) UpdateAlbum(album *domain.Album) error {
 	if album.Price < 0 {
 		return fmt.Errorf("price cannot be negative")
 	}
+	if album.StockQuantity < 0 {
+		return fmt.Errorf("stock quantity cannot be negative")
+	}
+	if err := normalizeCatalogNumber(album); err != nil {
+		return err
+	}
+	if err := sanitizeDescription(album); err != nil {
+		return err
+	}
 
 	// Проверяем, существует ли альбом
 	existingAlbum, err := s.repo.GetByID(album.ID)
@@ -61,16 +323,272 @@ func (s *AlbumService) UpdateAlbum(album *domain.Album) error {
 	
 	// Сохраняем оригинальные поля, которые не должны меняться
 	album.CreatedAt = existingAlbum.CreatedAt
+	album.AverageRating, album.ReviewCount = existingAlbum.AverageRating, existingAlbum.ReviewCount
+	album.LabelID, album.CoverURL = existingAlbum.LabelID, existingAlbum.CoverURL
+
+	o := applyWriteOptions(opts)
+	if o.legacyStock {
+		album.StockQuantity = legacyStockQuantity(o.inStock, existingAlbum.StockQuantity)
+	}
+	if o.keepCatalogNumber {
+		album.CatalogNumber = existingAlbum.CatalogNumber
+	}
+	if o.keepDescription {
+		album.Description = existingAlbum.Description
+	}
+
+	// Цена и количество альбома - сводные по изданиям: меняются через единственное издание
+	var variants []domain.AlbumVariant
+	if s.variants != nil {
+		if variants, err = s.variants.GetVariants(album.ID); err != nil {
+			return err
+		}
+	}
+	if len(variants) > 1 {
+		// У нескольких изданий сводные значения не меняются - клиент мог просто прислать их обратно
+		album.Price, album.StockQuantity = existingAlbum.Price, existingAlbum.StockQuantity
+	}
+
+	if s.priceGuard != nil && album.Price != existingAlbum.Price {
+		err := s.priceGuard.Check(album, &existingAlbum.Price, o.confirmPriceChange)
+		if err != nil {
+			return err
+		}
+	}
 
-	return s.repo.Update(album)
-}	
+	if err := s.updateAlbum(existingAlbum, album, variants); err != nil {
+		return err
+	}
+
+	s.purgeCDN([]string{album.ID}, []string{existingAlbum.Artist, album.Artist})
+	s.notify(existingAlbum, album)
+	return nil
+}
+
+// LegacyStock - клиент не передал stock_quantity: количество считается по флагу наличия
+// (nil - не меняется, true - текущее или 1, если альбома не было, false - 0)
+func LegacyStock(inStock *bool) WriteOption {
+	return func(o *writeOptions) {
+		o.legacyStock = true
+		o.inStock = inStock
+	}
+}
+
+// KeepCatalogNumber - клиент не передал catalog_number (не знает про него): при обновлении номер не меняется
+func KeepCatalogNumber() WriteOption {
+	return func(o *writeOptions) {
+		o.keepCatalogNumber = true
+	}
+}
+
+// KeepDescription - клиент не передал description (не знает про него): при обновлении описание не меняется
+func KeepDescription() WriteOption {
+	return func(o *writeOptions) {
+		o.keepDescription = true
+	}
+}
+
+// maxDescriptionLength - описание для страницы товара, а не статья
+const maxDescriptionLength = 10000
+
+// sanitizeDescription - оставляет в описании только безопасный HTML и проверяет длину
+// Очистка на сервере: описание выводится на странице товара, и ему нельзя доверять как есть
+func sanitizeDescription(album *domain.Album) error {
+	album.Description = strings.TrimSpace(sanitize.HTML(album.Description))
+	if utf8.RuneCountInString(album.Description) > maxDescriptionLength {
+		return fmt.Errorf("description is longer than %d characters", maxDescriptionLength)
+	}
+	return nil
+}
+
+// maxCatalogNumberLength - как у колонки albums.catalog_number
+const maxCatalogNumberLength = 50
+
+// normalizeCatalogNumber - убирает лишние пробелы в каталожном номере и проверяет его длину
+func normalizeCatalogNumber(album *domain.Album) error {
+	album.CatalogNumber = strings.Join(strings.Fields(album.CatalogNumber), " ")
+	if utf8.RuneCountInString(album.CatalogNumber) > maxCatalogNumberLength {
+		return fmt.Errorf("catalog number is longer than %d characters", maxCatalogNumberLength)
+	}
+	return nil
+}
+
+// legacyStockQuantity - количество на складе для клиента, который передал только флаг наличия
+func legacyStockQuantity(inStock *bool, current int) int {
+	if inStock == nil {
+		return current
+	}
+	return domain.StockFromInStock(*inStock, current)
+}
+
+// ErrInvalidStockAdjustment - недопустимое изменение количества на складе
+var ErrInvalidStockAdjustment = errors.New("invalid stock adjustment")
+
+// SetStockQuantity - задает количество на складе (инвентаризация; для сотрудников склада без права менять каталог)
+func (s *AlbumService) SetStockQuantity(id string, quantity int) (*domain.Album, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("%w: stock quantity cannot be negative", ErrInvalidStockAdjustment)
+	}
+	return s.setStock(id, func(int) int { return quantity })
+}
+
+// SetInStock - меняет только наличие альбома, для клиентов, которые еще не передают количество
+// (true сохраняет текущее количество или ставит 1, false обнуляет)
+func (s *AlbumService) SetInStock(id string, inStock bool) (*domain.Album, error) {
+	return s.setStock(id, func(current int) int { return domain.StockFromInStock(inStock, current) })
+}
+
+// setStock - меняет количество на складе на значение, вычисленное из текущего
+func (s *AlbumService) setStock(id string, quantity func(current int) int) (*domain.Album, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	existingAlbum, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("album not found %w", err)
+	}
+	newQuantity := quantity(existingAlbum.StockQuantity)
+	if existingAlbum.StockQuantity == newQuantity {
+		return existingAlbum, nil
+	}
+
+	variant, err := s.albumVariant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	album := *existingAlbum
+	if variant != nil {
+		variant.StockQuantity = newQuantity
+		updated, err := s.variants.Update(variant)
+		if err != nil {
+			return nil, err
+		}
+		s.invalidateCache(id)
+		album = *updated
+	} else {
+		album.StockQuantity = newQuantity
+		if err := s.repo.Update(&album); err != nil {
+			return nil, err
+		}
+	}
+
+	s.purgeCDN([]string{album.ID}, []string{album.Artist})
+	s.notify(existingAlbum, &album)
+	return &album, nil
+}
+
+// AdjustStock - приход (delta > 0) или списание (delta < 0) экземпляров со склада с указанием причины
+// Изменение применяется атомарно в хранилище: параллельные корректировки не перезаписывают друг друга
+// actor - кто изменил (из токена), для журнала аудита
+func (s *AlbumService) AdjustStock(id string, delta int, reason, actor string) (*domain.Album, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	if delta == 0 {
+		return nil, fmt.Errorf("%w: delta cannot be zero", ErrInvalidStockAdjustment)
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidStockAdjustment)
+	}
+
+	// У альбома с одним изданием меняется количество издания, с несколькими - нужно указать издание
+	variant, err := s.albumVariant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var album *domain.Album
+	if variant != nil {
+		variant, album, err = s.variants.AdjustStock(id, variant.ID, delta)
+		if err == nil {
+			s.invalidateCache(id)
+		}
+	} else {
+		album, err = s.repo.AdjustStock(id, delta)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	old := *album
+	old.StockQuantity -= delta
+	s.recordStockAdjustment(album, variant, delta, reason, actor)
+	s.purgeCDN([]string{album.ID}, []string{album.Artist})
+	s.notify(&old, album)
+	return album, nil
+}
+
+// recordStockAdjustment - записывает корректировку склада в журнал аудита (variant == nil - альбом без изданий)
+func (s *AlbumService) recordStockAdjustment(album *domain.Album, variant *domain.AlbumVariant, delta int, reason, actor string) {
+	if s.audit == nil {
+		return
+	}
+
+	details := map[string]any{
+		"delta":    delta,
+		"quantity": album.StockQuantity,
+		"reason":   reason,
+	}
+	if variant != nil {
+		details["variant_id"] = variant.ID
+		details["variant_quantity"] = variant.StockQuantity
+	}
+	if actor != "" {
+		details["actor"] = actor
+	}
+	err := s.audit.Record(domain.AuditEntry{
+		Action:     "stock_adjusted",
+		EntityType: "album",
+		EntityID:   album.ID,
+		Details:    details,
+	})
+	if err != nil {
+		log.Printf("recording stock adjustment audit error: %v", err)
+	}
+}
 
 // DeleteAlbum - удаляет альбом по ID
+// Если альбом уже был удален раньше, возвращает *AlbumDeletedError: повтор запроса клиентом
+// или повторная доставка вебхука не должны выглядеть как обращение к несуществующему альбому
 func (s *AlbumService) DeleteAlbum(id string) error {
 	if id == "" {
 		return fmt.Errorf("id cannot be empty")
 	}
-	return s.repo.Delete(id)
+
+	// Получаем альбом перед удалением чтобы знать исполнителя для очистки CDN
+	album, _ := s.repo.GetByID(id)
+
+	if err := s.repo.Delete(id); err != nil {
+		if tombstone := s.findTombstone(id); tombstone != nil {
+			return &AlbumDeletedError{Tombstone: *tombstone}
+		}
+		return err
+	}
+
+	var artists []string
+	if album != nil {
+		artists = append(artists, album.Artist)
+		s.notify(album, nil)
+	}
+	s.purgeCDN([]string{id}, artists)
+	return nil
+}
+
+// findTombstone - след удаления альбома, если он уже был удален (nil - не удалялся или проверка выключена)
+func (s *AlbumService) findTombstone(id string) *domain.AlbumTombstone {
+	if s.tombstones == nil {
+		return nil
+	}
+
+	tombstone, err := s.tombstones.GetTombstone(id)
+	if err != nil {
+		log.Printf("reading album tombstone error: %v", err)
+		return nil
+	}
+	return tombstone
 }
 
 // GetAlbumsByArtist - возвращает альбомы по исполнителю
@@ -84,4 +602,41 @@ func (s *AlbumService) GetAlbumsByArtist(artist string) ([]domain.Album, error)
 // GetAlbumsInStock - проверяет в наличии ли альбом
 func (s *AlbumService) GetAlbumsInStock() ([]domain.Album, error) {
 	return s.repo.GetInStock()
-}
\ No newline at end of file
+}
+
+// StreamAllAlbums - потоково отдает весь каталог (для экспорта и админских выгрузок)
+func (s *AlbumService) StreamAllAlbums() iter.Seq2[domain.Album, error] {
+	return s.repo.IterateAll()
+}
+
+// purgeCDN - асинхронно очищает в CDN публичные страницы, зависящие от альбомов
+func (s *AlbumService) purgeCDN(ids []string, artists []string) {
+	purgeAlbumTags(s.purger, ids, artists)
+}
+
+// purgeAlbumTags - асинхронно очищает в CDN по тегам все копии страниц альбомов (с любыми параметрами
+// запроса и подресурсами), списки каталога и страницы исполнителей
+func purgeAlbumTags(purger cdn.Purger, ids []string, artists []string) {
+	if purger == nil {
+		return
+	}
+
+	tags := []string{cdn.CatalogTag}
+	for _, id := range ids {
+		tags = append(tags, cdn.AlbumTag(id))
+	}
+	for _, artist := range artists {
+		if artist != "" && !slices.Contains(tags, cdn.ArtistTag(artist)) {
+			tags = append(tags, cdn.ArtistTag(artist))
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := purger.PurgeTags(ctx, tags...); err != nil {
+			log.Printf("purging CDN cache error: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxVariantWeight - тяжелее 1 кг пластинок не бывает, это опечатка
+const maxVariantWeight = 1000
+
+// GetVariants - издания альбома
+func (s *AlbumService) GetVariants(albumID string) ([]domain.AlbumVariant, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	if s.variants == nil {
+		return []domain.AlbumVariant{}, nil
+	}
+	return s.variants.GetVariants(albumID)
+}
+
+// AddVariant - добавляет издание альбома; возвращает альбом с пересчитанными ценой и количеством
+func (s *AlbumService) AddVariant(variant *domain.AlbumVariant, opts ...WriteOption) (*domain.Album, error) {
+	existingAlbum, err := s.variantAlbum(variant.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVariant(variant); err != nil {
+		return nil, err
+	}
+	if err := s.checkVariantPrice(existingAlbum, variant, nil, opts); err != nil {
+		return nil, err
+	}
+
+	album, err := s.variants.Create(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	s.variantChanged(existingAlbum, album)
+	return album, nil
+}
+
+// UpdateVariant - изменяет издание альбома; возвращает альбом с пересчитанными ценой и количеством
+func (s *AlbumService) UpdateVariant(variant *domain.AlbumVariant, opts ...WriteOption) (*domain.Album, error) {
+	existingAlbum, err := s.variantAlbum(variant.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVariant(variant); err != nil {
+		return nil, err
+	}
+
+	variants, err := s.variants.GetVariants(variant.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(variants, func(v domain.AlbumVariant) bool { return v.ID == variant.ID })
+	if i < 0 {
+		return nil, fmt.Errorf("variant %d of album %s not found", variant.ID, variant.AlbumID)
+	}
+	if oldPrice := variants[i].Price; variant.Price != oldPrice {
+		if err := s.checkVariantPrice(existingAlbum, variant, &oldPrice, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	album, err := s.variants.Update(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	s.variantChanged(existingAlbum, album)
+	return album, nil
+}
+
+// DeleteVariant - удаляет издание альбома; после удаления последнего цена и количество остаются у альбома
+func (s *AlbumService) DeleteVariant(albumID string, variantID int64) (*domain.Album, error) {
+	existingAlbum, err := s.variantAlbum(albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	album, err := s.variants.Delete(albumID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.variantChanged(existingAlbum, album)
+	return album, nil
+}
+
+// AdjustVariantStock - приход или списание экземпляров конкретного издания (как AdjustStock для альбома)
+func (s *AlbumService) AdjustVariantStock(albumID string, variantID int64, delta int, reason, actor string) (*domain.AlbumVariant, *domain.Album, error) {
+	if albumID == "" {
+		return nil, nil, fmt.Errorf("id cannot be empty")
+	}
+	if s.variants == nil {
+		return nil, nil, fmt.Errorf("album variants are not enabled")
+	}
+	if delta == 0 {
+		return nil, nil, fmt.Errorf("%w: delta cannot be zero", ErrInvalidStockAdjustment)
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, nil, fmt.Errorf("%w: reason is required", ErrInvalidStockAdjustment)
+	}
+
+	variant, album, err := s.variants.AdjustStock(albumID, variantID, delta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	old := *album
+	old.StockQuantity -= delta
+	s.recordStockAdjustment(album, variant, delta, reason, actor)
+	s.variantChanged(&old, album)
+	return variant, album, nil
+}
+
+// variantAlbum - альбом, издания которого меняются
+func (s *AlbumService) variantAlbum(albumID string) (*domain.Album, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	if s.variants == nil {
+		return nil, fmt.Errorf("album variants are not enabled")
+	}
+
+	album, err := s.repo.GetByID(albumID)
+	if err != nil {
+		return nil, fmt.Errorf("album not found %w", err)
+	}
+	return album, nil
+}
+
+// albumVariant - единственное издание альбома, через которое меняются цена и количество альбома
+// nil - изданий нет (или они выключены): цена и количество хранятся в самом альбоме
+func (s *AlbumService) albumVariant(albumID string) (*domain.AlbumVariant, error) {
+	if s.variants == nil {
+		return nil, nil
+	}
+
+	variants, err := s.variants.GetVariants(albumID)
+	if err != nil {
+		return nil, err
+	}
+	switch len(variants) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &variants[0], nil
+	}
+	return nil, domain.ErrVariantRequired
+}
+
+// createAlbum - сохраняет новый альбом; с изданиями - вместе с изданием по умолчанию из его цены
+// и количества, одной транзакцией
+func (s *AlbumService) createAlbum(album *domain.Album) error {
+	if s.variants == nil {
+		return s.repo.Create(album)
+	}
+
+	err := s.variants.CreateAlbum(album, &domain.AlbumVariant{
+		Format:        domain.DefaultVariantFormat,
+		PressingYear:  album.Year,
+		Price:         album.Price,
+		StockQuantity: album.StockQuantity,
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidateAlbums(*album) // Альбом создан в обход репозитория альбомов: списки исполнителя и наличия
+	return nil
+}
+
+// updateAlbum - сохраняет изменения альбома; с изданиями - вместе с ценой и количеством единственного
+// издания одной транзакцией, сводные значения пересчитываются по изданиям
+// variants - издания альбома (у нескольких изданий цена и количество через альбом не меняются)
+func (s *AlbumService) updateAlbum(existing, album *domain.Album, variants []domain.AlbumVariant) error {
+	if len(variants) == 0 {
+		return s.repo.Update(album)
+	}
+
+	var variant *domain.AlbumVariant
+	if len(variants) == 1 && (variants[0].Price != album.Price || variants[0].StockQuantity != album.StockQuantity) {
+		variant = &variants[0]
+		variant.Price, variant.StockQuantity = album.Price, album.StockQuantity
+	}
+
+	updated, err := s.variants.UpdateAlbum(album, variant)
+	if err != nil {
+		return err
+	}
+	*album = *updated
+	s.invalidateAlbums(*existing, *album) // Прежний и новый исполнитель
+	return nil
+}
+
+// checkVariantPrice - проверяет цену издания так же, как цену альбома
+func (s *AlbumService) checkVariantPrice(album *domain.Album, variant *domain.AlbumVariant, oldPrice *float64, opts []WriteOption) error {
+	if s.priceGuard == nil {
+		return nil
+	}
+
+	checked := *album
+	checked.Price = variant.Price
+	return s.priceGuard.Check(&checked, oldPrice, applyWriteOptions(opts).confirmPriceChange)
+}
+
+// variantChanged - альбом изменен через издания в обход репозитория альбомов
+func (s *AlbumService) variantChanged(old, album *domain.Album) {
+	s.invalidateCache(album.ID)
+	s.purgeCDN([]string{album.ID}, []string{album.Artist})
+	s.notify(old, album)
+}
+
+// invalidateCache - сбрасывает кэш альбома, если репозиторий альбомов кэширующий
+func (s *AlbumService) invalidateCache(id string) {
+	if cache, ok := s.repo.(albumCache); ok {
+		cache.InvalidateAlbum(id)
+	}
+}
+
+// albumsCache - кэш, который сбрасывается по уже известным альбомам, без чтения из базы
+type albumsCache interface {
+	InvalidateAlbums(albums []domain.Album)
+}
+
+// invalidateAlbums - сбрасывает кэш альбомов и их исполнителей, если репозиторий альбомов кэширующий
+func (s *AlbumService) invalidateAlbums(albums ...domain.Album) {
+	if cache, ok := s.repo.(albumsCache); ok {
+		cache.InvalidateAlbums(albums)
+	}
+}
+
+// validateVariant - проверяет и нормализует поля издания
+func validateVariant(variant *domain.AlbumVariant) error {
+	variant.Format = strings.ToLower(strings.TrimSpace(variant.Format))
+
+	switch {
+	case !slices.Contains(domain.VariantFormats, variant.Format):
+		return fmt.Errorf("invalid format %q, expected one of %s", variant.Format, strings.Join(domain.VariantFormats, ", "))
+	case variant.Price < 0:
+		return fmt.Errorf("price cannot be negative")
+	case variant.StockQuantity < 0:
+		return fmt.Errorf("stock quantity cannot be negative")
+	case variant.PressingYear != 0 && (variant.PressingYear < 1900 || variant.PressingYear > time.Now().Year()+1):
+		return fmt.Errorf("pressing year must be between 1900 and %d", time.Now().Year()+1)
+	case variant.WeightGrams < 0 || variant.WeightGrams > maxVariantWeight:
+		return fmt.Errorf("weight must be between 0 and %d grams", maxVariantWeight)
+	case variant.SpeedRPM != 0 && !domain.IsVinylFormat(variant.Format):
+		return fmt.Errorf("speed is only set for vinyl formats")
+	case variant.SpeedRPM != 0 && !slices.Contains(domain.VariantSpeeds, variant.SpeedRPM):
+		return fmt.Errorf("invalid speed %d, expected 33, 45 or 78", variant.SpeedRPM)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"go-music-shop/pkg/auth"
+	"slices"
+)
+
+// Permission - действие, на которое нужно разрешение
+type Permission string
+
+const (
+	PermCatalogWrite Permission = "catalog:write" // Создание, изменение и удаление альбомов
+	PermStockWrite   Permission = "stock:write"   // Изменение наличия
+	PermOwnOrders    Permission = "orders:own"    // Свои корзины и заказы
+	PermReviewWrite  Permission = "reviews:write" // Свои отзывы к альбомам
+	PermAdmin        Permission = "admin"         // Админка (/admin) и служебные эндпоинты (/internal)
+	PermNotes        Permission = "notes"         // Внутренние заметки сотрудников к альбомам
+	PermDebugCapture Permission = "debug:capture" // Принудительная запись запроса заголовком X-Debug-Capture
+)
+
+// rolePermissions - что разрешено каждой роли; одна таблица для REST и gRPC
+var rolePermissions = map[string][]Permission{
+	auth.RoleAdmin:    {PermCatalogWrite, PermStockWrite, PermOwnOrders, PermReviewWrite, PermAdmin, PermNotes, PermDebugCapture},
+	auth.RoleStaff:    {PermStockWrite, PermReviewWrite, PermNotes, PermDebugCapture},
+	auth.RoleCustomer: {PermOwnOrders, PermReviewWrite},
+}
+
+var (
+	ErrUnauthenticated = errors.New("authorization required")
+	ErrForbidden       = errors.New("insufficient permissions")
+)
+
+// Authorize - проверяет, что пользователю разрешено действие хотя бы одной из его ролей
+func Authorize(claims *auth.Claims, perm Permission) error {
+	if claims == nil {
+		return ErrUnauthenticated
+	}
+	for _, role := range claims.Roles {
+		if slices.Contains(rolePermissions[role], perm) {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
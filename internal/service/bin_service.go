@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"regexp"
+	"strings"
+)
+
+// binCodePattern - формат кода места хранения: буквы, цифры и дефисы (A-03-2)
+var binCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{0,31}$`)
+
+// BinService - сервис мест хранения пластинок на складе
+type BinService struct {
+	repo domain.AlbumBinRepository
+}
+
+// NewBinService - конструктор сервиса мест хранения
+func NewBinService(repo domain.AlbumBinRepository) *BinService {
+	return &BinService{repo: repo}
+}
+
+// AssignBin - переносит альбом в место хранения ("" - снять с места)
+func (s *BinService) AssignBin(id, binCode string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	binCode = normalizeBinCode(binCode)
+	if binCode != "" && !binCodePattern.MatchString(binCode) {
+		return fmt.Errorf("invalid bin code %q", binCode)
+	}
+
+	return s.repo.SetBin(id, binCode)
+}
+
+// GetBinContents - возвращает альбомы, лежащие в месте хранения
+func (s *BinService) GetBinContents(binCode string) ([]domain.AdminAlbum, error) {
+	binCode = normalizeBinCode(binCode)
+	if binCode == "" {
+		return nil, fmt.Errorf("bin code cannot be empty")
+	}
+
+	albums, err := s.repo.GetByBin(binCode)
+	if err != nil {
+		return nil, err
+	}
+	if albums == nil {
+		albums = []domain.AdminAlbum{}
+	}
+	return albums, nil
+}
+
+// normalizeBinCode - коды пишутся на стеллажах как попало ("a-03-2 "), храним в одном виде
+func normalizeBinCode(binCode string) string {
+	return strings.ToUpper(strings.TrimSpace(binCode))
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// CostService - сервис закупочных цен и маржи (только для админки)
+type CostService struct {
+	repo domain.AlbumCostRepository
+}
+
+// NewCostService - конструктор сервиса закупочных цен
+func NewCostService(repo domain.AlbumCostRepository) *CostService {
+	return &CostService{repo: repo}
+}
+
+// GetAlbums - возвращает все альбомы с закупочными ценами и маржой
+func (s *CostService) GetAlbums() ([]domain.AdminAlbum, error) {
+	return s.repo.GetAll()
+}
+
+// GetAlbum - возвращает альбом с закупочной ценой и маржой
+func (s *CostService) GetAlbum(id string) (*domain.AdminAlbum, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetByID(id)
+}
+
+// SetCostPrice - задает закупочную цену альбома (nil - очистить)
+func (s *CostService) SetCostPrice(id string, costPrice *float64) (*domain.AdminAlbum, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	if costPrice != nil && *costPrice < 0 {
+		return nil, fmt.Errorf("cost price cannot be negative")
+	}
+
+	if err := s.repo.SetCostPrice(id, costPrice); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+// maxConditionNotesLength - заметки о состоянии экземпляра, а не описание альбома
+const maxConditionNotesLength = 2000
+
+// SetConditionNotes - задает внутренние заметки о состоянии экземпляра ("" - очистить)
+func (s *CostService) SetConditionNotes(id, notes string) (*domain.AdminAlbum, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	notes = strings.TrimSpace(notes)
+	if utf8.RuneCountInString(notes) > maxConditionNotesLength {
+		return nil, fmt.Errorf("condition notes are longer than %d characters", maxConditionNotesLength)
+	}
+
+	if err := s.repo.SetConditionNotes(id, notes); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+// GetValuation - оценка склада по ценам продажи и закупки
+func (s *CostService) GetValuation() (*domain.StockValuation, error) {
+	return s.repo.GetValuation()
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/cdn"
+	"go-music-shop/pkg/storage"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrInvalidCover - в запросе нет файла обложки или он пустой
+	ErrInvalidCover = errors.New("invalid cover")
+	// ErrCoverTooLarge - файл обложки больше COVER_MAX_BYTES
+	ErrCoverTooLarge = errors.New("cover is too large")
+	// ErrCoverType - файл не JPEG, PNG или WebP
+	ErrCoverType = errors.New("unsupported cover type")
+)
+
+// coverTypes - допустимые типы обложек и расширения ключей в хранилище
+// Тип определяется по содержимому файла, а не по заголовку Content-Type от клиента
+var coverTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// coverPrefix - префикс ключей обложек в хранилище
+const coverPrefix = "covers/"
+
+// CoverStorage - хранилище обложек: объекты доступны покупателям по публичному адресу
+type CoverStorage interface {
+	storage.ObjectStorage
+	PublicURL(key string) string
+}
+
+// CoverService - загрузка обложек альбомов в объектное хранилище
+// Ключ содержит хэш файла, поэтому адрес новой обложки всегда новый и ее можно кэшировать без срока
+type CoverService struct {
+	storage  CoverStorage
+	repo     domain.CoverRepository
+	maxBytes int64
+	cache    albumCache // nil - альбомы не кэшируются
+	purger   cdn.Purger // nil - CDN не очищается
+}
+
+// NewCoverService - конструктор сервиса обложек
+func NewCoverService(coverStorage CoverStorage, repo domain.CoverRepository, maxBytes int64) *CoverService {
+	return &CoverService{storage: coverStorage, repo: repo, maxBytes: maxBytes}
+}
+
+// SetCache - включает сброс кэша альбома после смены обложки
+func (s *CoverService) SetCache(cache albumCache) {
+	s.cache = cache
+}
+
+// SetPurger - включает очистку CDN после смены обложки: cover_url есть на странице альбома, в списках и у исполнителя
+func (s *CoverService) SetPurger(purger cdn.Purger) {
+	s.purger = purger
+}
+
+// MaxBytes - максимальный размер файла обложки
+func (s *CoverService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// UploadCover - сохраняет обложку альбома и заменяет ею прежнюю
+func (s *CoverService) UploadCover(ctx context.Context, albumID string, data []byte) (*domain.Album, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidCover)
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, fmt.Errorf("%w: maximum is %d bytes", ErrCoverTooLarge, s.maxBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := coverTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w %s, expected JPEG, PNG or WebP", ErrCoverType, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	key := coverPrefix + url.PathEscape(albumID) + "/" + hex.EncodeToString(sum[:8]) + ext
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		return nil, fmt.Errorf("failed to upload cover: %w", err)
+	}
+
+	coverURL := s.storage.PublicURL(key)
+	previous, album, err := s.repo.SetCoverURL(albumID, coverURL)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			s.deleteObject(ctx, coverURL) // Альбома нет - файл никому не нужен
+		}
+		return nil, err
+	}
+
+	s.replaced(ctx, album, previous, coverURL)
+	return album, nil
+}
+
+// DeleteCover - убирает обложку альбома
+func (s *CoverService) DeleteCover(ctx context.Context, albumID string) (*domain.Album, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	previous, album, err := s.repo.SetCoverURL(albumID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.replaced(ctx, album, previous, "")
+	return album, nil
+}
+
+// replaced - сбрасывает кэш альбома и CDN и удаляет файл прежней обложки
+func (s *CoverService) replaced(ctx context.Context, album *domain.Album, previous, current string) {
+	if s.cache != nil {
+		s.cache.InvalidateAlbum(album.ID)
+	}
+	purgeAlbumTags(s.purger, []string{album.ID}, []string{album.Artist})
+	if previous != "" && previous != current {
+		s.deleteObject(ctx, previous)
+	}
+}
+
+// deleteObject - удаляет файл обложки по ее адресу; ошибка только пишется в лог,
+// чтобы сбой хранилища не откатывал уже сохраненную обложку (останется лишний файл)
+func (s *CoverService) deleteObject(ctx context.Context, coverURL string) {
+	key, ok := s.coverKey(coverURL)
+	if !ok {
+		return // Адрес не из нашего хранилища (например, задан до смены S3_PUBLIC_URL)
+	}
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("deleting cover %s error: %v", key, err)
+	}
+}
+
+// coverKey - ключ объекта по публичному адресу обложки
+func (s *CoverService) coverKey(coverURL string) (string, bool) {
+	escaped, ok := strings.CutPrefix(coverURL, s.storage.PublicURL(""))
+	if !ok || !strings.HasPrefix(escaped, coverPrefix) {
+		return "", false
+	}
+	key, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
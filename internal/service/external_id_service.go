@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownExternalSystem - внешняя система не поддерживается
+var ErrUnknownExternalSystem = errors.New("unknown external system")
+
+// maxExternalIDLength - ограничение колонки album_external_ids.external_id
+const maxExternalIDLength = 128
+
+// ExternalIDService - сервис внешних id альбомов (Discogs, MusicBrainz, Shopify, касса)
+type ExternalIDService struct {
+	repo domain.AlbumExternalIDRepository
+}
+
+// NewExternalIDService - конструктор сервиса внешних id
+func NewExternalIDService(repo domain.AlbumExternalIDRepository) *ExternalIDService {
+	return &ExternalIDService{repo: repo}
+}
+
+// GetExternalIDs - внешние id альбома (система -> id)
+func (s *ExternalIDService) GetExternalIDs(albumID string) (map[string]string, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetExternalIDs(albumID)
+}
+
+// SetExternalID - привязывает внешний id к альбому
+func (s *ExternalIDService) SetExternalID(albumID, system, externalID string) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	system, err := normalizeExternalSystem(system)
+	if err != nil {
+		return err
+	}
+
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return fmt.Errorf("external id cannot be empty")
+	}
+	if len(externalID) > maxExternalIDLength {
+		return fmt.Errorf("external id is longer than %d characters", maxExternalIDLength)
+	}
+
+	return s.repo.SetExternalID(albumID, system, externalID)
+}
+
+// DeleteExternalID - отвязывает внешний id системы от альбома
+func (s *ExternalIDService) DeleteExternalID(albumID, system string) error {
+	system, err := normalizeExternalSystem(system)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteExternalID(albumID, system)
+}
+
+// FindAlbumID - наш id альбома по внешнему id
+func (s *ExternalIDService) FindAlbumID(system, externalID string) (string, error) {
+	system, err := normalizeExternalSystem(system)
+	if err != nil {
+		return "", err
+	}
+	return s.repo.FindAlbumID(system, strings.TrimSpace(externalID))
+}
+
+// FindAlbumIDs - наши id альбомов по списку внешних id одной системы (внешний id -> id)
+func (s *ExternalIDService) FindAlbumIDs(system string, externalIDs []string) (map[string]string, error) {
+	system, err := normalizeExternalSystem(system)
+	if err != nil {
+		return nil, err
+	}
+	if len(externalIDs) == 0 {
+		return map[string]string{}, nil
+	}
+	return s.repo.FindAlbumIDs(system, externalIDs)
+}
+
+// normalizeExternalSystem - приводит имя системы к нижнему регистру и проверяет что она поддерживается
+func normalizeExternalSystem(system string) (string, error) {
+	system = strings.ToLower(strings.TrimSpace(system))
+	if !slices.Contains(domain.ExternalSystems, system) {
+		return "", fmt.Errorf("%w %q, expected one of: %s", ErrUnknownExternalSystem, system, strings.Join(domain.ExternalSystems, ", "))
+	}
+	return system, nil
+}
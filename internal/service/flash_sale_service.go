@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/monitoring"
+	"go-music-shop/pkg/alert"
+	"go-music-shop/pkg/redis"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	flashSaleKey            = "flash_sale" // Окно распродажи в Redis - общее для всех экземпляров
+	flashSaleTrendingDays   = 7            // За сколько дней брать популярные альбомы для прогрева кэша
+	flashSaleReportInterval = time.Minute  // Как часто писать в лог трафик во время распродажи
+)
+
+// ErrInvalidFlashSale - некорректное окно распродажи
+var ErrInvalidFlashSale = errors.New("invalid flash sale window")
+
+// FlashSale - окно распродажи
+type FlashSale struct {
+	StartsAt time.Time                `json:"starts_at"`
+	EndsAt   time.Time                `json:"ends_at"`
+	Active   bool                     `json:"active"`            // Окно уже началось (на этом экземпляре)
+	Traffic  *monitoring.TrafficStats `json:"traffic,omitempty"` // Трафик этого экземпляра
+}
+
+// StockCacheTuner - кэш, время жизни данных о наличии в котором можно менять на ходу
+type StockCacheTuner interface {
+	SetStockTTL(ttl time.Duration) // 0 - стандартное время жизни
+}
+
+// RateLimitTuner - лимит частоты запросов, который можно временно ужесточить
+type RateLimitTuner interface {
+	Tighten(rps, burst int)
+	Reset()
+}
+
+// FlashSaleService - режим распродажи на заданное окно времени
+// Окно хранится в Redis, поэтому его подхватывают все экземпляры API (включая реплики
+// только для чтения); каждый экземпляр сам включает и выключает режим по часам
+type FlashSaleService struct {
+	redis   *redis.RedisClient
+	cfg     config.FlashSaleConfig
+	cache   StockCacheTuner
+	limiter RateLimitTuner
+	albums  *AlbumService
+	views   *ViewService
+	traffic *monitoring.TrafficMetrics
+	alerts  *alert.Notifier // Оповещения о начале и конце распродажи (nil - не отправляем)
+	key     string
+
+	mu     sync.Mutex
+	active *FlashSale // Действующее на этом экземпляре окно (nil - обычный режим)
+}
+
+// NewFlashSaleService - конструктор сервиса распродаж
+func NewFlashSaleService(
+	redisClient *redis.RedisClient,
+	cfg config.FlashSaleConfig,
+	cache StockCacheTuner,
+	limiter RateLimitTuner,
+	albums *AlbumService,
+	views *ViewService,
+	traffic *monitoring.TrafficMetrics,
+) *FlashSaleService {
+	return &FlashSaleService{
+		redis:   redisClient,
+		cfg:     cfg,
+		cache:   cache,
+		limiter: limiter,
+		albums:  albums,
+		views:   views,
+		traffic: traffic,
+		key:     flashSaleKey,
+	}
+}
+
+// SetNamespace - у каждого магазина свое окно распродажи; вызывается при старте
+func (s *FlashSaleService) SetNamespace(namespace string) {
+	if namespace != "" {
+		s.key = namespace + ":" + flashSaleKey
+	}
+}
+
+// SetNotifier - включает оповещения о начале и конце распродажи в чат команды
+func (s *FlashSaleService) SetNotifier(notifier *alert.Notifier) {
+	s.alerts = notifier
+}
+
+// Schedule - назначает распродажу; пустое начало - с текущего момента
+// Назначенная ранее распродажа заменяется
+func (s *FlashSaleService) Schedule(ctx context.Context, startsAt, endsAt time.Time) (*FlashSale, error) {
+	now := time.Now()
+	if startsAt.IsZero() || startsAt.Before(now) {
+		startsAt = now
+	}
+
+	maxDuration := time.Duration(s.cfg.MaxDuration) * time.Second
+	switch {
+	case !endsAt.After(startsAt):
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at and in the future", ErrInvalidFlashSale)
+	case endsAt.Sub(startsAt) > maxDuration:
+		return nil, fmt.Errorf("%w: flash sale cannot be longer than %s", ErrInvalidFlashSale, maxDuration)
+	}
+
+	sale := &FlashSale{StartsAt: startsAt.UTC(), EndsAt: endsAt.UTC()}
+	data, err := json.Marshal(sale)
+	if err != nil {
+		return nil, fmt.Errorf("encoding flash sale error: %w", err)
+	}
+
+	// Ключ истекает вместе с окном - даже если экземпляры не увидят DELETE, режим закончится сам
+	if err := s.redis.Set(ctx, s.key, data, time.Until(endsAt)); err != nil {
+		return nil, fmt.Errorf("saving flash sale error: %w", err)
+	}
+
+	s.apply(ctx, sale)
+	return s.Status(ctx)
+}
+
+// Cancel - досрочно завершает распродажу
+func (s *FlashSaleService) Cancel(ctx context.Context) error {
+	if err := s.redis.Delete(ctx, s.key); err != nil {
+		return fmt.Errorf("deleting flash sale error: %w", err)
+	}
+	s.apply(ctx, nil)
+	return nil
+}
+
+// Status - назначенная распродажа с трафиком этого экземпляра; nil - распродажа не назначена
+func (s *FlashSaleService) Status(ctx context.Context) (*FlashSale, error) {
+	sale, err := s.load(ctx)
+	if err != nil || sale == nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	sale.Active = s.active != nil
+	s.mu.Unlock()
+
+	traffic := s.traffic.Snapshot()
+	sale.Traffic = &traffic
+	return sale, nil
+}
+
+// load - читает окно распродажи из Redis
+func (s *FlashSaleService) load(ctx context.Context) (*FlashSale, error) {
+	data, err := s.redis.Get(ctx, s.key)
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, nil
+	}
+
+	var sale FlashSale
+	if err := json.Unmarshal([]byte(data), &sale); err != nil {
+		return nil, fmt.Errorf("decoding flash sale error: %w", err)
+	}
+	return &sale, nil
+}
+
+// StartWatcher - запускает фоновую проверку окна распродажи до отмены контекста:
+// режим включается в начале окна и выключается в конце без участия администратора
+func (s *FlashSaleService) StartWatcher(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Duration(s.cfg.CheckInterval) * time.Second)
+		defer ticker.Stop()
+
+		report := time.NewTicker(flashSaleReportInterval)
+		defer report.Stop()
+		lastReport, lastReportAt := s.traffic.Snapshot(), time.Now()
+
+		s.check(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.check(ctx)
+			case <-report.C:
+				var rate *monitoring.TrafficRate
+				lastReport, rate = s.traffic.Measure(lastReport, lastReportAt)
+				lastReportAt = rate.To
+				if s.isActive() {
+					log.Printf("flash sale traffic: %d requests (%.1f/s), %d rate limited",
+						rate.Requests, rate.RequestsPerSecond, rate.RateLimited)
+				}
+			}
+		}
+	}()
+}
+
+// check - сверяет режим этого экземпляра с окном в Redis
+func (s *FlashSaleService) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	sale, err := s.load(checkCtx)
+	if err != nil {
+		log.Printf("checking flash sale error: %v", err)
+		// Пока Redis недоступен, режим не меняем, но и не держим его дольше окна
+		s.mu.Lock()
+		sale = s.active
+		s.mu.Unlock()
+		if sale == nil {
+			return
+		}
+	}
+
+	if sale != nil && (now.Before(sale.StartsAt) || !now.Before(sale.EndsAt)) {
+		sale = nil // Окно еще не началось или уже закончилось
+	}
+	s.apply(ctx, sale)
+}
+
+// isActive - включен ли режим распродажи на этом экземпляре
+func (s *FlashSaleService) isActive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active != nil
+}
+
+// apply - включает режим распродажи (sale != nil и окно началось) или возвращает обычный
+func (s *FlashSaleService) apply(ctx context.Context, sale *FlashSale) {
+	if sale != nil && time.Now().Before(sale.StartsAt) {
+		sale = nil
+	}
+
+	s.mu.Lock()
+	previous := s.active
+	s.active = sale
+	s.mu.Unlock()
+
+	switch {
+	case sale != nil && (previous == nil || !previous.EndsAt.Equal(sale.EndsAt)):
+		s.cache.SetStockTTL(time.Duration(s.cfg.StockCacheTTL) * time.Second)
+		s.limiter.Tighten(s.cfg.RateLimitRPS, s.cfg.RateLimitBurst)
+		log.Printf("flash sale mode enabled until %s", sale.EndsAt.Format(time.RFC3339))
+		s.alerts.Notify(alert.Alert{
+			Type:    alert.TypeFlashSale,
+			Key:     "start:" + sale.StartsAt.Format(time.RFC3339),
+			Title:   "Flash sale started",
+			Message: fmt.Sprintf("Flash sale mode is on until %s", sale.EndsAt.Format(time.RFC3339)),
+		})
+		go s.prewarm(context.WithoutCancel(ctx))
+
+	case sale == nil && previous != nil:
+		s.cache.SetStockTTL(0)
+		s.limiter.Reset()
+		log.Printf("flash sale mode disabled")
+		s.alerts.Notify(alert.Alert{
+			Type:    alert.TypeFlashSale,
+			Key:     "end:" + previous.StartsAt.Format(time.RFC3339),
+			Title:   "Flash sale ended",
+			Message: "Flash sale mode is off, cache TTLs and rate limits are back to normal",
+		})
+	}
+}
+
+// prewarm - загружает в кэш самые просматриваемые альбомы и список альбомов в наличии,
+// чтобы первая волна покупателей не ушла в базу
+func (s *FlashSaleService) prewarm(ctx context.Context) {
+	if s.cfg.PrewarmCount <= 0 {
+		return
+	}
+
+	trending, err := s.views.GetTrending(flashSaleTrendingDays, s.cfg.PrewarmCount)
+	if err != nil {
+		log.Printf("loading trending albums for prewarm error: %v", err)
+	}
+
+	warmed := 0
+	for _, album := range trending {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.albums.GetAlbumByID(album.ID); err != nil {
+			log.Printf("prewarming album %s error: %v", album.ID, err)
+			continue
+		}
+		warmed++
+	}
+
+	if _, err := s.albums.GetAlbumsInStock(); err != nil {
+		log.Printf("prewarming albums in stock error: %v", err)
+	}
+	log.Printf("flash sale prewarm: %d albums loaded into cache", warmed)
+}
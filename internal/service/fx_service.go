@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/alert"
+	"go-music-shop/pkg/fx"
+	"go-music-shop/pkg/redis"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrUnknownCurrency - курса для валюты нет
+	ErrUnknownCurrency = errors.New("unknown currency")
+	// ErrStaleRates - курсы давно не обновлялись, конвертировать по ним нельзя
+	ErrStaleRates = errors.New("exchange rates are stale")
+)
+
+// currencyPattern - код валюты ISO 4217
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// cachedRates - курсы в кэше Redis (общие для всех экземпляров сервиса)
+type cachedRates struct {
+	Rates     map[string]float64 `json:"rates"`
+	FetchedAt time.Time          `json:"fetched_at"`
+}
+
+// FXService - сервис курсов валют
+// Курсы берутся у провайдера, кэшируются в Redis и держатся в памяти,
+// поэтому конвертация цен на запросе не делает сетевых вызовов
+type FXService struct {
+	provider        fx.Provider // nil - провайдер не настроен, работают только ручные курсы
+	redis           *redis.RedisClient
+	base            string
+	refreshInterval time.Duration
+	maxStaleness    time.Duration
+	rates           atomic.Pointer[domain.ExchangeRates] // Текущие курсы (заменяются целиком)
+	alerts          *alert.Notifier                      // Оповещения об устаревших курсах (nil - только лог)
+}
+
+// NewFXService - конструктор сервиса курсов валют
+func NewFXService(provider fx.Provider, redisClient *redis.RedisClient, cfg config.FXConfig) *FXService {
+	return &FXService{
+		provider:        provider,
+		redis:           redisClient,
+		base:            cfg.BaseCurrency,
+		refreshInterval: time.Duration(cfg.RefreshInterval) * time.Second,
+		maxStaleness:    time.Duration(cfg.MaxStaleness) * time.Second,
+	}
+}
+
+// SetNotifier - включает оповещения об устаревших курсах
+func (s *FXService) SetNotifier(notifier *alert.Notifier) {
+	s.alerts = notifier
+}
+
+// BaseCurrency - валюта, в которой хранятся цены каталога
+func (s *FXService) BaseCurrency() string {
+	return s.base
+}
+
+// Rate - курс валюты относительно базовой
+// Возвращает ErrStaleRates, если курсы провайдера устарели и ручного курса нет
+func (s *FXService) Rate(currency string) (float64, error) {
+	currency = strings.ToUpper(currency)
+	if currency == s.base {
+		return 1, nil
+	}
+
+	rates := s.rates.Load()
+	if rates == nil {
+		return 0, ErrStaleRates
+	}
+	if rate, ok := rates.Overrides[currency]; ok {
+		return rate, nil
+	}
+	if s.isStale(rates.FetchedAt) {
+		return 0, ErrStaleRates
+	}
+	if rate, ok := rates.Rates[currency]; ok {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+}
+
+// Convert - переводит сумму из базовой валюты в currency (с округлением до центов)
+func (s *FXService) Convert(amount float64, currency string) (float64, error) {
+	rate, err := s.Rate(currency)
+	if err != nil {
+		return 0, err
+	}
+	return math.Round(amount*rate*100) / 100, nil
+}
+
+// GetRates - текущие курсы для админки
+func (s *FXService) GetRates() *domain.ExchangeRates {
+	rates := s.rates.Load()
+	if rates == nil {
+		return &domain.ExchangeRates{Base: s.base, Rates: map[string]float64{}, Overrides: map[string]float64{}, Stale: true}
+	}
+
+	result := *rates
+	result.Stale = s.isStale(rates.FetchedAt)
+	return &result
+}
+
+// SetOverride - задает курс валюты вручную (действует, пока его не удалят)
+func (s *FXService) SetOverride(ctx context.Context, currency string, rate float64) error {
+	currency = strings.ToUpper(currency)
+	if !currencyPattern.MatchString(currency) || currency == s.base {
+		return fmt.Errorf("invalid currency %q", currency)
+	}
+	if rate <= 0 {
+		return fmt.Errorf("rate must be positive")
+	}
+
+	if err := s.redis.HSet(ctx, s.overridesKey(), map[string]any{currency: rate}); err != nil {
+		return err
+	}
+	return s.reloadOverrides(ctx)
+}
+
+// DeleteOverride - убирает ручной курс валюты
+func (s *FXService) DeleteOverride(ctx context.Context, currency string) error {
+	if err := s.redis.HDel(ctx, s.overridesKey(), strings.ToUpper(currency)); err != nil {
+		return err
+	}
+	return s.reloadOverrides(ctx)
+}
+
+// Start - загружает курсы и обновляет их в фоне до отмены контекста
+// Пока курсы не загружены, цены отдаются в базовой валюте
+func (s *FXService) Start(ctx context.Context) {
+	go func() {
+		s.refresh(ctx)
+
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.refresh(ctx)
+			}
+		}
+	}()
+}
+
+// refresh - обновляет курсы в памяти
+// Сначала смотрим в Redis (курсы мог уже обновить другой экземпляр), к провайдеру идем только за устаревшими
+func (s *FXService) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cached, err := s.loadCachedRates(ctx)
+	if err != nil {
+		log.Printf("reading cached FX rates error: %v", err)
+	}
+
+	if s.provider != nil && (cached == nil || time.Since(cached.FetchedAt) >= s.refreshInterval) {
+		rates, err := s.provider.Rates(ctx, s.base)
+		if err != nil {
+			// Оставляем старые курсы: пока они не устарели, конвертация продолжает работать
+			log.Printf("fetching FX rates error: %v", err)
+		} else {
+			cached = &cachedRates{Rates: rates, FetchedAt: time.Now()}
+			if err := s.saveCachedRates(ctx, cached); err != nil {
+				log.Printf("saving FX rates in cache error: %v", err)
+			}
+		}
+	}
+
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		log.Printf("reading FX overrides error: %v", err)
+		if current := s.rates.Load(); current != nil {
+			overrides = current.Overrides
+		}
+	}
+
+	rates := &domain.ExchangeRates{Base: s.base, Rates: map[string]float64{}, Overrides: overrides}
+	if cached != nil {
+		rates.Rates = cached.Rates
+		rates.FetchedAt = cached.FetchedAt
+	}
+	s.rates.Store(rates)
+
+	if s.provider != nil && s.isStale(rates.FetchedAt) {
+		message := fmt.Sprintf("fetched at %s, prices are served in %s", rates.FetchedAt.Format(time.RFC3339), s.base)
+		log.Printf("WARNING: FX rates are stale (%s)", message)
+		s.alerts.Notify(alert.Alert{Type: alert.TypeFXRatesStale, Title: "FX rates are stale", Message: message})
+	}
+}
+
+// reloadOverrides - перечитывает ручные курсы после изменения
+func (s *FXService) reloadOverrides(ctx context.Context) error {
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		return err
+	}
+
+	rates := &domain.ExchangeRates{Base: s.base, Rates: map[string]float64{}}
+	if current := s.rates.Load(); current != nil {
+		rates = current
+	}
+
+	updated := *rates
+	updated.Overrides = overrides
+	s.rates.Store(&updated)
+	return nil
+}
+
+// isStale - курсы провайдера слишком старые для конвертации
+func (s *FXService) isStale(fetchedAt time.Time) bool {
+	return time.Since(fetchedAt) > s.maxStaleness
+}
+
+// ratesKey - ключ Redis с курсами провайдера
+func (s *FXService) ratesKey() string {
+	return "fx:rates:" + s.base
+}
+
+// overridesKey - ключ Redis с ручными курсами (хэш валюта -> курс)
+func (s *FXService) overridesKey() string {
+	return "fx:overrides:" + s.base
+}
+
+// loadCachedRates - читает курсы из Redis; nil - в кэше ничего нет
+func (s *FXService) loadCachedRates(ctx context.Context) (*cachedRates, error) {
+	data, err := s.redis.GetBytes(ctx, s.ratesKey())
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+
+	var cached cachedRates
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("parsing cached FX rates error: %w", err)
+	}
+	return &cached, nil
+}
+
+// saveCachedRates - сохраняет курсы в Redis
+// Храним дольше порога устаревания, чтобы при недоступности провайдера было что показать в админке
+func (s *FXService) saveCachedRates(ctx context.Context, cached *cachedRates) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("encoding FX rates error: %w", err)
+	}
+	return s.redis.Set(ctx, s.ratesKey(), data, 2*s.maxStaleness)
+}
+
+// loadOverrides - читает ручные курсы из Redis
+func (s *FXService) loadOverrides(ctx context.Context) (map[string]float64, error) {
+	values, err := s.redis.HGetAll(ctx, s.overridesKey())
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]float64, len(values))
+	for currency, value := range values {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		overrides[currency] = rate
+	}
+	return overrides, nil
+}
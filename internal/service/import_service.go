@@ -0,0 +1,352 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/spreadsheet"
+	"go-music-shop/pkg/storage"
+	"log"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ключи загрузок в объектном хранилище: предпросмотр и итог загрузки
+const (
+	importPrefix        = "imports/"
+	importPreviewSuffix = ".preview.json"
+	importResultSuffix  = ".result.json"
+)
+
+// importIDPattern - формат id загрузки (защищает ключ в хранилище от подстановки пути)
+var importIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// ImportFields - поля альбома, которые можно загрузить из таблицы
+var ImportFields = []string{"title", "artist", "price", "year", "genre", "catalog_number", "description", "condition", "stock_quantity", "in_stock"}
+
+// importRequiredFields - без этих колонок загрузка не имеет смысла
+var importRequiredFields = []string{"title", "artist", "price"}
+
+var (
+	// ErrInvalidImport - файл или соответствие колонок не подходят для загрузки
+	ErrInvalidImport = errors.New("invalid import")
+	// ErrImportCommitted - альбомы по этому предпросмотру уже загружены
+	ErrImportCommitted = errors.New("import already committed")
+)
+
+// ImportService - загрузка альбомов из таблиц сотрудников (CSV, XLSX) в два шага:
+// предпросмотр с приведением типов и ошибками по строкам, затем загрузка того, что показано в предпросмотре
+type ImportService struct {
+	albums  *AlbumService
+	storage storage.ObjectStorage
+	maxRows int
+
+	mu sync.Mutex // Одна загрузка за раз: повторный вызов не должен создать альбомы дважды
+}
+
+// NewImportService - конструктор сервиса загрузки
+func NewImportService(albums *AlbumService, objectStorage storage.ObjectStorage, cfg config.ImportConfig) *ImportService {
+	return &ImportService{albums: albums, storage: objectStorage, maxRows: cfg.MaxRows}
+}
+
+// Preview - разбирает файл по соответствию колонок и сохраняет предпросмотр
+// Поля без соответствия подбираются по заголовкам колонок (без учета регистра, "_" и пробелы равнозначны)
+func (s *ImportService) Preview(ctx context.Context, data []byte, mapping domain.ImportMapping) (*domain.ImportPreview, error) {
+	rows, err := spreadsheet.Read(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	headerRow := slices.IndexFunc(rows, func(row []string) bool { return !spreadsheet.IsEmpty(row) })
+	if headerRow < 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidImport)
+	}
+	header := rows[headerRow]
+
+	mapping, columns, err := resolveImportMapping(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.ImportPreview{
+		ID:        newJobID(),
+		Columns:   header,
+		Mapping:   mapping,
+		Rows:      []domain.ImportRow{},
+		CreatedAt: time.Now().UTC(),
+	}
+	for i := headerRow + 1; i < len(rows); i++ {
+		if spreadsheet.IsEmpty(rows[i]) {
+			continue
+		}
+		if len(preview.Rows) == s.maxRows {
+			return nil, fmt.Errorf("%w: file has more than %d rows", ErrInvalidImport, s.maxRows)
+		}
+
+		row := parseImportRow(rows[i], columns)
+		row.Row = i + 1
+		if len(row.Errors) == 0 {
+			preview.Valid++
+		} else {
+			preview.Invalid++
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+
+	if err := s.putJSON(ctx, importKey(preview.ID, importPreviewSuffix), preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// Commit - создает альбомы из строк предпросмотра без ошибок
+// Строки, которые не прошли проверки сервиса (например, подозрительная цена), попадают в Failed
+func (s *ImportService) Commit(ctx context.Context, id string) (*domain.ImportResult, error) {
+	if !importIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("%w: invalid import id %q", ErrInvalidImport, id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if object, err := s.storage.Get(ctx, importKey(id, importResultSuffix)); err == nil {
+		object.Close()
+		return nil, ErrImportCommitted
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	var preview domain.ImportPreview
+	if err := s.getJSON(ctx, importKey(id, importPreviewSuffix), &preview); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{ID: id, Created: []string{}, Failed: []domain.ImportRow{}}
+	for _, row := range preview.Rows {
+		if len(row.Errors) > 0 {
+			result.Skipped++
+			continue
+		}
+
+		album := row.Album
+		if err := s.albums.CreateAlbum(&album); err != nil {
+			row.Errors = []string{err.Error()}
+			result.Failed = append(result.Failed, row)
+			continue
+		}
+		result.Created = append(result.Created, album.ID)
+	}
+	result.CommittedAt = time.Now().UTC()
+
+	// Итог сохраняется и при частичной загрузке: повторный вызов создал бы уже созданные альбомы
+	if err := s.putJSON(ctx, importKey(id, importResultSuffix), result); err != nil {
+		log.Printf("saving import %s result error: %v", id, err)
+	}
+
+	log.Printf("import %s: %d albums created, %d failed, %d skipped", id, len(result.Created), len(result.Failed), result.Skipped)
+	return result, nil
+}
+
+// resolveImportMapping - проверяет соответствие полей колонкам и дополняет его по заголовкам
+// Возвращает итоговое соответствие и номера колонок для каждого поля
+func resolveImportMapping(header []string, mapping domain.ImportMapping) (domain.ImportMapping, map[string]int, error) {
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := byName[normalizeColumn(name)]; !ok && name != "" {
+			byName[normalizeColumn(name)] = i
+		}
+	}
+
+	resolved := make(domain.ImportMapping, len(ImportFields))
+	columns := make(map[string]int, len(ImportFields))
+	for field, column := range mapping {
+		if !slices.Contains(ImportFields, field) {
+			return nil, nil, fmt.Errorf("%w: unknown field %q, expected one of %s", ErrInvalidImport, field, strings.Join(ImportFields, ", "))
+		}
+		if column == "" {
+			continue // Поле явно не загружается
+		}
+		i, ok := byName[normalizeColumn(column)]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: column %q for field %s not found in file", ErrInvalidImport, column, field)
+		}
+		resolved[field], columns[field] = header[i], i
+	}
+
+	for _, field := range ImportFields {
+		if _, ok := mapping[field]; ok {
+			continue
+		}
+		if i, ok := byName[normalizeColumn(field)]; ok {
+			resolved[field], columns[field] = header[i], i
+		}
+	}
+
+	for _, field := range importRequiredFields {
+		if _, ok := columns[field]; !ok {
+			return nil, nil, fmt.Errorf("%w: no column for required field %s", ErrInvalidImport, field)
+		}
+	}
+	return resolved, columns, nil
+}
+
+// normalizeColumn - заголовок колонки для сравнения: "Stock Quantity" и "stock_quantity" совпадают
+func normalizeColumn(name string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(strings.ToLower(name), "_", " ")), " ")
+}
+
+// parseImportRow - приводит ячейки строки к полям альбома и собирает ошибки
+func parseImportRow(cells []string, columns map[string]int) domain.ImportRow {
+	var row domain.ImportRow
+	cell := func(field string) (string, bool) {
+		i, ok := columns[field]
+		if !ok || cells[i] == "" {
+			return "", false
+		}
+		return cells[i], true
+	}
+	fail := func(format string, args ...any) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	album := &row.Album
+	album.Title, _ = cell("title")
+	album.Artist, _ = cell("artist")
+	album.Genre, _ = cell("genre")
+	album.CatalogNumber, _ = cell("catalog_number")
+	album.Description, _ = cell("description")
+	if album.Title == "" {
+		fail("title is required")
+	}
+	if album.Artist == "" {
+		fail("artist is required")
+	}
+
+	if value, ok := cell("price"); !ok {
+		fail("price is required")
+	} else if price, err := parseImportPrice(value); err != nil {
+		fail("invalid price %q", value)
+	} else {
+		album.Price = price
+	}
+
+	if value, ok := cell("year"); ok {
+		year, err := parseImportInt(value)
+		if err != nil || year < 1000 || year > 9999 {
+			fail("invalid year %q", value)
+		}
+		album.Year = year
+	}
+
+	if value, ok := cell("condition"); ok {
+		album.Condition = strings.ToLower(value)
+		if !slices.Contains(albumConditions, album.Condition) {
+			fail("invalid condition %q, expected one of %s", value, strings.Join(albumConditions, ", "))
+		}
+	}
+
+	if value, ok := cell("stock_quantity"); ok {
+		quantity, err := parseImportInt(value)
+		if err != nil || quantity < 0 {
+			fail("invalid stock quantity %q", value)
+		}
+		album.StockQuantity = max(quantity, 0)
+	} else if value, ok := cell("in_stock"); ok {
+		inStock, err := parseImportBool(value)
+		if err != nil {
+			fail("invalid in stock %q, expected yes or no", value)
+		}
+		album.StockQuantity = domain.StockFromInStock(inStock, 0)
+	}
+
+	return row
+}
+
+// parseImportPrice - цена в записи из таблиц: "56.99", "56,99", "$1,234.50", "1 234,50 ₽"
+// Из двух разделителей десятичный - последний; одна запятая без точки тоже десятичная
+func parseImportPrice(value string) (float64, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' || r == '.' || r == ',' || r == '-' {
+			return r
+		}
+		return -1 // Символы валют, пробелы (в том числе неразрывные)
+	}, value)
+
+	lastDot, lastComma := strings.LastIndex(cleaned, "."), strings.LastIndex(cleaned, ",")
+	switch {
+	case lastComma > lastDot && strings.Count(cleaned, ",") == 1:
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	default:
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	}
+
+	price, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || price < 0 || math.IsInf(price, 0) || math.IsNaN(price) {
+		return 0, fmt.Errorf("invalid price %q", value)
+	}
+	return math.Round(price*100) / 100, nil
+}
+
+// parseImportInt - целое число; XLSX хранит числа как 1957 или 1957.0
+func parseImportInt(value string) (int, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid integer %q", value)
+	}
+	return int(f), nil
+}
+
+// parseImportBool - да/нет в том виде, в каком его пишут в таблицах
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "true", "1", "+", "да", "д":
+		return true, nil
+	case "no", "n", "false", "0", "-", "нет", "н":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", value)
+}
+
+// importKey - ключ объекта загрузки в хранилище
+func importKey(id, suffix string) string {
+	return importPrefix + id + suffix
+}
+
+// putJSON - сохраняет объект загрузки в хранилище
+func (s *ImportService) putJSON(ctx context.Context, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s error: %w", key, err)
+	}
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), "application/json"); err != nil {
+		return fmt.Errorf("saving %s error: %w", key, err)
+	}
+	return nil
+}
+
+// getJSON - читает объект загрузки из хранилища
+func (s *ImportService) getJSON(ctx context.Context, key string, v any) error {
+	object, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	if err := json.NewDecoder(object).Decode(v); err != nil {
+		return fmt.Errorf("reading %s error: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/discogs"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Результат приемки одного скана
+const (
+	ReceiveReceived = "received" // Экземпляр добавлен на склад
+	ReceiveQueued   = "queued"   // Штрихкод неизвестен - поставлен в очередь на поиск в Discogs
+	ReceiveError    = "error"
+)
+
+// maxBarcodeLength - как у внешних id альбомов (album_external_ids.external_id)
+const maxBarcodeLength = 100
+
+// BarcodeLookup - поиск сведений об издании по штрихкоду (Discogs)
+type BarcodeLookup interface {
+	// LookupBarcode - издание по штрихкоду; nil без ошибки, если издание не найдено
+	LookupBarcode(ctx context.Context, barcode string) (*discogs.Release, error)
+}
+
+// ReceiveScan - один скан при приемке: штрихкод, состояние экземпляра и, если нужно, новая цена
+type ReceiveScan struct {
+	Barcode   string   `json:"barcode"`
+	Condition string   `json:"condition"`
+	Price     *float64 `json:"price"`
+}
+
+// ReceiveResult - подтверждение скана для сканера
+type ReceiveResult struct {
+	Barcode       string   `json:"barcode"`
+	Status        string   `json:"status"`
+	AlbumID       string   `json:"album_id,omitempty"`
+	StockQuantity int      `json:"stock_quantity,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"` // Скан принят, но что-то стоит проверить
+	Error         string   `json:"error,omitempty"`
+}
+
+// InventoryService - приемка пластинок на склад по сканам штрихкодов
+// Каждый скан - один поиск альбома по штрихкоду и одно изменение количества, чтобы сканер
+// получал подтверждение сразу; все медленное (поиск в Discogs) идет в фоне
+type InventoryService struct {
+	albums      *AlbumService
+	externalIDs *ExternalIDService
+	unknown     domain.UnknownBarcodeRepository
+	lookup      BarcodeLookup // nil - неизвестные штрихкоды только копятся в очереди
+}
+
+// NewInventoryService - конструктор сервиса приемки
+func NewInventoryService(albums *AlbumService, externalIDs *ExternalIDService, unknown domain.UnknownBarcodeRepository) *InventoryService {
+	return &InventoryService{albums: albums, externalIDs: externalIDs, unknown: unknown}
+}
+
+// SetBarcodeLookup - включает поиск неизвестных штрихкодов (StartEnrichment)
+func (s *InventoryService) SetBarcodeLookup(lookup BarcodeLookup) {
+	s.lookup = lookup
+}
+
+// Receive - принимает один скан
+func (s *InventoryService) Receive(scan ReceiveScan, actor string) ReceiveResult {
+	return s.ReceiveBatch([]ReceiveScan{scan}, actor)[0]
+}
+
+// ReceiveBatch - принимает пачку сканов; альбомы по штрихкодам ищутся одним запросом
+// Один и тот же штрихкод может встречаться несколько раз - каждый скан это отдельный экземпляр
+func (s *InventoryService) ReceiveBatch(scans []ReceiveScan, actor string) []ReceiveResult {
+	barcodes := make([]string, 0, len(scans))
+	for i := range scans {
+		scans[i].Barcode = strings.TrimSpace(scans[i].Barcode)
+		scans[i].Condition = strings.ToLower(strings.TrimSpace(scans[i].Condition))
+		if scans[i].Barcode != "" && !slices.Contains(barcodes, scans[i].Barcode) {
+			barcodes = append(barcodes, scans[i].Barcode)
+		}
+	}
+
+	results := make([]ReceiveResult, len(scans))
+	albumIDs, err := s.externalIDs.FindAlbumIDs(domain.ExternalSystemBarcode, barcodes)
+	if err != nil {
+		log.Printf("finding albums by barcodes error: %v", err)
+		for i, scan := range scans {
+			results[i] = ReceiveResult{Barcode: scan.Barcode, Status: ReceiveError, Error: "failed to find albums by barcode"}
+		}
+		return results
+	}
+
+	for i, scan := range scans {
+		results[i] = s.receive(scan, albumIDs[scan.Barcode], actor)
+	}
+	return results
+}
+
+// receive - добавляет экземпляр известного альбома или ставит штрихкод в очередь
+func (s *InventoryService) receive(scan ReceiveScan, albumID, actor string) ReceiveResult {
+	result := ReceiveResult{Barcode: scan.Barcode, Status: ReceiveError}
+	switch {
+	case scan.Barcode == "":
+		result.Error = "barcode cannot be empty"
+		return result
+	case len(scan.Barcode) > maxBarcodeLength:
+		result.Error = fmt.Sprintf("barcode is longer than %d characters", maxBarcodeLength)
+		return result
+	case scan.Condition != "" && !slices.Contains(albumConditions, scan.Condition):
+		result.Error = fmt.Sprintf("invalid condition %q, expected one of %s", scan.Condition, strings.Join(albumConditions, ", "))
+		return result
+	case scan.Price != nil && *scan.Price < 0:
+		result.Error = "price cannot be negative"
+		return result
+	}
+
+	if albumID == "" {
+		if err := s.unknown.Record(scan.Barcode, scan.Condition, scan.Price, time.Now()); err != nil {
+			log.Printf("queueing unknown barcode %s error: %v", scan.Barcode, err)
+			result.Error = "failed to queue unknown barcode"
+			return result
+		}
+		result.Status = ReceiveQueued
+		return result
+	}
+
+	album, err := s.albums.AdjustStock(albumID, 1, "received: barcode scan", actor)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = ReceiveReceived
+	result.AlbumID = album.ID
+	result.StockQuantity = album.StockQuantity
+
+	if scan.Condition != "" && album.Condition != "" && scan.Condition != album.Condition {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("condition %s differs from album condition %s", scan.Condition, album.Condition))
+	}
+
+	if scan.Price != nil && *scan.Price != album.Price {
+		updated := *album
+		updated.Price = *scan.Price
+		if err := s.albums.UpdateAlbum(&updated); err != nil {
+			result.Warnings = append(result.Warnings, "price not changed: "+err.Error())
+		}
+	}
+	return result
+}
+
+// UnknownBarcodes - очередь неизвестных штрихкодов в статусе status (пусто - все)
+func (s *InventoryService) UnknownBarcodes(status string, limit int) ([]domain.UnknownBarcode, error) {
+	statuses := []string{domain.UnknownBarcodePending, domain.UnknownBarcodeFound, domain.UnknownBarcodeNotFound}
+	if status != "" && !slices.Contains(statuses, status) {
+		return nil, fmt.Errorf("invalid status %q, expected one of %s", status, strings.Join(statuses, ", "))
+	}
+	return s.unknown.List(status, limit)
+}
+
+// StartEnrichment - запускает фоновый поиск неизвестных штрихкодов в Discogs: раз в interval
+// берет до batch штрихкодов из очереди; без SetBarcodeLookup ничего не делает
+func (s *InventoryService) StartEnrichment(ctx context.Context, interval time.Duration, batch int) {
+	if s.lookup == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.enrich(ctx, batch)
+			}
+		}
+	}()
+}
+
+// enrich - ищет в Discogs штрихкоды из очереди; при исчерпании лимита Discogs откладывает остальные
+func (s *InventoryService) enrich(ctx context.Context, batch int) {
+	pending, err := s.unknown.List(domain.UnknownBarcodePending, batch)
+	if err != nil {
+		log.Printf("reading unknown barcodes error: %v", err)
+		return
+	}
+
+	for i, barcode := range pending {
+		release, err := s.lookup.LookupBarcode(ctx, barcode.Barcode)
+		if errors.Is(err, discogs.ErrRateLimited) {
+			log.Printf("discogs rate limit reached, %d barcodes left for the next run", len(pending)-i)
+			return
+		}
+		if err != nil {
+			log.Printf("looking up barcode %s error: %v", barcode.Barcode, err)
+			continue // Останется в очереди до следующего прохода
+		}
+
+		now := time.Now()
+		barcode.LookedUpAt = &now
+		barcode.Status = domain.UnknownBarcodeNotFound
+		if release != nil {
+			barcode.Status = domain.UnknownBarcodeFound
+			barcode.DiscogsReleaseID = release.ID
+			barcode.Title, barcode.Artist = release.Title, release.Artist
+			barcode.Year, barcode.Genre = release.Year, release.Genre
+		}
+		if err := s.unknown.SaveLookup(barcode); err != nil {
+			log.Printf("saving barcode %s lookup error: %v", barcode.Barcode, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/storage"
+	"io"
+	"iter"
+)
+
+// Виды фоновых задач
+const (
+	JobKindCatalogExport = "catalog_export"
+	JobKindSuggestIndex  = "suggest_reindex"
+)
+
+// jobProgressEvery - как часто обновлять прогресс задачи (в альбомах)
+const jobProgressEvery = 100
+
+// CatalogExportJob - выгрузка всего каталога в NDJSON (gzip) в файловое хранилище
+func CatalogExportJob(albums *AlbumService, objectStorage storage.ObjectStorage) JobFunc {
+	return func(ctx context.Context, jobID string, progress JobProgress) (string, error) {
+		total := countAlbums(albums)
+		key := "exports/catalog-" + jobID + ".ndjson.gz"
+
+		pr, pw := io.Pipe()
+		go func() {
+			gz := gzip.NewWriter(pw)
+			encoder := json.NewEncoder(gz)
+			for album, err := range withJobProgress(ctx, albums.StreamAllAlbums(), total, progress) {
+				if err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+				if err := encoder.Encode(album); err != nil {
+					pw.CloseWithError(fmt.Errorf("encoding export error: %w", err))
+					return
+				}
+			}
+			pw.CloseWithError(gz.Close())
+		}()
+
+		if err := objectStorage.Put(ctx, key, pr, "application/gzip"); err != nil {
+			pr.CloseWithError(err) // Останавливаем запись, если хранилище отказало
+			return "", err
+		}
+		return key, nil
+	}
+}
+
+// SuggestReindexJob - полная перестройка индекса подсказок поиска
+func SuggestReindexJob(albums *AlbumService, suggest *SuggestService) JobFunc {
+	return func(ctx context.Context, jobID string, progress JobProgress) (string, error) {
+		total := countAlbums(albums)
+		return "", suggest.Rebuild(ctx, withJobProgress(ctx, albums.StreamAllAlbums(), total, progress))
+	}
+}
+
+// countAlbums - сколько альбомов в каталоге, для расчета прогресса (0 - неизвестно)
+func countAlbums(albums *AlbumService) int {
+	page, err := albums.GetAlbumsPage(domain.AlbumFilter{}, "", 1, 0)
+	if err != nil {
+		return 0
+	}
+	return page.Total
+}
+
+// withJobProgress - отдает альбомы, сообщая прогресс задачи и прерываясь при ее отмене
+func withJobProgress(ctx context.Context, albums iter.Seq2[domain.Album, error], total int, progress JobProgress) iter.Seq2[domain.Album, error] {
+	return func(yield func(domain.Album, error) bool) {
+		done := 0
+		for album, err := range albums {
+			if ctx.Err() != nil {
+				yield(domain.Album{}, ctx.Err())
+				return
+			}
+			if !yield(album, err) || err != nil {
+				return
+			}
+
+			done++
+			if done%jobProgressEvery == 0 {
+				progress(done, max(total, done))
+			}
+		}
+	}
+}
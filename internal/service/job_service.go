@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/storage"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jobRetention - сколько хранить завершенные задачи (и их результаты в списке задач)
+const jobRetention = 24 * time.Hour
+
+var (
+	ErrJobNotFound    = errors.New("job not found")
+	ErrJobNotFinished = errors.New("job is not finished")
+	ErrJobNoResult    = errors.New("job has no result")
+)
+
+// JobProgress - сообщает прогресс задачи: сделано done из total
+type JobProgress func(done, total int)
+
+// JobFunc - тело фоновой задачи; должна прерываться при отмене ctx
+// Возвращает ключ результата в файловом хранилище ("" - задача без файла результата)
+type JobFunc func(ctx context.Context, jobID string, progress JobProgress) (resultKey string, err error)
+
+// runningJob - задача вместе с функцией ее отмены
+type runningJob struct {
+	job    domain.Job
+	cancel context.CancelFunc
+}
+
+// JobService - фоновые задачи для тяжелых операций: запрос сразу получает id задачи,
+// а клиент опрашивает ее состояние и скачивает результат, когда задача завершится
+// Задачи выполняются и хранятся в памяти экземпляра, который их принял
+type JobService struct {
+	storage storage.ObjectStorage
+
+	mu   sync.Mutex
+	jobs map[string]*runningJob
+}
+
+// NewJobService - конструктор сервиса фоновых задач
+func NewJobService(objectStorage storage.ObjectStorage) *JobService {
+	return &JobService{storage: objectStorage, jobs: make(map[string]*runningJob)}
+}
+
+// Submit - ставит задачу в работу и сразу возвращает ее
+func (s *JobService) Submit(kind string, fn JobFunc) domain.Job {
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &runningJob{
+		job: domain.Job{
+			ID:        newJobID(),
+			Kind:      kind,
+			State:     domain.JobQueued,
+			CreatedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	s.mu.Lock()
+	s.sweep()
+	s.jobs[entry.job.ID] = entry
+	job := entry.job
+	s.mu.Unlock()
+
+	go s.run(ctx, entry, fn)
+	return job
+}
+
+// run - выполняет задачу и записывает ее итог
+func (s *JobService) run(ctx context.Context, entry *runningJob, fn JobFunc) {
+	defer entry.cancel()
+
+	s.update(entry, func(job *domain.Job) {
+		job.State = domain.JobRunning
+		job.StartedAt = time.Now().UTC()
+	})
+
+	progress := func(done, total int) {
+		if total <= 0 {
+			return
+		}
+		s.update(entry, func(job *domain.Job) {
+			job.Progress = min(float64(done)*100/float64(total), 99.9) // 100% - только когда задача завершена
+		})
+	}
+
+	resultKey, err := fn(ctx, entry.job.ID, progress)
+
+	s.update(entry, func(job *domain.Job) {
+		job.FinishedAt = time.Now().UTC()
+		switch {
+		case ctx.Err() != nil:
+			job.State = domain.JobCanceled
+		case err != nil:
+			job.State = domain.JobFailed
+			job.Error = err.Error()
+		default:
+			job.State = domain.JobSucceeded
+			job.Progress = 100
+			job.ResultKey = resultKey
+		}
+	})
+
+	if err != nil && ctx.Err() == nil {
+		log.Printf("%s job %s failed: %v", entry.job.Kind, entry.job.ID, err)
+	}
+}
+
+// update - меняет состояние задачи под блокировкой
+func (s *JobService) update(entry *runningJob, change func(job *domain.Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !entry.job.State.Finished() {
+		change(&entry.job)
+	}
+}
+
+// Get - текущее состояние задачи
+func (s *JobService) Get(id string) (domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	return entry.job, nil
+}
+
+// List - все задачи, новые первыми
+func (s *JobService) List() []domain.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]domain.Job, 0, len(s.jobs))
+	for _, entry := range s.jobs {
+		jobs = append(jobs, entry.job)
+	}
+	slices.SortFunc(jobs, func(a, b domain.Job) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return jobs
+}
+
+// Cancel - отменяет задачу; завершенные задачи не меняются
+func (s *JobService) Cancel(id string) (domain.Job, error) {
+	s.mu.Lock()
+	entry, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	entry.cancel()
+	s.update(entry, func(job *domain.Job) {
+		job.State = domain.JobCanceled
+		job.FinishedAt = time.Now().UTC()
+	})
+	return s.Get(id)
+}
+
+// Result - открывает файл результата завершенной задачи (вызывающий должен закрыть его)
+func (s *JobService) Result(ctx context.Context, id string) (io.ReadCloser, string, error) {
+	job, err := s.Get(id)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case !job.State.Finished():
+		return nil, "", fmt.Errorf("%w: %s is %s", ErrJobNotFinished, id, job.State)
+	case job.ResultKey == "":
+		return nil, "", fmt.Errorf("%w: %s", ErrJobNoResult, id)
+	}
+
+	r, err := s.storage.Get(ctx, job.ResultKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return r, job.ResultKey[strings.LastIndex(job.ResultKey, "/")+1:], nil
+}
+
+// sweep - забывает давно завершенные задачи; вызывается под блокировкой
+func (s *JobService) sweep() {
+	for id, entry := range s.jobs {
+		if entry.job.State.Finished() && time.Since(entry.job.FinishedAt) > jobRetention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// newJobID - случайный идентификатор задачи
+func newJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b) // Никогда не возвращает ошибку
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// countryCodePattern - код страны ISO 3166-1 alpha-2
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// LabelService - сервис лейблов звукозаписи
+type LabelService struct {
+	repo  domain.LabelRepository
+	cache albumCache // nil - альбомы не кэшируются
+}
+
+// NewLabelService - конструктор сервиса лейблов
+func NewLabelService(repo domain.LabelRepository) *LabelService {
+	return &LabelService{repo: repo}
+}
+
+// SetCache - включает сброс кэша альбомов после смены их лейбла
+func (s *LabelService) SetCache(cache albumCache) {
+	s.cache = cache
+}
+
+// GetLabels - все лейблы
+func (s *LabelService) GetLabels() ([]domain.Label, error) {
+	labels, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = []domain.Label{}
+	}
+	return labels, nil
+}
+
+// GetLabel - лейбл по ID
+func (s *LabelService) GetLabel(id string) (*domain.Label, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetByID(id)
+}
+
+// GetLabelAlbums - альбомы лейбла
+func (s *LabelService) GetLabelAlbums(id string) ([]domain.Album, error) {
+	if _, err := s.GetLabel(id); err != nil {
+		return nil, err
+	}
+
+	albums, err := s.repo.GetAlbums(id)
+	if err != nil {
+		return nil, err
+	}
+	if albums == nil {
+		albums = []domain.Album{}
+	}
+	return albums, nil
+}
+
+// CreateLabel - создает лейбл с валидацией
+func (s *LabelService) CreateLabel(label *domain.Label) error {
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	return s.repo.Create(label)
+}
+
+// UpdateLabel - изменяет лейбл с валидацией
+func (s *LabelService) UpdateLabel(label *domain.Label) error {
+	if label.ID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	return s.repo.Update(label)
+}
+
+// DeleteLabel - удаляет лейбл
+func (s *LabelService) DeleteLabel(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	// Альбомы лейбла остаются без лейбла (ON DELETE SET NULL) - их кэш тоже нужно сбросить
+	var albums []domain.Album
+	if s.cache != nil {
+		var err error
+		if albums, err = s.repo.GetAlbums(id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	for _, album := range albums {
+		s.cache.InvalidateAlbum(album.ID)
+	}
+	return nil
+}
+
+// SetAlbumLabel - назначает альбому лейбл ("" - снять лейбл)
+func (s *LabelService) SetAlbumLabel(albumID, labelID string) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if err := s.repo.SetAlbumLabel(albumID, strings.TrimSpace(labelID)); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.InvalidateAlbum(albumID)
+	}
+	return nil
+}
+
+// validateLabel - проверяет и нормализует поля лейбла
+func validateLabel(label *domain.Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	label.Country = strings.ToUpper(strings.TrimSpace(label.Country))
+
+	if label.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if label.Country != "" && !countryCodePattern.MatchString(label.Country) {
+		return fmt.Errorf("invalid country code %q, expected ISO 3166-1 alpha-2", label.Country)
+	}
+	if label.FoundedYear != 0 && (label.FoundedYear < 1800 || label.FoundedYear > time.Now().Year()) {
+		return fmt.Errorf("invalid founded year %d", label.FoundedYear)
+	}
+	return nil
+}
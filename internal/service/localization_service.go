@@ -0,0 +1,223 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxLocalizedTitleLength = 255  // Как у колонки albums.title
+	maxLocalizedNotesLength = 5000 // Примечания к изданию, а не полноценная статья
+)
+
+// languageTagPattern - тег языка BCP 47 в нижнем регистре: "ja", "ja-latn", "pt-br"
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// LocalizationService - названия и примечания альбомов на разных языках
+// Перевод выбирается по Accept-Language: сначала точное совпадение тега, затем основной язык ("ja-jp" -> "ja");
+// если раньше перевода в списке встречается язык основных названий, альбом отдается как есть
+type LocalizationService struct {
+	repo            domain.LocalizationRepository
+	defaultLanguage string
+	cache           albumCache // nil - альбомы не кэшируются
+}
+
+// NewLocalizationService - конструктор сервиса переводов
+func NewLocalizationService(repo domain.LocalizationRepository, defaultLanguage string) *LocalizationService {
+	return &LocalizationService{repo: repo, defaultLanguage: normalizeLanguage(defaultLanguage)}
+}
+
+// SetCache - включает сброс кэша альбомов после изменения переводов (в кэше время изменения альбома)
+func (s *LocalizationService) SetCache(cache albumCache) {
+	s.cache = cache
+}
+
+// GetLocalizations - переводы альбома
+func (s *LocalizationService) GetLocalizations(albumID string) (domain.AlbumLocalizations, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	localizations, err := s.repo.GetLocalizations([]string{albumID})
+	if err != nil {
+		return nil, err
+	}
+	localized, ok := localizations[albumID]
+	if !ok {
+		return nil, fmt.Errorf("album with ID %s not found", albumID)
+	}
+	return localized, nil
+}
+
+// SetLocalization - добавляет или заменяет перевод альбома на языке language
+func (s *LocalizationService) SetLocalization(albumID, language string, localization domain.AlbumLocalization) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	language = normalizeLanguage(language)
+	if !languageTagPattern.MatchString(language) {
+		return fmt.Errorf("invalid language %q, expected a language tag like ja or ja-latn", language)
+	}
+
+	localization.Title = strings.TrimSpace(localization.Title)
+	localization.Notes = strings.TrimSpace(localization.Notes)
+	switch {
+	case localization.Title == "" && localization.Notes == "":
+		return fmt.Errorf("title or notes are required")
+	case utf8.RuneCountInString(localization.Title) > maxLocalizedTitleLength:
+		return fmt.Errorf("title is longer than %d characters", maxLocalizedTitleLength)
+	case utf8.RuneCountInString(localization.Notes) > maxLocalizedNotesLength:
+		return fmt.Errorf("notes are longer than %d characters", maxLocalizedNotesLength)
+	}
+
+	if err := s.repo.SetLocalization(albumID, language, localization); err != nil {
+		return err
+	}
+	s.invalidate(albumID)
+	return nil
+}
+
+// DeleteLocalization - удаляет перевод альбома на языке language
+func (s *LocalizationService) DeleteLocalization(albumID, language string) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if err := s.repo.DeleteLocalization(albumID, normalizeLanguage(language)); err != nil {
+		return err
+	}
+	s.invalidate(albumID)
+	return nil
+}
+
+// invalidate - сбрасывает кэш альбома
+func (s *LocalizationService) invalidate(albumID string) {
+	if s.cache != nil {
+		s.cache.InvalidateAlbum(albumID)
+	}
+}
+
+// PreferredLanguages - языки из Accept-Language по убыванию веса
+// nil - переводы не нужны: первым идет язык основных названий (или заголовка нет)
+func (s *LocalizationService) PreferredLanguages(acceptLanguage string) []string {
+	type weighted struct {
+		language string
+		q        float64
+	}
+
+	var preferred []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		language, params, _ := strings.Cut(part, ";")
+		language = normalizeLanguage(language)
+		if language == "" || language == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			preferred = append(preferred, weighted{language, q})
+		}
+	}
+	slices.SortStableFunc(preferred, func(a, b weighted) int { return cmp.Compare(b.q, a.q) })
+
+	if len(preferred) == 0 || baseLanguage(preferred[0].language) == s.defaultLanguage {
+		return nil
+	}
+	languages := make([]string, len(preferred))
+	for i, p := range preferred {
+		languages[i] = p.language
+	}
+	return languages
+}
+
+// LocalizeAlbums - копия списка с названиями на языке покупателя
+func (s *LocalizationService) LocalizeAlbums(albums []domain.Album, languages []string) ([]domain.Album, error) {
+	if len(languages) == 0 || len(albums) == 0 {
+		return albums, nil
+	}
+
+	ids := make([]string, len(albums))
+	for i, album := range albums {
+		ids[i] = album.ID
+	}
+	localizations, err := s.repo.GetLocalizations(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	localized := make([]domain.Album, len(albums))
+	for i, album := range albums {
+		if _, localization, ok := s.match(localizations[album.ID], languages); ok && localization.Title != "" {
+			album.Title = localization.Title
+		}
+		localized[i] = album
+	}
+	return localized, nil
+}
+
+// LocalizeAlbum - альбом на языке покупателя; Language пустой - подходящего перевода нет
+func (s *LocalizationService) LocalizeAlbum(album domain.Album, languages []string) (domain.LocalizedAlbum, error) {
+	result := domain.LocalizedAlbum{Album: album, OriginalTitle: album.Title}
+	if len(languages) == 0 {
+		return result, nil
+	}
+
+	localizations, err := s.repo.GetLocalizations([]string{album.ID})
+	if err != nil {
+		return result, err
+	}
+
+	language, localization, ok := s.match(localizations[album.ID], languages)
+	if !ok {
+		return result, nil
+	}
+	result.Language, result.Notes = language, localization.Notes
+	if localization.Title != "" {
+		result.Title = localization.Title
+	}
+	return result, nil
+}
+
+// match - перевод для первого подходящего языка из списка
+func (s *LocalizationService) match(localized domain.AlbumLocalizations, languages []string) (string, domain.AlbumLocalization, bool) {
+	if len(localized) == 0 {
+		return "", domain.AlbumLocalization{}, false
+	}
+
+	for _, language := range languages {
+		if localization, ok := localized[language]; ok {
+			return language, localization, true
+		}
+		base := baseLanguage(language)
+		if localization, ok := localized[base]; ok {
+			return base, localization, true
+		}
+		if base == s.defaultLanguage {
+			break // Покупатель предпочитает основные названия переводам на следующие языки
+		}
+	}
+	return "", domain.AlbumLocalization{}, false
+}
+
+// normalizeLanguage - тег языка в нижнем регистре без пробелов, "ja_JP" -> "ja-jp"
+func normalizeLanguage(language string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(language)), "_", "-")
+}
+
+// baseLanguage - основной язык тега: "ja-latn" -> "ja"
+func baseLanguage(language string) string {
+	base, _, _ := strings.Cut(language, "-")
+	return base
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/alert"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения заметок: автор хранится в VARCHAR(100), текст - чтобы заметка оставалась заметкой
+const (
+	maxNoteAuthorLength = 100
+	maxNoteBodyLength   = 10000
+)
+
+// mentionPattern - упоминание сотрудника в тексте заметки: @имя (буквы, цифры, точка, дефис, подчеркивание)
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\p{L}\d][\p{L}\d._-]*)`)
+
+// NoteService - сервис внутренних заметок сотрудников к альбомам
+type NoteService struct {
+	repo   domain.AlbumNoteRepository
+	alerts *alert.Notifier // Оповещения об упоминаниях (nil - не отправляем)
+}
+
+// NewNoteService - конструктор сервиса заметок
+func NewNoteService(repo domain.AlbumNoteRepository) *NoteService {
+	return &NoteService{repo: repo}
+}
+
+// SetNotifier - включает оповещения об упоминаниях сотрудников в чат команды
+func (s *NoteService) SetNotifier(notifier *alert.Notifier) {
+	s.alerts = notifier
+}
+
+// GetNotes - заметки альбома от старых к новым
+func (s *NoteService) GetNotes(albumID string) ([]domain.AlbumNote, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetNotes(albumID)
+}
+
+// AddNote - добавляет заметку к альбому и оповещает упомянутых сотрудников
+func (s *NoteService) AddNote(note *domain.AlbumNote) error {
+	note.Author = strings.TrimSpace(note.Author)
+	note.Body = strings.TrimSpace(note.Body)
+
+	switch {
+	case note.AlbumID == "":
+		return fmt.Errorf("id cannot be empty")
+	case note.Author == "":
+		return fmt.Errorf("author cannot be empty")
+	case utf8.RuneCountInString(note.Author) > maxNoteAuthorLength:
+		return fmt.Errorf("author is longer than %d characters", maxNoteAuthorLength)
+	case note.Body == "":
+		return fmt.Errorf("note cannot be empty")
+	case utf8.RuneCountInString(note.Body) > maxNoteBodyLength:
+		return fmt.Errorf("note is longer than %d characters", maxNoteBodyLength)
+	}
+
+	note.Mentions = parseMentions(note.Body)
+	if err := s.repo.Create(note); err != nil {
+		return err
+	}
+
+	for _, mention := range note.Mentions {
+		s.alerts.Notify(alert.Alert{
+			Type:    alert.TypeNoteMention,
+			Key:     fmt.Sprintf("%d:%s", note.ID, mention), // Каждое упоминание - отдельное оповещение
+			Title:   fmt.Sprintf("@%s mentioned in a note on album %s", mention, note.AlbumID),
+			Message: fmt.Sprintf("%s wrote: %s", note.Author, note.Body),
+		})
+	}
+	return nil
+}
+
+// DeleteNote - удаляет заметку альбома
+func (s *NoteService) DeleteNote(albumID string, noteID int64) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.Delete(albumID, noteID)
+}
+
+// parseMentions - уникальные упоминания @имя в тексте в нижнем регистре, в порядке появления
+func parseMentions(body string) []string {
+	mentions := []string{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.ToLower(strings.TrimRight(match[1], "._-")) // "@anna." в конце предложения
+		if name != "" && !slices.Contains(mentions, name) {
+			mentions = append(mentions, name)
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"log"
+	"math"
+)
+
+// ErrPriceConfirmationRequired - изменение цены выглядит как ошибка и должно быть подтверждено
+var ErrPriceConfirmationRequired = errors.New("price change requires confirmation")
+
+// WriteOption - дополнительный параметр создания/обновления альбома
+type WriteOption func(*writeOptions)
+
+// writeOptions - параметры записи альбома
+type writeOptions struct {
+	confirmPriceChange bool
+	legacyStock        bool  // Клиент не передал stock_quantity - количество считается по inStock
+	inStock            *bool // nil - количество не меняется
+	keepCatalogNumber  bool  // Клиент не передал catalog_number - остается текущий
+	keepDescription    bool  // Клиент не передал description - остается текущее
+}
+
+// ConfirmPriceChange - подтверждает подозрительное изменение цены (проверки PriceGuard не блокируют запись)
+func ConfirmPriceChange(confirm bool) WriteOption {
+	return func(o *writeOptions) {
+		o.confirmPriceChange = confirm
+	}
+}
+
+// applyWriteOptions - собирает параметры записи
+func applyWriteOptions(opts []WriteOption) writeOptions {
+	var o writeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// PriceGuard - проверка цен на правдоподобность: $56.99 не должно превратиться в $5699 из-за опечатки
+// Подозрительные изменения без подтверждения отклоняются, все подозрительные изменения пишутся в аудит
+type PriceGuard struct {
+	maxChangePercent float64
+	maxPrice         float64
+	audit            domain.AuditRepository
+}
+
+// NewPriceGuard - конструктор проверки цен
+func NewPriceGuard(cfg config.PriceGuardConfig, audit domain.AuditRepository) *PriceGuard {
+	return &PriceGuard{
+		maxChangePercent: float64(cfg.MaxChangePercent),
+		maxPrice:         float64(cfg.MaxPrice),
+		audit:            audit,
+	}
+}
+
+// Check - проверяет новую цену альбома (oldPrice == nil при создании)
+func (g *PriceGuard) Check(album *domain.Album, oldPrice *float64, confirmed bool) error {
+	newPrice := album.Price
+	reason := g.suspiciousReason(oldPrice, newPrice)
+	if reason == "" {
+		return nil
+	}
+
+	action := "price_change_rejected"
+	if confirmed {
+		action = "price_change_confirmed"
+	}
+
+	details := map[string]any{
+		"title":     album.Title,
+		"artist":    album.Artist,
+		"new_price": newPrice,
+		"reason":    reason,
+	}
+	if oldPrice != nil {
+		details["old_price"] = *oldPrice
+	}
+
+	entityID := album.ID
+	if entityID == "" {
+		entityID = "new" // Альбом еще не создан
+	}
+	err := g.audit.Record(domain.AuditEntry{
+		Action:     action,
+		EntityType: "album",
+		EntityID:   entityID,
+		Details:    details,
+	})
+	if err != nil {
+		log.Printf("recording price change audit error: %v", err)
+	}
+
+	if !confirmed {
+		return fmt.Errorf("%w: %s", ErrPriceConfirmationRequired, reason)
+	}
+	return nil
+}
+
+// suspiciousReason - почему цена выглядит подозрительно ("" - все в порядке)
+func (g *PriceGuard) suspiciousReason(oldPrice *float64, newPrice float64) string {
+	if g.maxPrice > 0 && newPrice > g.maxPrice {
+		return fmt.Sprintf("price %.2f is above %.2f", newPrice, g.maxPrice)
+	}
+
+	if g.maxChangePercent > 0 && oldPrice != nil && *oldPrice > 0 {
+		change := math.Abs(newPrice-*oldPrice) / *oldPrice * 100
+		if change > g.maxChangePercent {
+			return fmt.Sprintf("price changes by %.0f%% (%.2f -> %.2f), limit is %.0f%%", change, *oldPrice, newPrice, g.maxChangePercent)
+		}
+	}
+	return ""
+}
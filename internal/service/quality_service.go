@@ -0,0 +1,194 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/alert"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// qualityDigestInterval - как часто отправлять сводку качества каталога в чат команды
+const qualityDigestInterval = 7 * 24 * time.Hour
+
+// qualityPenalties - сколько баллов снимается за каждую проблему
+// Нулевая цена и дубли мешают продаже сильнее, чем незаполненные поля
+var qualityPenalties = map[string]int{
+	domain.QualityMissingGenre:     20,
+	domain.QualityMissingCondition: 15,
+	domain.QualityMissingYear:      10,
+	domain.QualityImplausibleYear:  20,
+	domain.QualityZeroPrice:        50,
+	domain.QualityDuplicateSuspect: 30,
+}
+
+// QualityService - проверка качества карточек каталога (незаполненные поля, нулевые цены, дубли)
+type QualityService struct {
+	albums *AlbumService
+	cfg    config.QualityConfig
+	alerts *alert.Notifier // Еженедельная сводка (nil - не отправляем)
+}
+
+// NewQualityService - конструктор сервиса качества каталога
+func NewQualityService(albums *AlbumService, cfg config.QualityConfig) *QualityService {
+	return &QualityService{albums: albums, cfg: cfg}
+}
+
+// SetNotifier - включает отправку еженедельной сводки в чат команды
+func (s *QualityService) SetNotifier(notifier *alert.Notifier) {
+	s.alerts = notifier
+}
+
+// Report - проверяет весь каталог и возвращает отчет
+func (s *QualityService) Report() (*domain.QualityReport, error) {
+	report := &domain.QualityReport{
+		IssueCounts: make(map[string]int),
+		Items:       []domain.AlbumQuality{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	var items []domain.AlbumQuality
+	byKey := make(map[string][]int) // Нормализованные исполнитель и название -> индексы в items
+	totalScore := 0
+
+	for album, err := range s.albums.StreamAllAlbums() {
+		if err != nil {
+			return nil, fmt.Errorf("reading catalog error: %w", err)
+		}
+
+		item := domain.AlbumQuality{AlbumID: album.ID, Title: album.Title, Artist: album.Artist}
+		s.checkAlbum(&item, album)
+
+		key := normalizeForDuplicates(album.Artist) + "\x00" + normalizeForDuplicates(album.Title)
+		byKey[key] = append(byKey[key], len(items))
+		items = append(items, item)
+	}
+
+	// Дубли видны только после чтения всего каталога
+	for _, indexes := range byKey {
+		if len(indexes) < 2 {
+			continue
+		}
+		for _, i := range indexes {
+			var others []string
+			for _, j := range indexes {
+				if j != i {
+					others = append(others, items[j].AlbumID)
+				}
+			}
+			addQualityIssue(&items[i], domain.QualityDuplicateSuspect,
+				fmt.Sprintf("same artist and title as %s", strings.Join(others, ", ")))
+		}
+	}
+
+	for _, item := range items {
+		item.Score = 100
+		for _, issue := range item.Issues {
+			item.Score -= issue.Penalty
+			report.IssueCounts[issue.Code]++
+		}
+		item.Score = max(item.Score, 0)
+		totalScore += item.Score
+
+		if len(item.Issues) > 0 {
+			report.Items = append(report.Items, item)
+		}
+	}
+
+	report.Albums = len(items)
+	report.Flagged = len(report.Items)
+	if report.Albums > 0 {
+		report.Score = float64(totalScore) / float64(report.Albums)
+	}
+
+	slices.SortStableFunc(report.Items, func(a, b domain.AlbumQuality) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), strings.Compare(a.AlbumID, b.AlbumID))
+	})
+	return report, nil
+}
+
+// checkAlbum - проверки одной карточки, не зависящие от остального каталога
+func (s *QualityService) checkAlbum(item *domain.AlbumQuality, album domain.Album) {
+	if strings.TrimSpace(album.Genre) == "" {
+		addQualityIssue(item, domain.QualityMissingGenre, "genre is not set")
+	}
+	if album.Condition == "" {
+		addQualityIssue(item, domain.QualityMissingCondition, "condition is not set")
+	}
+	if album.Price == 0 {
+		addQualityIssue(item, domain.QualityZeroPrice, "price is zero")
+	}
+
+	maxYear := time.Now().Year() + 1 // Предзаказы на следующий год
+	switch {
+	case album.Year == 0:
+		addQualityIssue(item, domain.QualityMissingYear, "year is not set")
+	case album.Year < s.cfg.MinYear || album.Year > maxYear:
+		addQualityIssue(item, domain.QualityImplausibleYear,
+			fmt.Sprintf("year %d is outside %d-%d", album.Year, s.cfg.MinYear, maxYear))
+	}
+}
+
+// addQualityIssue - добавляет проблему в оценку альбома
+func addQualityIssue(item *domain.AlbumQuality, code, message string) {
+	item.Issues = append(item.Issues, domain.QualityIssue{Code: code, Message: message, Penalty: qualityPenalties[code]})
+}
+
+// normalizeForDuplicates - приводит исполнителя или название к виду для поиска дублей:
+// без регистра, пунктуации и лишних пробелов ("The Blues Brothers " == "the blues-brothers")
+func normalizeForDuplicates(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+	return strings.Join(fields, " ")
+}
+
+// StartDigest - запускает еженедельную отправку сводки качества каталога до отмены контекста
+func (s *QualityService) StartDigest(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(qualityDigestInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.sendDigest()
+			}
+		}
+	}()
+}
+
+// sendDigest - отправляет сводку: общая оценка и количество альбомов по проблемам
+func (s *QualityService) sendDigest() {
+	report, err := s.Report()
+	if err != nil {
+		log.Printf("building catalog quality digest error: %v", err)
+		return
+	}
+
+	codes := make([]string, 0, len(report.IssueCounts))
+	for code := range report.IssueCounts {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "Catalog score %.1f/100, %d of %d albums need attention", report.Score, report.Flagged, report.Albums)
+	for _, code := range codes {
+		fmt.Fprintf(&message, "\n%s: %d", code, report.IssueCounts[code])
+	}
+
+	s.alerts.Notify(alert.Alert{
+		Type:    alert.TypeQualityDigest,
+		Key:     report.GeneratedAt.Format(time.DateOnly),
+		Title:   "Weekly catalog quality digest",
+		Message: message.String(),
+	})
+}
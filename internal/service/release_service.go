@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"time"
+)
+
+// maxFeedReleases - сколько релизов максимум отдаем в одном календаре
+const maxFeedReleases = 500
+
+// ReleaseService - сервис дат выхода альбомов и календаря релизов
+type ReleaseService struct {
+	repo domain.AlbumReleaseRepository
+}
+
+// NewReleaseService - конструктор сервиса релизов
+func NewReleaseService(repo domain.AlbumReleaseRepository) *ReleaseService {
+	return &ReleaseService{repo: repo}
+}
+
+// SetReleaseDate - задает дату выхода альбома в формате 2006-01-02 ("" - снять дату)
+func (s *ReleaseService) SetReleaseDate(id, date string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if date == "" {
+		return s.repo.SetReleaseDate(id, nil)
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return fmt.Errorf("invalid release date %q, expected YYYY-MM-DD", date)
+	}
+	return s.repo.SetReleaseDate(id, &releaseDate)
+}
+
+// GetUpcomingReleases - предстоящие релизы (начиная с сегодняшнего дня) с отбором по исполнителю и жанру
+func (s *ReleaseService) GetUpcomingReleases(artist, genre string) ([]domain.Release, error) {
+	return s.repo.GetReleases(domain.ReleaseFilter{
+		Artist: artist,
+		Genre:  genre,
+		From:   time.Now().UTC().Truncate(24 * time.Hour),
+		Limit:  maxFeedReleases,
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения отзывов: оценка по пятибалльной шкале, текст - чтобы отзыв оставался отзывом
+const (
+	minReviewRating     = 1
+	maxReviewRating     = 5
+	maxReviewTextLength = 5000
+)
+
+// albumCache - кэш альбомов, который нужно сбросить после изменения альбома в обход AlbumService
+// (пересчет рейтинга, назначение лейбла)
+type albumCache interface {
+	InvalidateAlbum(id string)
+}
+
+// ReviewService - сервис отзывов покупателей к альбомам
+type ReviewService struct {
+	repo  domain.ReviewRepository
+	cache albumCache // nil - альбомы не кэшируются
+}
+
+// NewReviewService - конструктор сервиса отзывов
+func NewReviewService(repo domain.ReviewRepository) *ReviewService {
+	return &ReviewService{repo: repo}
+}
+
+// SetCache - включает сброс кэша альбома после изменения его отзывов
+func (s *ReviewService) SetCache(cache albumCache) {
+	s.cache = cache
+}
+
+// GetReviews - отзывы альбома от новых к старым и общее их количество
+func (s *ReviewService) GetReviews(albumID string, limit, offset int) ([]domain.Review, int, error) {
+	if albumID == "" {
+		return nil, 0, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetReviews(albumID, limit, offset)
+}
+
+// AddReview - добавляет отзыв покупателя; второй отзыв того же покупателя к альбому - ErrDuplicateReview
+func (s *ReviewService) AddReview(review *domain.Review) error {
+	review.Text = strings.TrimSpace(review.Text)
+
+	switch {
+	case review.AlbumID == "":
+		return fmt.Errorf("id cannot be empty")
+	case review.CustomerID == "":
+		return fmt.Errorf("customer id cannot be empty")
+	case review.Rating < minReviewRating || review.Rating > maxReviewRating:
+		return fmt.Errorf("rating must be between %d and %d", minReviewRating, maxReviewRating)
+	case utf8.RuneCountInString(review.Text) > maxReviewTextLength:
+		return fmt.Errorf("review is longer than %d characters", maxReviewTextLength)
+	}
+
+	if err := s.repo.Create(review); err != nil {
+		return err
+	}
+
+	s.invalidate(review.AlbumID)
+	return nil
+}
+
+// DeleteReview - удаляет отзыв покупателя к альбому
+func (s *ReviewService) DeleteReview(albumID, customerID string) error {
+	if err := s.repo.Delete(albumID, customerID); err != nil {
+		return err
+	}
+
+	s.invalidate(albumID)
+	return nil
+}
+
+// invalidate - сбрасывает кэш альбома: в нем средняя оценка и число отзывов
+func (s *ReviewService) invalidate(albumID string) {
+	if s.cache != nil {
+		s.cache.InvalidateAlbum(albumID)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidQuery - строка поиска не разбирается (неверный диапазон, значение поля и т.п.)
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// ParseSearchQuery - разбирает строку поиска в фильтр репозитория
+//
+// Синтаксис:
+//
+//	coltrane "blue train"       - слова и фразы в исполнителе, названии, лейбле или каталожном номере
+//	-reissue -"live at"         - исключить слово или фразу
+//	-genre:reissue              - исключить исполнителя или жанр (-artist, -genre); другие поля исключать нельзя
+//	artist:"Miles Davis"        - поля: artist, title, genre, label, catno, condition
+//	catno:"BLP 1577"            - каталожный номер без учета регистра, пробелов и дефисов
+//	year:1959..1965 year:1959.. - диапазоны: year, price (границы включительно)
+//	stock:yes                   - только в наличии (yes/no)
+//
+// Неизвестные поля считаются обычным текстом (в названиях бывают двоеточия)
+func ParseSearchQuery(query string) (domain.SearchFilter, error) {
+	var filter domain.SearchFilter
+
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return filter, err
+	}
+
+	for _, token := range tokens {
+		if token.negated {
+			switch token.key {
+			case "":
+				filter.ExcludeTerms = append(filter.ExcludeTerms, token.value)
+			case "artist":
+				filter.ExcludeArtists = append(filter.ExcludeArtists, token.value)
+			case "genre":
+				filter.ExcludeGenres = append(filter.ExcludeGenres, token.value)
+			default:
+				return filter, fmt.Errorf("%w: %s cannot be excluded", ErrInvalidQuery, token.key)
+			}
+			continue
+		}
+
+		switch token.key {
+		case "":
+			filter.Terms = append(filter.Terms, token.value)
+		case "artist":
+			filter.Artist = token.value
+		case "title":
+			filter.Title = token.value
+		case "genre":
+			filter.Genre = token.value
+		case "label":
+			filter.Label = token.value
+		case "catno":
+			filter.CatalogNumber = token.value
+		case "condition":
+			filter.Condition = strings.ToLower(token.value)
+		case "year":
+			from, to, err := parseRange(token.value, strconv.Atoi)
+			if err != nil {
+				return filter, fmt.Errorf("%w: year: %v", ErrInvalidQuery, err)
+			}
+			filter.YearFrom, filter.YearTo = from, to
+		case "price":
+			from, to, err := parseRange(token.value, func(s string) (float64, error) {
+				return strconv.ParseFloat(s, 64)
+			})
+			if err != nil {
+				return filter, fmt.Errorf("%w: price: %v", ErrInvalidQuery, err)
+			}
+			filter.PriceFrom, filter.PriceTo = from, to
+		case "stock":
+			inStock, err := parseYesNo(token.value)
+			if err != nil {
+				return filter, fmt.Errorf("%w: stock: %v", ErrInvalidQuery, err)
+			}
+			filter.InStock = &inStock
+		default:
+			filter.Terms = append(filter.Terms, token.text())
+		}
+	}
+
+	return filter, nil
+}
+
+// queryToken - элемент строки поиска: [-][key:]value
+type queryToken struct {
+	negated bool
+	key     string
+	value   string
+}
+
+// text - токен как обычный текст (для неизвестных полей)
+func (t queryToken) text() string {
+	if t.key == "" {
+		return t.value
+	}
+	return t.key + ":" + t.value
+}
+
+// searchFields - поля, которые распознаются в строке поиска
+var searchFields = map[string]bool{
+	"artist": true, "title": true, "genre": true, "label": true, "catno": true, "condition": true,
+	"year": true, "price": true, "stock": true,
+}
+
+// tokenizeQuery - делит строку по пробелам с учетом кавычек
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		var token queryToken
+
+		if strings.HasPrefix(rest, "-") && len(rest) > 1 {
+			token.negated = true
+			rest = rest[1:]
+		}
+
+		// Имя поля - до двоеточия, если двоеточие раньше пробела и кавычки
+		if i := strings.IndexAny(rest, ": \""); i > 0 && rest[i] == ':' {
+			if key := strings.ToLower(rest[:i]); searchFields[key] {
+				token.key = key
+				rest = rest[i+1:]
+			}
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidQuery)
+			}
+			token.value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			token.value = rest[:end]
+			rest = rest[end:]
+		}
+		rest = strings.TrimSpace(rest)
+
+		token.value = strings.TrimSpace(token.value)
+		if token.value != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+// parseRange - разбирает "from..to", "from..", "..to" или одно значение (from = to)
+func parseRange[T int | float64](s string, parse func(string) (T, error)) (T, T, error) {
+	var from, to T
+
+	fromStr, toStr, isRange := strings.Cut(s, "..")
+	if !isRange {
+		toStr = fromStr
+	}
+
+	var err error
+	if fromStr != "" {
+		if from, err = parse(fromStr); err != nil {
+			return from, to, fmt.Errorf("invalid value %q", fromStr)
+		}
+	}
+	if toStr != "" {
+		if to, err = parse(toStr); err != nil {
+			return from, to, fmt.Errorf("invalid value %q", toStr)
+		}
+	}
+	if fromStr == "" && toStr == "" {
+		return from, to, fmt.Errorf("empty range")
+	}
+	if fromStr != "" && toStr != "" && from > to {
+		return from, to, fmt.Errorf("range %q is reversed", s)
+	}
+
+	return from, to, nil
+}
+
+// parseYesNo - разбирает логическое значение поля (yes/no, true/false, 1/0)
+func parseYesNo(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "yes", "true", "1":
+		return true, nil
+	case "no", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected yes or no, got %q", s)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxCorrections     = 3   // Сколько вариантов "Возможно, вы имели в виду" возвращать
+	maxZeroResultQuery = 200 // Длиннее запросы в журнале поисков без результатов обрезаются
+)
+
+// SearchService - сервис поиска по каталогу
+type SearchService struct {
+	repo        domain.AlbumSearchRepository
+	zeroResults domain.ZeroResultSearchRepository // Журнал поисков без результатов (nil - не ведется)
+}
+
+// NewSearchService - конструктор сервиса поиска
+func NewSearchService(repo domain.AlbumSearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// SetZeroResultLog - включает запись поисков без результатов (не на репликах только для чтения)
+func (s *SearchService) SetZeroResultLog(repo domain.ZeroResultSearchRepository) {
+	s.zeroResults = repo
+}
+
+// Search - страница альбомов по строке запроса (см. ParseSearchQuery); размер страницы - не больше MaxAlbumsPageSize
+// Если ничего не нашлось - предлагает исправления запроса
+func (s *SearchService) Search(query string, limit, offset int) (*domain.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query cannot be empty", ErrInvalidQuery)
+	}
+	if limit <= 0 || limit > MaxAlbumsPageSize {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxAlbumsPageSize)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("%w: offset cannot be negative", ErrInvalidQuery)
+	}
+
+	filter, err := ParseSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	albums, total, err := s.repo.Search(filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if albums == nil {
+		albums = []domain.Album{}
+	}
+
+	result := &domain.SearchResult{
+		Albums: albums,
+		Meta:   domain.SearchMeta{Query: query, Total: total, Limit: limit, Offset: offset},
+	}
+
+	// Исправления ищем по тексту запроса: исполнителю или свободным словам
+	correctable := filter.Artist
+	if correctable == "" {
+		correctable = strings.Join(filter.Terms, " ")
+	}
+
+	// Пустая дальняя страница - не поиск без результатов
+	if total == 0 {
+		s.recordZeroResult(query)
+	}
+
+	if total == 0 && correctable != "" {
+		// Подсказки - не основной результат: при ошибке просто отдаем пустой ответ
+		corrections, err := s.repo.SuggestCorrections(correctable, maxCorrections)
+		if err != nil {
+			log.Printf("suggesting search corrections error: %v", err)
+		}
+		result.Meta.DidYouMean = corrections
+	}
+
+	return result, nil
+}
+
+// recordZeroResult - асинхронно записывает поиск без результатов, не задерживая ответ
+func (s *SearchService) recordZeroResult(query string) {
+	if s.zeroResults == nil {
+		return
+	}
+
+	query = normalizeZeroResultQuery(query)
+	at := time.Now()
+	go func() {
+		if err := s.zeroResults.Record(query, at); err != nil {
+			log.Printf("recording zero-result search error: %v", err)
+		}
+	}()
+}
+
+// normalizeZeroResultQuery - запрос в нижнем регистре с одиночными пробелами, не длиннее maxZeroResultQuery символов
+func normalizeZeroResultQuery(query string) string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if utf8.RuneCountInString(query) > maxZeroResultQuery {
+		query = string([]rune(query)[:maxZeroResultQuery])
+	}
+	return query
+}
+
+// ZeroResultSearches - самые частые запросы без результатов за последние days дней
+func (s *SearchService) ZeroResultSearches(days, limit int) ([]domain.ZeroResultSearch, error) {
+	if s.zeroResults == nil {
+		return []domain.ZeroResultSearch{}, nil
+	}
+	return s.zeroResults.List(since(days), limit)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/storage"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotPrefix   = "snapshots/catalog-"
+	snapshotSuffix   = ".ndjson.gz"
+	snapshotIDLayout = "20060102T150405Z"
+)
+
+// snapshotIDPattern - идентификатор снимка - время создания в UTC
+var snapshotIDPattern = regexp.MustCompile(`^\d{8}T\d{6}Z$`)
+
+// SnapshotService - снимки каталога и восстановление из них
+// Снимок - gzip архив NDJSON (один альбом со складскими данными на строку) в объектном хранилище
+type SnapshotService struct {
+	albums    domain.AlbumCostRepository // Источник полных данных альбомов (с закупочными ценами и местами)
+	repo      domain.SnapshotRepository
+	storage   storage.ObjectStorage
+	onRestore func()                 // Вызывается после восстановления: сброс кэшей, индексов и т.п.
+	audit     domain.AuditRepository // nil - создание снимков не пишется в аудит
+}
+
+// NewSnapshotService - конструктор сервиса снимков
+func NewSnapshotService(albums domain.AlbumCostRepository, repo domain.SnapshotRepository, objectStorage storage.ObjectStorage, onRestore func()) *SnapshotService {
+	return &SnapshotService{albums: albums, repo: repo, storage: objectStorage, onRestore: onRestore}
+}
+
+// SetAuditLog - включает запись создания снимков в журнал аудита (восстановление пишется всегда)
+func (s *SnapshotService) SetAuditLog(audit domain.AuditRepository) {
+	s.audit = audit
+}
+
+// CreateSnapshot - сохраняет текущий каталог в новый снимок
+// actor - пользователь из токена для журнала аудита
+func (s *SnapshotService) CreateSnapshot(ctx context.Context, actor string) (*domain.SnapshotInfo, error) {
+	albums, err := s.albums.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, album := range albums {
+		if err := encoder.Encode(album); err != nil {
+			return nil, fmt.Errorf("encoding snapshot error: %w", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("compressing snapshot error: %w", err)
+	}
+
+	createdAt := time.Now().UTC()
+	info := &domain.SnapshotInfo{
+		ID:        createdAt.Format(snapshotIDLayout),
+		CreatedAt: createdAt,
+		Size:      int64(buf.Len()),
+		Albums:    len(albums),
+	}
+
+	if err := s.storage.Put(ctx, snapshotKey(info.ID), &buf, "application/gzip"); err != nil {
+		return nil, err
+	}
+
+	log.Printf("catalog snapshot %s has been created (%d albums)", info.ID, info.Albums)
+	if s.audit != nil {
+		err := s.audit.Record(domain.AuditEntry{
+			Action:     "catalog_snapshot_created",
+			EntityType: "snapshot",
+			EntityID:   info.ID,
+			Details:    snapshotAuditDetails(info.Albums, actor),
+		})
+		if err != nil {
+			log.Printf("recording snapshot audit error: %v", err)
+		}
+	}
+	return info, nil
+}
+
+// ListSnapshots - возвращает снимки, новые первыми
+func (s *SnapshotService) ListSnapshots(ctx context.Context) ([]domain.SnapshotInfo, error) {
+	objects, err := s.storage.List(ctx, snapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []domain.SnapshotInfo{}
+	for i := len(objects) - 1; i >= 0; i-- {
+		id := strings.TrimSuffix(strings.TrimPrefix(objects[i].Key, snapshotPrefix), snapshotSuffix)
+		createdAt, err := time.Parse(snapshotIDLayout, id)
+		if err != nil {
+			continue // Посторонний файл
+		}
+		snapshots = append(snapshots, domain.SnapshotInfo{ID: id, CreatedAt: createdAt, Size: objects[i].Size})
+	}
+	return snapshots, nil
+}
+
+// PreviewRestore - загружает снимок в промежуточную таблицу и показывает, что изменится
+func (s *SnapshotService) PreviewRestore(ctx context.Context, id string) (*domain.SnapshotDiff, error) {
+	albums, err := s.readSnapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.repo.Stage(albums)
+	if err != nil {
+		return nil, err
+	}
+	diff.SnapshotID = id
+	return diff, nil
+}
+
+// Restore - возвращает каталог к состоянию снимка
+// actor - пользователь из токена; запись аудита пишется в транзакции восстановления
+func (s *SnapshotService) Restore(ctx context.Context, id, actor string) error {
+	albums, err := s.readSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Restore(albums, domain.AuditEntry{
+		Action:     "catalog_restored",
+		EntityType: "snapshot",
+		EntityID:   id,
+		Details:    snapshotAuditDetails(len(albums), actor),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("catalog has been restored from snapshot %s (%d albums, actor %q)", id, len(albums), actor)
+	if s.onRestore != nil {
+		s.onRestore()
+	}
+	return nil
+}
+
+// snapshotAuditDetails - детали записи аудита о снимке
+func snapshotAuditDetails(albums int, actor string) map[string]any {
+	details := map[string]any{"albums": albums}
+	if actor != "" {
+		details["actor"] = actor
+	}
+	return details
+}
+
+// readSnapshot - читает альбомы из снимка
+func (s *SnapshotService) readSnapshot(ctx context.Context, id string) ([]domain.AdminAlbum, error) {
+	if !snapshotIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("invalid snapshot id %q", id)
+	}
+
+	object, err := s.storage.Get(ctx, snapshotKey(id))
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	gz, err := gzip.NewReader(object)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s error: %w", id, err)
+	}
+	defer gz.Close()
+
+	var albums []domain.AdminAlbum
+	decoder := json.NewDecoder(gz)
+	for {
+		var album snapshotAlbum
+		err := decoder.Decode(&album)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading snapshot %s error: %w", id, err)
+		}
+		albums = append(albums, album.adminAlbum())
+	}
+	return albums, nil
+}
+
+// snapshotAlbum - альбом из снимка; в снимках, снятых до появления stock_quantity, есть только in_stock
+type snapshotAlbum struct {
+	domain.AdminAlbum
+	StockQuantity *int  `json:"stock_quantity"`
+	InStock       *bool `json:"in_stock"`
+}
+
+// adminAlbum - альбом снимка с количеством на складе (старый снимок: 1 экземпляр, если альбом был в наличии)
+func (a snapshotAlbum) adminAlbum() domain.AdminAlbum {
+	album := a.AdminAlbum
+	album.StockQuantity = legacyStockQuantity(a.InStock, 0)
+	if a.StockQuantity != nil {
+		album.StockQuantity = *a.StockQuantity
+	}
+	return album
+}
+
+// snapshotKey - ключ снимка в хранилище
+func snapshotKey(id string) string {
+	return snapshotPrefix + id + snapshotSuffix
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"go-music-shop/internal/domain/models"
+	"log"
+	"time"
+)
+
+// StatsService - сервис статистики каталога
+// Статистика хранится в материализованном представлении и пересчитывается по расписанию
+type StatsService struct {
+	repo            domain.AlbumStatsRepository
+	refreshInterval time.Duration
+}
+
+// NewStatsService - конструктор сервиса статистики
+func NewStatsService(repo domain.AlbumStatsRepository, refreshInterval time.Duration) *StatsService {
+	return &StatsService{repo: repo, refreshInterval: refreshInterval}
+}
+
+// GetStats - возвращает статистику каталога и ее возраст (насколько она устарела)
+func (s *StatsService) GetStats() (*domain.AlbumStats, time.Duration, error) {
+	stats, err := s.repo.GetStats()
+	if err != nil {
+		return nil, 0, err
+	}
+	return stats, time.Since(stats.RefreshedAt), nil
+}
+
+// StartRefresher - запускает фоновый пересчет статистики до отмены контекста
+func (s *StatsService) StartRefresher(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.repo.RefreshStats(); err != nil {
+					log.Printf("refreshing album stats error: %v", err)
+				}
+			}
+		}
+	}()
+}
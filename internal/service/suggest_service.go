@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/redis"
+	"iter"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	suggestIndexKey = "album:suggest"      // Sorted set строк "термин\x00тип\x00текст" для поиска по префиксу
+	suggestRefsKey  = "album:suggest:refs" // Сколько альбомов ссылаются на каждую строку индекса
+	suggestSep      = "\x00"
+)
+
+// Suggestion - вариант автодополнения для строки поиска
+type Suggestion struct {
+	Text string `json:"text"`
+	Type string `json:"type"` // "artist" или "title"
+}
+
+// SuggestService - автодополнение поиска по исполнителям и названиям
+// Индекс хранится в Redis (sorted set + ZRANGEBYLEX), поэтому подсказки не ходят в БД
+type SuggestService struct {
+	redis   *redis.RedisClient
+	timeOut time.Duration
+}
+
+// NewSuggestService - конструктор сервиса подсказок
+func NewSuggestService(redisClient *redis.RedisClient) *SuggestService {
+	return &SuggestService{redis: redisClient, timeOut: 2 * time.Second}
+}
+
+// Suggest - возвращает до limit подсказок, начинающихся с prefix
+// Префикс ищется с начала любого слова: "col" находит "John Coltrane"
+func (s *SuggestService) Suggest(prefix string, limit int) ([]Suggestion, error) {
+	prefix = normalizeTerm(prefix)
+	if prefix == "" {
+		return []Suggestion{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeOut)
+	defer cancel()
+
+	// Одна и та же подсказка встречается под разными словами - берем с запасом
+	members, err := s.redis.ZRangeByLex(ctx, suggestIndexKey, "["+prefix, "["+prefix+"\xff", int64(limit*3))
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, limit)
+	seen := make(map[Suggestion]bool)
+	for _, member := range members {
+		parts := strings.SplitN(member, suggestSep, 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		suggestion := Suggestion{Type: parts[1], Text: parts[2]}
+		if seen[suggestion] {
+			continue
+		}
+		seen[suggestion] = true
+
+		suggestions = append(suggestions, suggestion)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+	return suggestions, nil
+}
+
+// AlbumChanged - обновляет индекс после изменения альбома (реализует CatalogListener)
+func (s *SuggestService) AlbumChanged(old, updated *domain.Album) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeOut)
+	defer cancel()
+
+	// Строки, которые есть и в старой и в новой версии, не трогаем
+	added := make(map[string]int)
+	if updated != nil {
+		for _, member := range indexMembers(updated) {
+			added[member]++
+		}
+	}
+	if old != nil {
+		for _, member := range indexMembers(old) {
+			added[member]--
+		}
+	}
+
+	for member, delta := range added {
+		if delta == 0 {
+			continue
+		}
+		if err := s.changeRef(ctx, member, int64(delta)); err != nil {
+			log.Printf("updating suggest index error: %v", err)
+		}
+	}
+}
+
+// changeRef - меняет счетчик ссылок на строку индекса
+// Строка добавляется в индекс при первой ссылке и удаляется, когда ссылок не осталось
+func (s *SuggestService) changeRef(ctx context.Context, member string, delta int64) error {
+	refs, err := s.redis.HIncrBy(ctx, suggestRefsKey, member, delta)
+	if err != nil {
+		return err
+	}
+
+	if refs > 0 {
+		return s.redis.ZAddLex(ctx, suggestIndexKey, member)
+	}
+	if err := s.redis.ZRem(ctx, suggestIndexKey, member); err != nil {
+		return err
+	}
+	return s.redis.HDel(ctx, suggestRefsKey, member)
+}
+
+// Rebuild - полностью перестраивает индекс по каталогу
+// Индекс собирается во временных ключах и подменяется атомарно, чтобы подсказки не пропадали
+func (s *SuggestService) Rebuild(ctx context.Context, albums iter.Seq2[domain.Album, error]) error {
+	refs := make(map[string]any)
+	for album, err := range albums {
+		if err != nil {
+			return err
+		}
+		for _, member := range indexMembers(&album) {
+			count, _ := refs[member].(int)
+			refs[member] = count + 1
+		}
+	}
+
+	if len(refs) == 0 {
+		if err := s.redis.Delete(ctx, suggestIndexKey); err != nil {
+			return err
+		}
+		return s.redis.Delete(ctx, suggestRefsKey)
+	}
+
+	members := make([]string, 0, len(refs))
+	for member := range refs {
+		members = append(members, member)
+	}
+
+	tmpIndexKey, tmpRefsKey := suggestIndexKey+":rebuild", suggestRefsKey+":rebuild"
+	if err := s.redis.ZAddLex(ctx, tmpIndexKey, members...); err != nil {
+		return err
+	}
+	if err := s.redis.HSet(ctx, tmpRefsKey, refs); err != nil {
+		return err
+	}
+	if _, err := s.redis.Rename(ctx, tmpIndexKey, suggestIndexKey); err != nil {
+		return err
+	}
+	if _, err := s.redis.Rename(ctx, tmpRefsKey, suggestRefsKey); err != nil {
+		return err
+	}
+
+	log.Printf("suggest index has been rebuilt (%d entries)", len(members))
+	return nil
+}
+
+// indexMembers - строки индекса для альбома: по одной на каждое слово исполнителя и названия,
+// чтобы подсказка находилась по началу любого слова
+func indexMembers(album *domain.Album) []string {
+	var members []string
+	for _, field := range []struct{ kind, text string }{
+		{"artist", album.Artist},
+		{"title", album.Title},
+	} {
+		text := strings.TrimSpace(field.text)
+		if text == "" {
+			continue
+		}
+
+		words := strings.Fields(normalizeTerm(text))
+		for i := range words {
+			term := strings.Join(words[i:], " ")
+			members = append(members, term+suggestSep+field.kind+suggestSep+text)
+		}
+	}
+	return members
+}
+
+// normalizeTerm - приводит строку к виду для поиска: нижний регистр, одиночные пробелы
+func normalizeTerm(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
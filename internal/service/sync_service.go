@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+	"go-music-shop/internal/domain/models"
+	"strconv"
+)
+
+// ErrInvalidCursor - курсор синхронизации не разобран (клиент должен начать с пустого курсора)
+var ErrInvalidCursor = errors.New("invalid sync cursor")
+
+// SyncBatch - пачка изменений каталога для офлайн-копии
+// Клиент сначала применяет удаления, затем альбомы: альбом, созданный заново после удаления,
+// всегда имеет больший номер, чем его tombstone
+type SyncBatch struct {
+	Albums  []domain.SyncAlbum `json:"albums"`
+	Deleted []string           `json:"deleted"`
+	Cursor  string             `json:"cursor"`   // Передать в ?since= следующего запроса
+	HasMore bool               `json:"has_more"` // Есть еще изменения - запросить сразу
+}
+
+// SyncService - сервис синхронизации каталога с мобильным приложением
+type SyncService struct {
+	repo domain.AlbumSyncRepository
+}
+
+// NewSyncService - конструктор сервиса синхронизации
+func NewSyncService(repo domain.AlbumSyncRepository) *SyncService {
+	return &SyncService{repo: repo}
+}
+
+// GetChanges - изменения после курсора ("" - весь каталог с начала)
+func (s *SyncService) GetChanges(cursor string, limit int) (*SyncBatch, error) {
+	var after int64
+	if cursor != "" {
+		var err error
+		if after, err = strconv.ParseInt(cursor, 10, 64); err != nil || after < 0 {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	// Запрашиваем на одно изменение больше, чтобы узнать, есть ли следующая пачка
+	changes, err := s.repo.GetChanges(after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &SyncBatch{Albums: []domain.SyncAlbum{}, Deleted: []string{}, Cursor: cursor}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		batch.HasMore = true
+	}
+
+	deleted := make(map[string]bool)
+	for _, change := range changes {
+		if change.Album != nil {
+			batch.Albums = append(batch.Albums, *change.Album)
+		} else if !deleted[change.AlbumID] {
+			deleted[change.AlbumID] = true
+			batch.Deleted = append(batch.Deleted, change.AlbumID)
+		}
+		batch.Cursor = strconv.FormatInt(change.Seq, 10)
+	}
+
+	return batch, nil
+}
+
+// GetChecksum - контрольная сумма каталога для проверки офлайн-копии
+func (s *SyncService) GetChecksum() (*domain.SyncChecksum, error) {
+	return s.repo.GetChecksum()
+}
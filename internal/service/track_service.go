@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTrackTitleLength = 255       // Как у колонки tracks.title
+	maxTrackDuration    = 24 * 3600 // Длиннее суток - явно опечатка (миллисекунды вместо секунд)
+	maxAlbumTracks      = 200       // Бокс-сеты бывают большими, но не бесконечными
+)
+
+// TrackService - сервис списка треков альбомов
+type TrackService struct {
+	repo domain.TrackRepository
+}
+
+// NewTrackService - конструктор сервиса треков
+func NewTrackService(repo domain.TrackRepository) *TrackService {
+	return &TrackService{repo: repo}
+}
+
+// GetTracks - треки альбома по порядку
+func (s *TrackService) GetTracks(albumID string) ([]domain.Track, error) {
+	if albumID == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.GetTracks(albumID)
+}
+
+// ReplaceTracks - заменяет весь список треков альбома
+// Позиции задаются у всех треков или ни у одного - тогда треки нумеруются по порядку в списке
+func (s *TrackService) ReplaceTracks(albumID string, tracks []domain.Track) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if len(tracks) > maxAlbumTracks {
+		return fmt.Errorf("album cannot have more than %d tracks", maxAlbumTracks)
+	}
+
+	numbered := 0
+	for i := range tracks {
+		if tracks[i].Position != 0 {
+			numbered++
+		}
+	}
+	if numbered != 0 && numbered != len(tracks) {
+		return fmt.Errorf("position must be set for all tracks or for none")
+	}
+
+	positions := make(map[int]bool, len(tracks))
+	for i := range tracks {
+		if numbered == 0 {
+			tracks[i].Position = i + 1
+		}
+		if err := validateTrack(&tracks[i]); err != nil {
+			return fmt.Errorf("track %d: %w", i+1, err)
+		}
+		if positions[tracks[i].Position] {
+			return fmt.Errorf("track %d: %w", i+1, domain.ErrDuplicateTrackPosition)
+		}
+		positions[tracks[i].Position] = true
+	}
+
+	return s.repo.ReplaceTracks(albumID, tracks)
+}
+
+// AddTrack - добавляет трек к альбому; без позиции - в конец списка
+func (s *TrackService) AddTrack(track *domain.Track) error {
+	if track.AlbumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if track.Position != 0 {
+		if err := validateTrack(track); err != nil {
+			return err
+		}
+	} else if err := validateTrackFields(track); err != nil {
+		return err
+	}
+	return s.repo.Create(track)
+}
+
+// UpdateTrack - изменяет трек альбома
+func (s *TrackService) UpdateTrack(track *domain.Track) error {
+	if track.AlbumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if err := validateTrack(track); err != nil {
+		return err
+	}
+	return s.repo.Update(track)
+}
+
+// DeleteTrack - удаляет трек альбома
+func (s *TrackService) DeleteTrack(albumID string, trackID int64) error {
+	if albumID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	return s.repo.Delete(albumID, trackID)
+}
+
+// validateTrack - проверяет позицию и поля трека
+func validateTrack(track *domain.Track) error {
+	if track.Position < 1 {
+		return fmt.Errorf("position must be positive")
+	}
+	return validateTrackFields(track)
+}
+
+// validateTrackFields - проверяет и нормализует название и длительность трека
+func validateTrackFields(track *domain.Track) error {
+	track.Title = strings.TrimSpace(track.Title)
+
+	switch {
+	case track.Title == "":
+		return fmt.Errorf("title cannot be empty")
+	case utf8.RuneCountInString(track.Title) > maxTrackTitleLength:
+		return fmt.Errorf("title is longer than %d characters", maxTrackTitleLength)
+	case track.DurationSeconds < 0:
+		return fmt.Errorf("duration cannot be negative")
+	case track.DurationSeconds > maxTrackDuration:
+		return fmt.Errorf("duration cannot exceed %d seconds", maxTrackDuration)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"go-music-shop/internal/domain/models"
+	"go-music-shop/pkg/redis"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	viewsKeyPrefix    = "album:views:"          // Хэш просмотров за день: album:views:2006-01-02 -> {albumID: count}
+	flushingKeyPrefix = "album:views-flushing:" // Хэш, который сейчас сбрасывается в БД: album:views-flushing:2006-01-02:<захват>
+	viewsDayLayout    = "2006-01-02"
+
+	// flushingGracePeriod - через сколько ключ сброса считается брошенным (экземпляр упал посреди сброса)
+	// До этого его не трогают: другой экземпляр может еще записывать его в БД
+	flushingGracePeriod = 10 * time.Minute
+)
+
+// ViewService - сервис просмотров альбомов
+// Просмотры считаются в Redis и пачками сбрасываются в БД, чтобы не писать в БД на каждое чтение
+type ViewService struct {
+	repo          domain.AlbumViewRepository
+	redis         *redis.RedisClient
+	flushInterval time.Duration
+}
+
+// NewViewService - конструктор сервиса просмотров
+func NewViewService(repo domain.AlbumViewRepository, redisClient *redis.RedisClient, flushInterval time.Duration) *ViewService {
+	return &ViewService{repo: repo, redis: redisClient, flushInterval: flushInterval}
+}
+
+// RecordView - учитывает просмотр альбома (асинхронно, не задерживая ответ)
+func (s *ViewService) RecordView(albumID string) {
+	key := viewsKeyPrefix + time.Now().UTC().Format(viewsDayLayout)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		if _, err := s.redis.HIncrBy(ctx, key, albumID, 1); err != nil {
+			log.Printf("recording album view error: %v", err)
+		}
+	}()
+}
+
+// GetAlbumViews - возвращает просмотры альбома по дням за последние days дней
+// Учитываются только уже сброшенные в БД просмотры
+func (s *ViewService) GetAlbumViews(albumID string, days int) (*domain.AlbumViews, error) {
+	daily, err := s.repo.GetViews(albumID, since(days))
+	if err != nil {
+		return nil, err
+	}
+
+	views := &domain.AlbumViews{AlbumID: albumID, Daily: daily}
+	for _, d := range daily {
+		views.TotalViews += d.Views
+	}
+	return views, nil
+}
+
+// GetTrending - возвращает самые просматриваемые альбомы за последние days дней
+func (s *ViewService) GetTrending(days, limit int) ([]domain.TrendingAlbum, error) {
+	return s.repo.GetTrending(since(days), limit)
+}
+
+// since - начало периода в днях от сегодняшнего (UTC), включая сегодня
+func since(days int) time.Time {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(days - 1))
+}
+
+// StartFlusher - запускает фоновый сброс счетчиков в БД до отмены контекста
+func (s *ViewService) StartFlusher(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.flush(ctx); err != nil {
+					log.Printf("flushing album views error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// flush - переносит накопленные счетчики из Redis в БД
+// Хэш дня сначала атомарно переименовывается в ключ сброса, уникальный для этого захода:
+// новые просмотры во время сброса не теряются, а один хэш забирает только один экземпляр сервиса
+func (s *ViewService) flush(ctx context.Context) error {
+	// Сначала дожимаем хэши, которые не удалось сбросить в прошлый раз
+	// Свежие ключи сброса не трогаем - их, возможно, еще записывает другой экземпляр
+	leftovers, err := s.redis.ScanKeys(ctx, flushingKeyPrefix+"*")
+	if err != nil {
+		return err
+	}
+	for _, key := range leftovers {
+		day, claimedAt, ok := parseFlushingKey(key)
+		if !ok {
+			// Чужой или испорченный ключ - удаляем, чтобы не спотыкаться о него каждый раз
+			log.Printf("skipping unexpected views key %s", key)
+			if err := s.redis.Delete(ctx, key); err != nil {
+				return err
+			}
+			continue
+		}
+		if time.Since(claimedAt) < flushingGracePeriod {
+			continue
+		}
+		if err := s.claimAndFlush(ctx, key, day); err != nil {
+			return err
+		}
+	}
+
+	keys, err := s.redis.ScanKeys(ctx, viewsKeyPrefix+"*")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		day, err := time.Parse(viewsDayLayout, strings.TrimPrefix(key, viewsKeyPrefix))
+		if err != nil {
+			log.Printf("skipping unexpected views key %s", key)
+			if err := s.redis.Delete(ctx, key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.claimAndFlush(ctx, key, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claimAndFlush - забирает хэш переименованием в новый ключ сброса и записывает его в БД
+// Если ключ уже забрал другой экземпляр сервиса, RENAME не найдет его - тогда пропускаем
+func (s *ViewService) claimAndFlush(ctx context.Context, key string, day time.Time) error {
+	flushingKey := newFlushingKey(day)
+	renamed, err := s.redis.Rename(ctx, key, flushingKey)
+	if err != nil {
+		return err
+	}
+	if !renamed {
+		return nil
+	}
+	return s.flushKey(ctx, flushingKey, day)
+}
+
+// flushKey - записывает хэш просмотров за день в БД и удаляет его
+func (s *ViewService) flushKey(ctx context.Context, key string, day time.Time) error {
+	values, err := s.redis.HGetAll(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int64, len(values))
+	for albumID, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		counts[albumID] = count
+	}
+
+	if err := s.repo.AddViews(day, counts); err != nil {
+		return err
+	}
+	return s.redis.Delete(ctx, key)
+}
+
+// newFlushingKey - ключ сброса: album:views-flushing:<день>:<время захвата, нс>:<случайный суффикс>
+func newFlushingKey(day time.Time) string {
+	b := make([]byte, 4)
+	rand.Read(b) // Никогда не возвращает ошибку
+	return flushingKeyPrefix + day.Format(viewsDayLayout) + ":" + strconv.FormatInt(time.Now().UnixNano(), 10) + ":" + hex.EncodeToString(b)
+}
+
+// parseFlushingKey - день и время захвата ключа сброса
+// У ключей старого формата (album:views-flushing:<день>) времени захвата нет - они считаются давно брошенными
+func parseFlushingKey(key string) (time.Time, time.Time, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, flushingKeyPrefix), ":")
+	day, err := time.Parse(viewsDayLayout, parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	switch len(parts) {
+	case 1:
+		return day, time.Time{}, true
+	case 3:
+		nanos, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		return day, time.Unix(0, nanos), true
+	}
+	return time.Time{}, time.Time{}, false
+}
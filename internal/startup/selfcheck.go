@@ -0,0 +1,176 @@
+// Пакет для проверки окружения при запуске сервиса
+package startup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/config"
+	"go-music-shop/pkg/redis"
+	"log"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// ExpectedSchemaVersion - версия схемы БД, с которой работает этот бинарник
+// (номер последней миграции в scripts/migrations)
+const ExpectedSchemaVersion = 26
+
+// Check - результат одной проверки
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Fatal  bool   `json:"fatal"` // Критичная ошибка - сервис не должен принимать запросы
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report - отчет о проверке окружения при запуске
+type Report struct {
+	Service       string            `json:"service"`
+	GoVersion     string            `json:"go_version"`
+	Dependencies  map[string]string `json:"dependencies"` // Версии БД, Redis и ключевых библиотек
+	SchemaVersion int               `json:"schema_version"`
+	Checks        []Check           `json:"checks"`
+}
+
+// HasFatal - есть ли в отчете критичные ошибки
+func (r *Report) HasFatal() bool {
+	for _, check := range r.Checks {
+		if !check.OK && check.Fatal {
+			return true
+		}
+	}
+	return false
+}
+
+// Run - проверяет конфигурацию, БД и Redis, пишет отчет в лог
+// Завершает процесс при критичных ошибках, если это не отключено в конфигурации
+func Run(service string, cfg *config.Config, db *sql.DB, redisClient *redis.RedisClient) *Report {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report := &Report{
+		Service:      service,
+		GoVersion:    runtime.Version(),
+		Dependencies: make(map[string]string),
+	}
+
+	report.checkConfig(cfg)
+	report.checkSchema(ctx, db)
+	report.checkClockSkew(ctx, db, time.Duration(cfg.Startup.MaxClockSkew)*time.Second)
+	report.checkRedis(ctx, redisClient)
+	report.collectModuleVersions()
+
+	// Пишем отчет одной JSON строкой, чтобы его было удобно искать в логах
+	if data, err := json.Marshal(report); err == nil {
+		log.Printf("startup report: %s", data)
+	}
+
+	if report.HasFatal() {
+		if cfg.Startup.IgnoreFailures {
+			log.Println("WARNING: startup checks failed, continuing because STARTUP_IGNORE_FAILURES is set")
+		} else {
+			log.Fatalf("startup checks failed, refusing to serve traffic (set STARTUP_IGNORE_FAILURES=true to override)")
+		}
+	}
+
+	return report
+}
+
+// add - добавляет результат проверки в отчет
+func (r *Report) add(name string, fatal bool, err error) {
+	check := Check{Name: name, OK: err == nil, Fatal: fatal}
+	if err != nil {
+		check.Detail = err.Error()
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// checkConfig - проверяет что обязательные настройки заданы и согласованы
+func (r *Report) checkConfig(cfg *config.Config) {
+	var err error
+	switch {
+	case cfg.DataBase.Host == "" || cfg.DataBase.Name == "" || cfg.DataBase.User == "":
+		err = fmt.Errorf("database host, name and user are required")
+	case cfg.Redis.Host == "":
+		err = fmt.Errorf("redis host is required")
+	case (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == ""):
+		err = fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	case cfg.CDN.PurgeURL != "" && cfg.CDN.PublicBaseURL == "":
+		err = fmt.Errorf("CDN_PUBLIC_BASE_URL is required when CDN purging is enabled")
+	}
+	r.add("config", true, err)
+}
+
+// checkSchema - сверяет версию схемы БД с ожидаемой
+func (r *Report) checkSchema(ctx context.Context, db *sql.DB) {
+	var serverVersion string
+	if err := db.QueryRowContext(ctx, `SHOW server_version`).Scan(&serverVersion); err == nil {
+		r.Dependencies["postgres"] = serverVersion
+	}
+
+	var version sql.NullInt64
+	err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		r.add("schema_version", true, fmt.Errorf("reading schema version error: %w", err))
+		return
+	}
+
+	r.SchemaVersion = int(version.Int64)
+	if r.SchemaVersion != ExpectedSchemaVersion {
+		r.add("schema_version", true, fmt.Errorf("schema version %d, binary expects %d", r.SchemaVersion, ExpectedSchemaVersion))
+		return
+	}
+	r.add("schema_version", true, nil)
+}
+
+// checkClockSkew - сравнивает локальное время с временем сервера БД
+// Сильное расхождение ломает TTL, created_at/updated_at и курсоры пагинации
+func (r *Report) checkClockSkew(ctx context.Context, db *sql.DB, maxSkew time.Duration) {
+	var dbNow time.Time
+	before := time.Now()
+	if err := db.QueryRowContext(ctx, `SELECT NOW()`).Scan(&dbNow); err != nil {
+		r.add("clock_skew", false, fmt.Errorf("reading database time error: %w", err))
+		return
+	}
+
+	// Учитываем время на запрос: сравниваем с серединой интервала
+	local := before.Add(time.Since(before) / 2)
+	skew := local.Sub(dbNow).Abs()
+	if skew > maxSkew {
+		r.add("clock_skew", true, fmt.Errorf("clock skew with database is %s (max %s)", skew.Round(time.Millisecond), maxSkew))
+		return
+	}
+	r.add("clock_skew", true, nil)
+}
+
+// checkRedis - проверяет что Redis отвечает
+func (r *Report) checkRedis(ctx context.Context, redisClient *redis.RedisClient) {
+	if err := redisClient.Ping(ctx); err != nil {
+		r.add("redis", true, err)
+		return
+	}
+
+	if version, err := redisClient.ServerVersion(ctx); err == nil {
+		r.Dependencies["redis"] = version
+	}
+	r.add("redis", true, nil)
+}
+
+// collectModuleVersions - добавляет в отчет версию сборки и ключевых библиотек
+func (r *Report) collectModuleVersions() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+
+	r.Dependencies["build"] = info.Main.Version
+	for _, dep := range info.Deps {
+		switch dep.Path {
+		case "github.com/gin-gonic/gin", "google.golang.org/grpc", "github.com/lib/pq", "github.com/redis/go-redis/v9":
+			r.Dependencies[dep.Path] = dep.Version
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Пакет для отправки операционных оповещений в чаты команды (Slack, Discord)
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/config"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Типы оповещений - по ним настраивается, в какие каналы они уходят
+const (
+	TypePoolSaturated = "pool_saturated" // Исчерпан пул подключений к PostgreSQL или Redis
+	TypeFXRatesStale  = "fx_rates_stale" // Курсы валют давно не обновлялись
+	TypeNoteMention   = "note_mention"   // Сотрудника упомянули (@имя) во внутренней заметке к альбому
+	TypeFlashSale     = "flash_sale"     // Распродажа началась или закончилась
+	TypeQualityDigest = "quality_digest" // Еженедельная сводка качества каталога
+)
+
+// Alert - одно оповещение
+type Alert struct {
+	Type    string // Тип оповещения (TypePoolSaturated, ...)
+	Key     string // Уточнение для ограничения частоты: например "postgres" и "redis" ограничиваются отдельно
+	Title   string
+	Message string
+}
+
+// Sink - канал доставки оповещений
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// Notifier - рассылает оповещения по каналам с учетом настроек типа и ограничения частоты
+// Ограничение частоты действует в пределах экземпляра сервиса
+type Notifier struct {
+	sinks    map[string]Sink     // Каналы по имени ("slack", "discord")
+	routes   map[string][]string // Тип оповещения -> имена каналов; для типов без настройки - все каналы
+	throttle time.Duration
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // Время последней отправки по типу и ключу
+}
+
+// NewNotifier - создает Notifier по конфигурации
+// Если ни один канал не настроен - оповещения только пишутся в лог
+func NewNotifier(cfg config.AlertConfig) *Notifier {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	n := &Notifier{
+		sinks:    make(map[string]Sink),
+		routes:   make(map[string][]string),
+		throttle: time.Duration(cfg.ThrottleSeconds) * time.Second,
+		lastSent: make(map[string]time.Time),
+	}
+	if cfg.SlackWebhookURL != "" {
+		n.sinks["slack"] = &SlackSink{webhookURL: cfg.SlackWebhookURL, client: client}
+	}
+	if cfg.DiscordWebhookURL != "" {
+		n.sinks["discord"] = &DiscordSink{webhookURL: cfg.DiscordWebhookURL, client: client}
+	}
+
+	// Маршруты задаются как "тип=канал", тип может повторяться: "pool_saturated=slack,pool_saturated=discord"
+	// Канал "none" отключает отправку оповещений этого типа
+	for _, route := range cfg.Routes {
+		alertType, sink, ok := strings.Cut(route, "=")
+		if !ok {
+			log.Printf("WARNING: invalid alert route %q, expected type=sink", route)
+			continue
+		}
+		n.routes[alertType] = append(n.routes[alertType], sink)
+	}
+	return n
+}
+
+// Notify - асинхронно отправляет оповещение в настроенные каналы
+// Повторы того же типа и ключа в пределах интервала ограничения пропускаются
+func (n *Notifier) Notify(alert Alert) {
+	if n == nil || !n.allow(alert) {
+		return
+	}
+
+	sinks := n.sinksFor(alert.Type)
+	if len(sinks) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for name, sink := range sinks {
+			if err := sink.Send(ctx, alert); err != nil {
+				log.Printf("sending %s alert to %s error: %v", alert.Type, name, err)
+			}
+		}
+	}()
+}
+
+// allow - проверяет ограничение частоты и запоминает время отправки
+func (n *Notifier) allow(alert Alert) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	key := alert.Type + "\x00" + alert.Key
+	if last, ok := n.lastSent[key]; ok && time.Since(last) < n.throttle {
+		return false
+	}
+	n.lastSent[key] = time.Now()
+	return true
+}
+
+// sinksFor - каналы для типа оповещения
+func (n *Notifier) sinksFor(alertType string) map[string]Sink {
+	names, ok := n.routes[alertType]
+	if !ok {
+		return n.sinks
+	}
+
+	sinks := make(map[string]Sink)
+	for _, name := range names {
+		if sink, ok := n.sinks[name]; ok {
+			sinks[name] = sink
+		}
+	}
+	return sinks
+}
+
+// SlackSink - отправляет оповещения через Slack Incoming Webhook
+type SlackSink struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// Send - отправляет оповещение в Slack
+func (s *SlackSink) Send(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, s.client, s.webhookURL, map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", alert.Title, alert.Message),
+	})
+}
+
+// DiscordSink - отправляет оповещения через Discord Webhook
+type DiscordSink struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// discordMaxContent - максимальная длина сообщения Discord
+const discordMaxContent = 2000
+
+// Send - отправляет оповещение в Discord
+func (s *DiscordSink) Send(ctx context.Context, alert Alert) error {
+	content := fmt.Sprintf("**%s**\n%s", alert.Title, alert.Message)
+	if runes := []rune(content); len(runes) > discordMaxContent {
+		content = string(runes[:discordMaxContent])
+	}
+	return postJSON(ctx, s.client, s.webhookURL, map[string]string{"content": content})
+}
+
+// postJSON - отправляет JSON на webhook
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding alert error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating alert request error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending alert error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Пакет auth - подписанные токены доступа (JWT, HS256)
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Роли пользователей
+const (
+	RoleCustomer = "customer"
+	RoleStaff    = "staff" // Сотрудник магазина: склад, наличие
+	RoleAdmin    = "admin"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// leeway - допустимое расхождение часов между сервисом, выдавшим токен, и проверяющим
+const leeway = 30 * time.Second
+
+// Claims - данные токена: кто пользователь и до какого времени токен действует
+type Claims struct {
+	Subject   string   `json:"sub"`   // ID пользователя
+	Roles     []string `json:"roles"` // RoleCustomer, RoleStaff, RoleAdmin
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// claimsKey - ключ данных токена в context.Context
+type claimsKey struct{}
+
+// NewContext - контекст с данными пользователя из токена
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext - данные пользователя из контекста (nil - запрос без токена)
+func FromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
+}
+
+// header - заголовок токена; поддерживаем только HS256, другие алгоритмы (и "none") отклоняем
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+var encoding = base64.RawURLEncoding
+
+// Issue - выдает токен для пользователя на ttl
+func Issue(secret []byte, subject string, roles []string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Subject:   subject,
+		Roles:     roles,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	return Sign(secret, claims)
+}
+
+// Sign - подписывает данные токена
+func Sign(secret []byte, claims Claims) (string, error) {
+	headerData, err := json.Marshal(header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsData, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encoding claims error: %w", err)
+	}
+
+	unsigned := encoding.EncodeToString(headerData) + "." + encoding.EncodeToString(claimsData)
+	return unsigned + "." + encoding.EncodeToString(signature(secret, unsigned)), nil
+}
+
+// Verify - проверяет подпись и срок действия токена и возвращает его данные
+func Verify(secret []byte, token string) (*Claims, error) {
+	headerPart, rest, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	claimsPart, signaturePart, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	var h header
+	if err := decodePart(headerPart, &h); err != nil || h.Alg != "HS256" {
+		return nil, ErrInvalidToken
+	}
+
+	sig, err := encoding.DecodeString(signaturePart)
+	if err != nil || !hmac.Equal(sig, signature(secret, headerPart+"."+claimsPart)) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodePart(claimsPart, &claims); err != nil || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	if claims.ExpiresAt == 0 || time.Now().Add(-leeway).Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	return &claims, nil
+}
+
+// decodePart - раскодирует часть токена (base64url JSON)
+func decodePart(part string, v any) error {
+	data, err := encoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// signature - HMAC-SHA256 от заголовка и данных токена
+func signature(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,262 @@
+// Пакет catalogdiff - сравнение каталогов двух окружений (staging и production)
+// по выгрузке NDJSON (/admin/albums/export) или снимку каталога (.ndjson.gz)
+package catalogdiff
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Способы сопоставления альбомов двух каталогов
+const (
+	KeyID          = "id"           // По id (окружения, заполненные из одного снимка)
+	KeyArtistTitle = "artist-title" // По исполнителю и названию (альбомы заведены в окружениях отдельно)
+)
+
+// Album - альбом из выгрузки: поля как есть, чтобы сравнивать и поля, о которых этот код не знает
+type Album map[string]json.RawMessage
+
+// str - строковое поле альбома
+func (a Album) str(field string) string {
+	var s string
+	json.Unmarshal(a[field], &s) // Нестроковое или отсутствующее поле - пустая строка
+	return s
+}
+
+// Entry - альбом, который есть только в одном из каталогов
+type Entry struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist"`
+}
+
+// FieldDiff - различие одного поля
+type FieldDiff struct {
+	Field string          `json:"field"`
+	Left  json.RawMessage `json:"left"`  // null - поля нет
+	Right json.RawMessage `json:"right"` // null - поля нет
+}
+
+// Change - альбом, который есть в обоих каталогах, но отличается
+type Change struct {
+	Entry
+	RightID string      `json:"right_id,omitempty"` // id справа, если он другой (сопоставление не по id)
+	Fields  []FieldDiff `json:"fields"`
+}
+
+// Diff - различия каталогов: чего не хватает справа, что есть только справа и что отличается
+type Diff struct {
+	Left      string   `json:"left"`
+	Right     string   `json:"right"`
+	Key       string   `json:"key"`
+	Missing   []Entry  `json:"missing"` // Есть слева, нет справа
+	Extra     []Entry  `json:"extra"`   // Есть справа, нет слева
+	Changed   []Change `json:"changed"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// Empty - каталоги совпадают
+func (d *Diff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// Options - настройки сравнения
+type Options struct {
+	Key    string   // KeyID или KeyArtistTitle
+	Ignore []string // Поля, которые не сравниваются (created_at, updated_at отличаются всегда)
+}
+
+// Compare - сравнивает два каталога
+func Compare(left, right []Album, opts Options) (*Diff, error) {
+	keyOf, err := keyFunc(opts.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	leftByKey, err := index(left, keyOf)
+	if err != nil {
+		return nil, fmt.Errorf("left catalog: %w", err)
+	}
+	rightByKey, err := index(right, keyOf)
+	if err != nil {
+		return nil, fmt.Errorf("right catalog: %w", err)
+	}
+
+	diff := &Diff{Key: opts.Key, Missing: []Entry{}, Extra: []Entry{}, Changed: []Change{}}
+	for _, key := range slices.Sorted(maps.Keys(leftByKey)) {
+		l := leftByKey[key]
+		r, ok := rightByKey[key]
+		if !ok {
+			diff.Missing = append(diff.Missing, entry(l))
+			continue
+		}
+
+		fields := compareFields(l, r, opts.Ignore)
+		if len(fields) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		change := Change{Entry: entry(l), Fields: fields}
+		if rightID := r.str("id"); rightID != change.ID {
+			change.RightID = rightID
+		}
+		diff.Changed = append(diff.Changed, change)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(rightByKey)) {
+		if _, ok := leftByKey[key]; !ok {
+			diff.Extra = append(diff.Extra, entry(rightByKey[key]))
+		}
+	}
+	return diff, nil
+}
+
+// keyFunc - ключ сопоставления альбомов
+func keyFunc(key string) (func(Album) string, error) {
+	switch key {
+	case KeyID, "":
+		return func(a Album) string { return a.str("id") }, nil
+	case KeyArtistTitle:
+		return func(a Album) string {
+			return strings.ToLower(strings.TrimSpace(a.str("artist"))) + "\x00" + strings.ToLower(strings.TrimSpace(a.str("title")))
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown key %q (use %s or %s)", key, KeyID, KeyArtistTitle)
+}
+
+// index - альбомы по ключу; одинаковые ключи не дают однозначно сопоставить альбомы
+func index(albums []Album, keyOf func(Album) string) (map[string]Album, error) {
+	byKey := make(map[string]Album, len(albums))
+	for _, album := range albums {
+		key := keyOf(album)
+		if _, ok := byKey[key]; ok {
+			return nil, fmt.Errorf("duplicate key %q (album %s)", strings.ReplaceAll(key, "\x00", " - "), album.str("id"))
+		}
+		byKey[key] = album
+	}
+	return byKey, nil
+}
+
+// compareFields - поля, значения которых различаются
+func compareFields(left, right Album, ignore []string) []FieldDiff {
+	fields := make(map[string]bool)
+	for field := range left {
+		fields[field] = true
+	}
+	for field := range right {
+		fields[field] = true
+	}
+
+	var diffs []FieldDiff
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		if slices.Contains(ignore, field) {
+			continue
+		}
+		l, r := left[field], right[field]
+		if !sameJSON(l, r) {
+			diffs = append(diffs, FieldDiff{Field: field, Left: orNull(l), Right: orNull(r)})
+		}
+	}
+	return diffs
+}
+
+// sameJSON - одинаковые значения без учета форматирования (отсутствующее поле равно null)
+func sameJSON(a, b json.RawMessage) bool {
+	var va, vb any
+	json.Unmarshal(orNull(a), &va)
+	json.Unmarshal(orNull(b), &vb)
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// orNull - отсутствующее поле как null
+func orNull(v json.RawMessage) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("null")
+	}
+	return v
+}
+
+// entry - краткое описание альбома
+func entry(a Album) Entry {
+	return Entry{ID: a.str("id"), Title: a.str("title"), Artist: a.str("artist")}
+}
+
+// Load - читает каталог из окружения (http(s)://адрес - его выгрузка /admin/albums/export
+// с токеном администратора token) или из файла выгрузки/снимка (.ndjson или .ndjson.gz)
+func Load(ctx context.Context, client *http.Client, source, token string) ([]Album, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return loadURL(ctx, client, source, token)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return read(file)
+}
+
+// loadURL - скачивает выгрузку каталога окружения
+func loadURL(ctx context.Context, client *http.Client, baseURL, token string) ([]Album, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/admin/albums/export", nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching catalog from %s error: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching catalog from %s: unexpected status %s", baseURL, resp.Status)
+	}
+	return read(resp.Body)
+}
+
+// read - читает альбомы из NDJSON, сжатого gzip или нет
+func read(r io.Reader) ([]Album, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		return decode(gz)
+	}
+	return decode(br)
+}
+
+// decode - альбомы из NDJSON
+func decode(r io.Reader) ([]Album, error) {
+	var albums []Album
+	decoder := json.NewDecoder(r)
+	for {
+		var album Album
+		err := decoder.Decode(&album)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading album %d error: %w", len(albums)+1, err)
+		}
+		albums = append(albums, album)
+	}
+	return albums, nil
+}
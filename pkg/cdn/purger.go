@@ -0,0 +1,107 @@
+// Пакет для работы с CDN (Fastly/Cloudflare)
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-music-shop/internal/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Purger - интерфейс очистки кэша CDN по списку путей или тегов
+// Путь очищает только одну копию ответа, а тег - все копии, помеченные им (с любыми параметрами запроса)
+type Purger interface {
+	Purge(ctx context.Context, paths ...string) error
+	PurgeTags(ctx context.Context, tags ...string) error
+}
+
+// NewPurger - создает Purger по конфигурации
+// Если URL API не задан - возвращает NoopPurger (CDN не используется)
+func NewPurger(cfg *config.Config) Purger {
+	if cfg.CDN.PurgeURL == "" {
+		return NoopPurger{}
+	}
+
+	return &HTTPPurger{
+		purgeURL: cfg.CDN.PurgeURL,
+		apiToken: cfg.CDN.APIToken,
+		baseURL:  strings.TrimRight(cfg.CDN.PublicBaseURL, "/"),
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NoopPurger - ничего не делает, используется когда CDN не настроен
+type NoopPurger struct{}
+
+// Purge - ничего не делает
+func (NoopPurger) Purge(ctx context.Context, paths ...string) error {
+	return nil
+}
+
+// PurgeTags - ничего не делает
+func (NoopPurger) PurgeTags(ctx context.Context, tags ...string) error {
+	return nil
+}
+
+// HTTPPurger - очищает кэш через HTTP API CDN
+// Формат запроса совместим с Cloudflare: POST {"files": ["https://.../albums/1", ...]} или {"tags": [...]}
+type HTTPPurger struct {
+	purgeURL string
+	apiToken string
+	baseURL  string
+	client   *http.Client
+}
+
+// Purge - отправляет запрос на очистку кэша для переданных путей
+func (p *HTTPPurger) Purge(ctx context.Context, paths ...string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// CDN ожидает полные URL, поэтому добавляем публичный адрес магазина
+	files := make([]string, len(paths))
+	for i, path := range paths {
+		files[i] = p.baseURL + path
+	}
+	return p.send(ctx, map[string][]string{"files": files})
+}
+
+// PurgeTags - отправляет запрос на очистку кэша всех ответов с переданными тегами
+func (p *HTTPPurger) PurgeTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	return p.send(ctx, map[string][]string{"tags": tags})
+}
+
+// send - отправляет запрос на очистку в API CDN
+func (p *HTTPPurger) send(ctx context.Context, request map[string][]string) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("encoding purge request error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.purgeURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating purge request error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("purging CDN cache error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("CDN purge returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package cdn
+
+import "net/url"
+
+// CatalogTag - тег всех списков каталога (главная, фильтры, поиск, исполнители, подсказки)
+const CatalogTag = "albums"
+
+// AlbumTag - тег страницы альбома и его подресурсов (/albums/:id, /albums/:id/variants, ?include=tracks и т.п.)
+func AlbumTag(id string) string {
+	return "album-" + url.PathEscape(id)
+}
+
+// ArtistTag - тег страницы альбомов исполнителя
+// PathEscape кодирует пробелы и запятые, которыми CDN разделяет теги в заголовке
+func ArtistTag(artist string) string {
+	return "artist-" + url.PathEscape(artist)
+}
@@ -0,0 +1,101 @@
+// Пакет для поиска изданий в базе Discogs
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchURL - поиск по базе Discogs (нужен токен)
+const searchURL = "https://api.discogs.com/database/search"
+
+// userAgent - Discogs требует осмысленный User-Agent и блокирует запросы без него
+const userAgent = "go-music-shop/1.0"
+
+// ErrRateLimited - Discogs ответил 429: лимит запросов исчерпан, продолжать стоит позже
+var ErrRateLimited = errors.New("discogs rate limit exceeded")
+
+// Release - издание из Discogs
+type Release struct {
+	ID     int64
+	Title  string
+	Artist string
+	Year   int
+	Genre  string
+}
+
+// Client - клиент API Discogs
+type Client struct {
+	client *http.Client
+	token  string
+}
+
+// NewClient - создает клиента с персональным токеном Discogs
+func NewClient(token string) *Client {
+	return &Client{client: &http.Client{Timeout: 10 * time.Second}, token: token}
+}
+
+// searchResponse - формат ответа поиска: {"results": [{"id": 1, "title": "Artist - Title", ...}]}
+type searchResponse struct {
+	Results []struct {
+		ID    int64    `json:"id"`
+		Title string   `json:"title"`
+		Year  string   `json:"year"`
+		Genre []string `json:"genre"`
+		Style []string `json:"style"`
+	} `json:"results"`
+}
+
+// LookupBarcode - издание по штрихкоду; nil без ошибки, если Discogs его не знает
+func (c *Client) LookupBarcode(ctx context.Context, barcode string) (*Release, error) {
+	query := url.Values{"barcode": {barcode}, "type": {"release"}, "per_page": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating discogs request error: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Authorization", "Discogs token="+c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discogs search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, ErrRateLimited
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("discogs returned status %d", resp.StatusCode)
+	}
+
+	var body searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding discogs response error: %w", err)
+	}
+	if len(body.Results) == 0 {
+		return nil, nil
+	}
+
+	result := body.Results[0]
+	release := &Release{ID: result.ID, Title: result.Title}
+	// Название в поиске - "Исполнитель - Альбом"
+	if artist, title, ok := strings.Cut(result.Title, " - "); ok {
+		release.Artist, release.Title = strings.TrimSpace(artist), strings.TrimSpace(title)
+	}
+	release.Year, _ = strconv.Atoi(result.Year)
+	switch {
+	case len(result.Style) > 0: // Стиль точнее жанра: "Hard Bop" вместо "Jazz"
+		release.Genre = result.Style[0]
+	case len(result.Genre) > 0:
+		release.Genre = result.Genre[0]
+	}
+	return release, nil
+}
@@ -0,0 +1,151 @@
+// Пакет для получения курсов валют от внешних провайдеров
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"go-music-shop/internal/config"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Provider - источник курсов валют
+type Provider interface {
+	// Rates - сколько единиц каждой валюты стоит одна единица base
+	Rates(ctx context.Context, base string) (map[string]float64, error)
+}
+
+// NewProvider - создает провайдера по конфигурации
+// Если провайдер не задан - возвращает nil (конвертация валют отключена)
+func NewProvider(cfg config.FXConfig) (Provider, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "ecb":
+		return &ECBProvider{client: client}, nil
+	case "exchangeratehost":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("FX_API_KEY is required for exchangerate.host")
+		}
+		return &ExchangeRateHostProvider{client: client, apiKey: cfg.APIKey}, nil
+	}
+	return nil, fmt.Errorf("unknown FX provider %q", cfg.Provider)
+}
+
+// ecbDailyURL - ежедневные курсы Европейского центрального банка (относительно EUR)
+const ecbDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBProvider - курсы ЕЦБ: бесплатные, без ключа, обновляются раз в рабочий день
+type ECBProvider struct {
+	client *http.Client
+}
+
+// ecbEnvelope - формат ответа ЕЦБ: <Cube><Cube time="..."><Cube currency="USD" rate="1.08"/>...
+type ecbEnvelope struct {
+	Rates []struct {
+		Currency string  `xml:"currency,attr"`
+		Rate     float64 `xml:"rate,attr"`
+	} `xml:"Cube>Cube>Cube"`
+}
+
+// Rates - загружает курсы ЕЦБ и пересчитывает их относительно base
+func (p *ECBProvider) Rates(ctx context.Context, base string) (map[string]float64, error) {
+	resp, err := get(ctx, p.client, ecbDailyURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("decoding ECB rates error: %w", err)
+	}
+
+	eurRates := map[string]float64{"EUR": 1}
+	for _, r := range envelope.Rates {
+		eurRates[r.Currency] = r.Rate
+	}
+
+	baseRate, ok := eurRates[base]
+	if !ok || baseRate == 0 {
+		return nil, fmt.Errorf("ECB has no rate for base currency %s", base)
+	}
+
+	rates := make(map[string]float64, len(eurRates))
+	for currency, rate := range eurRates {
+		rates[currency] = rate / baseRate
+	}
+	return rates, nil
+}
+
+// exchangeRateHostURL - API exchangerate.host (нужен ключ доступа)
+const exchangeRateHostURL = "https://api.exchangerate.host/live"
+
+// ExchangeRateHostProvider - курсы exchangerate.host: больше валют, обновляются чаще
+type ExchangeRateHostProvider struct {
+	client *http.Client
+	apiKey string
+}
+
+// exchangeRateHostResponse - формат ответа: {"success": true, "quotes": {"USDEUR": 0.92}}
+type exchangeRateHostResponse struct {
+	Success bool               `json:"success"`
+	Source  string             `json:"source"`
+	Quotes  map[string]float64 `json:"quotes"`
+	Error   *struct {
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+// Rates - загружает курсы относительно base
+func (p *ExchangeRateHostProvider) Rates(ctx context.Context, base string) (map[string]float64, error) {
+	query := url.Values{"access_key": {p.apiKey}, "source": {base}}
+	resp, err := get(ctx, p.client, exchangeRateHostURL+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body exchangeRateHostResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding exchangerate.host rates error: %w", err)
+	}
+	if !body.Success {
+		if body.Error != nil {
+			return nil, fmt.Errorf("exchangerate.host error: %s", body.Error.Info)
+		}
+		return nil, fmt.Errorf("exchangerate.host request failed")
+	}
+
+	// Котировки приходят парами "USDEUR" - отрезаем базовую валюту
+	rates := map[string]float64{base: 1}
+	for pair, rate := range body.Quotes {
+		if len(pair) == 2*len(base) && pair[:len(base)] == base {
+			rates[pair[len(base):]] = rate
+		}
+	}
+	return rates, nil
+}
+
+// get - выполняет GET запрос и проверяет статус ответа
+func get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating FX request error: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching FX rates error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("FX provider returned status %d", resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -4,7 +4,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.9
 // 	protoc        v6.32.0
 // source: catalog.proto
 
@@ -17,6 +17,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -27,21 +28,20 @@ const (
 )
 
 type GetAlbumsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`   // Ограничение количества результатов
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Смещение для пагинации
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`      // Порядок: "price,-year" (минус - по убыванию); требует limit
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`  // Курсор следующей страницы из next_cursor (вместо offset, только без sort)
 	unknownFields protoimpl.UnknownFields
-
-	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`   // Ограничение количества результатов
-	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Смещение для пагинации
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumsRequest) Reset() {
 	*x = GetAlbumsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumsRequest) String() string {
@@ -52,7 +52,7 @@ func (*GetAlbumsRequest) ProtoMessage() {}
 
 func (x *GetAlbumsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -81,23 +81,35 @@ func (x *GetAlbumsRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetAlbumsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetAlbumsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // Сообщение для ответа со списком альбомов
 type GetAlbumsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Albums        []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"`                            // Список альбомов
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Общее количество альбомов
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`  // Курсор следующей страницы; пусто - страниц больше нет
 	unknownFields protoimpl.UnknownFields
-
-	Albums     []*Album `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"`                            // Список альбомов
-	TotalCount int32    `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Общее количество альбомов
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumsResponse) Reset() {
 	*x = GetAlbumsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumsResponse) String() string {
@@ -108,7 +120,7 @@ func (*GetAlbumsResponse) ProtoMessage() {}
 
 func (x *GetAlbumsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -137,22 +149,27 @@ func (x *GetAlbumsResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *GetAlbumsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // Сообщение для запроса альбома по ID
 type GetAlbumByIDRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                             // ID альбома
+	IncludeTracks bool                   `protobuf:"varint,2,opt,name=include_tracks,json=includeTracks,proto3" json:"include_tracks,omitempty"` // Добавить к альбому список треков
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID альбома
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumByIDRequest) Reset() {
 	*x = GetAlbumByIDRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumByIDRequest) String() string {
@@ -163,7 +180,7 @@ func (*GetAlbumByIDRequest) ProtoMessage() {}
 
 func (x *GetAlbumByIDRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -185,22 +202,26 @@ func (x *GetAlbumByIDRequest) GetId() string {
 	return ""
 }
 
+func (x *GetAlbumByIDRequest) GetIncludeTracks() bool {
+	if x != nil {
+		return x.IncludeTracks
+	}
+	return false
+}
+
 // Сообщение для ответа с одним альбомом
 type GetAlbumByIDResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Найденный альбом
 	unknownFields protoimpl.UnknownFields
-
-	Album *Album `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Найденный альбом
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumByIDResponse) Reset() {
 	*x = GetAlbumByIDResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumByIDResponse) String() string {
@@ -211,7 +232,7 @@ func (*GetAlbumByIDResponse) ProtoMessage() {}
 
 func (x *GetAlbumByIDResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -235,26 +256,27 @@ func (x *GetAlbumByIDResponse) GetAlbum() *Album {
 
 // Сообщение для запроса создания альбома
 type CreateAlbumRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Title     string  `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                     // Название альбома
-	Artist    string  `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`                   // Исполнитель
-	Price     float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                   // Цена
-	Year      int32   `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`                      // Год выпуска
-	Genre     string  `protobuf:"bytes,5,opt,name=genre,proto3" json:"genre,omitempty"`                     // Жанр
-	Condition string  `protobuf:"bytes,6,opt,name=condition,proto3" json:"condition,omitempty"`             // Состояние (mint, very good, good, fair, poor)
-	InStock   bool    `protobuf:"varint,7,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"` // В наличии
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Title              string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                                                        // Название альбома
+	Artist             string                 `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`                                                      // Исполнитель
+	Price              float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                                                      // Цена
+	Year               int32                  `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`                                                         // Год выпуска
+	Genre              string                 `protobuf:"bytes,5,opt,name=genre,proto3" json:"genre,omitempty"`                                                        // Жанр
+	Condition          string                 `protobuf:"bytes,6,opt,name=condition,proto3" json:"condition,omitempty"`                                                // Состояние (mint, very good, good, fair, poor)
+	InStock            bool                   `protobuf:"varint,7,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`                                    // В наличии (если не задан stock_quantity: true - 1 экземпляр)
+	ConfirmPriceChange bool                   `protobuf:"varint,8,opt,name=confirm_price_change,json=confirmPriceChange,proto3" json:"confirm_price_change,omitempty"` // Подтверждение подозрительной цены (иначе FAILED_PRECONDITION)
+	StockQuantity      *int32                 `protobuf:"varint,9,opt,name=stock_quantity,json=stockQuantity,proto3,oneof" json:"stock_quantity,omitempty"`            // Экземпляров на складе
+	CatalogNumber      string                 `protobuf:"bytes,10,opt,name=catalog_number,json=catalogNumber,proto3" json:"catalog_number,omitempty"`                  // Каталожный номер издания на лейбле
+	Description        string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`                                           // Описание для страницы товара (markdown, безопасный HTML)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CreateAlbumRequest) Reset() {
 	*x = CreateAlbumRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateAlbumRequest) String() string {
@@ -265,7 +287,7 @@ func (*CreateAlbumRequest) ProtoMessage() {}
 
 func (x *CreateAlbumRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -329,22 +351,47 @@ func (x *CreateAlbumRequest) GetInStock() bool {
 	return false
 }
 
+func (x *CreateAlbumRequest) GetConfirmPriceChange() bool {
+	if x != nil {
+		return x.ConfirmPriceChange
+	}
+	return false
+}
+
+func (x *CreateAlbumRequest) GetStockQuantity() int32 {
+	if x != nil && x.StockQuantity != nil {
+		return *x.StockQuantity
+	}
+	return 0
+}
+
+func (x *CreateAlbumRequest) GetCatalogNumber() string {
+	if x != nil {
+		return x.CatalogNumber
+	}
+	return ""
+}
+
+func (x *CreateAlbumRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 // Сообщение для ответа после создания альбома
 type CreateAlbumResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Созданный альбом
 	unknownFields protoimpl.UnknownFields
-
-	Album *Album `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Созданный альбом
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateAlbumResponse) Reset() {
 	*x = CreateAlbumResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateAlbumResponse) String() string {
@@ -355,7 +402,7 @@ func (*CreateAlbumResponse) ProtoMessage() {}
 
 func (x *CreateAlbumResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -379,27 +426,28 @@ func (x *CreateAlbumResponse) GetAlbum() *Album {
 
 // Сообщение для запроса обновления альбома
 type UpdateAlbumRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id        string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                           // ID альбома
-	Title     string  `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                     // Новое название
-	Artist    string  `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`                   // Новый исполнитель
-	Price     float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                   // Новая цена
-	Year      int32   `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`                      // Новый год
-	Genre     string  `protobuf:"bytes,6,opt,name=genre,proto3" json:"genre,omitempty"`                     // Новый жанр
-	Condition string  `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`             // Новое состояние
-	InStock   bool    `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"` // Новый статус наличия
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                              // ID альбома
+	Title              string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                                        // Новое название
+	Artist             string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`                                                      // Новый исполнитель
+	Price              float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                                                      // Новая цена
+	Year               int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`                                                         // Новый год
+	Genre              string                 `protobuf:"bytes,6,opt,name=genre,proto3" json:"genre,omitempty"`                                                        // Новый жанр
+	Condition          string                 `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`                                                // Новое состояние
+	InStock            bool                   `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`                                    // Новый статус наличия (если не задан stock_quantity: true сохраняет текущее количество)
+	ConfirmPriceChange bool                   `protobuf:"varint,9,opt,name=confirm_price_change,json=confirmPriceChange,proto3" json:"confirm_price_change,omitempty"` // Подтверждение подозрительного изменения цены (иначе FAILED_PRECONDITION)
+	StockQuantity      *int32                 `protobuf:"varint,10,opt,name=stock_quantity,json=stockQuantity,proto3,oneof" json:"stock_quantity,omitempty"`           // Новое количество на складе
+	CatalogNumber      *string                `protobuf:"bytes,11,opt,name=catalog_number,json=catalogNumber,proto3,oneof" json:"catalog_number,omitempty"`            // Новый каталожный номер (не задан - не меняется)
+	Description        *string                `protobuf:"bytes,12,opt,name=description,proto3,oneof" json:"description,omitempty"`                                     // Новое описание (не задано - не меняется)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *UpdateAlbumRequest) Reset() {
 	*x = UpdateAlbumRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *UpdateAlbumRequest) String() string {
@@ -410,7 +458,7 @@ func (*UpdateAlbumRequest) ProtoMessage() {}
 
 func (x *UpdateAlbumRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -481,22 +529,47 @@ func (x *UpdateAlbumRequest) GetInStock() bool {
 	return false
 }
 
+func (x *UpdateAlbumRequest) GetConfirmPriceChange() bool {
+	if x != nil {
+		return x.ConfirmPriceChange
+	}
+	return false
+}
+
+func (x *UpdateAlbumRequest) GetStockQuantity() int32 {
+	if x != nil && x.StockQuantity != nil {
+		return *x.StockQuantity
+	}
+	return 0
+}
+
+func (x *UpdateAlbumRequest) GetCatalogNumber() string {
+	if x != nil && x.CatalogNumber != nil {
+		return *x.CatalogNumber
+	}
+	return ""
+}
+
+func (x *UpdateAlbumRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
 // Сообщение для ответа после обновления альбома
 type UpdateAlbumResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Album         *Album                 `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Обновленный альбом
 	unknownFields protoimpl.UnknownFields
-
-	Album *Album `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"` // Обновленный альбом
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateAlbumResponse) Reset() {
 	*x = UpdateAlbumResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *UpdateAlbumResponse) String() string {
@@ -507,7 +580,7 @@ func (*UpdateAlbumResponse) ProtoMessage() {}
 
 func (x *UpdateAlbumResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -531,20 +604,17 @@ func (x *UpdateAlbumResponse) GetAlbum() *Album {
 
 // Сообщение для запроса удаления альбома
 type DeleteAlbumRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID альбома для удаления
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID альбома для удаления
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteAlbumRequest) Reset() {
 	*x = DeleteAlbumRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *DeleteAlbumRequest) String() string {
@@ -555,7 +625,7 @@ func (*DeleteAlbumRequest) ProtoMessage() {}
 
 func (x *DeleteAlbumRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -579,21 +649,18 @@ func (x *DeleteAlbumRequest) GetId() string {
 
 // Сообщение для ответа после удаления альбома
 type DeleteAlbumResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Флаг успешного удаления
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Сообщение
 	unknownFields protoimpl.UnknownFields
-
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Флаг успешного удаления
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Сообщение
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteAlbumResponse) Reset() {
 	*x = DeleteAlbumResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *DeleteAlbumResponse) String() string {
@@ -604,7 +671,7 @@ func (*DeleteAlbumResponse) ProtoMessage() {}
 
 func (x *DeleteAlbumResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -635,21 +702,18 @@ func (x *DeleteAlbumResponse) GetMessage() string {
 
 // Сообщение для поиска альбомов по исполнителю
 type SearchAlbumsByArtistRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artist        string                 `protobuf:"bytes,1,opt,name=artist,proto3" json:"artist,omitempty"` // Имя исполнителя
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // Ограничение количества
 	unknownFields protoimpl.UnknownFields
-
-	Artist string `protobuf:"bytes,1,opt,name=artist,proto3" json:"artist,omitempty"` // Имя исполнителя
-	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // Ограничение количества
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchAlbumsByArtistRequest) Reset() {
 	*x = SearchAlbumsByArtistRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *SearchAlbumsByArtistRequest) String() string {
@@ -660,7 +724,7 @@ func (*SearchAlbumsByArtistRequest) ProtoMessage() {}
 
 func (x *SearchAlbumsByArtistRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -691,20 +755,17 @@ func (x *SearchAlbumsByArtistRequest) GetLimit() int32 {
 
 // Сообщение для ответа с альбомами исполнителя
 type SearchAlbumsByArtistResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Albums        []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Список альбомов
 	unknownFields protoimpl.UnknownFields
-
-	Albums []*Album `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Список альбомов
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchAlbumsByArtistResponse) Reset() {
 	*x = SearchAlbumsByArtistResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *SearchAlbumsByArtistResponse) String() string {
@@ -715,7 +776,7 @@ func (*SearchAlbumsByArtistResponse) ProtoMessage() {}
 
 func (x *SearchAlbumsByArtistResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -739,20 +800,17 @@ func (x *SearchAlbumsByArtistResponse) GetAlbums() []*Album {
 
 // Сообщение для запроса альбомов в наличии
 type GetAlbumsInStockRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // Ограничение количества
 	unknownFields protoimpl.UnknownFields
-
-	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // Ограничение количества
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumsInStockRequest) Reset() {
 	*x = GetAlbumsInStockRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumsInStockRequest) String() string {
@@ -763,7 +821,7 @@ func (*GetAlbumsInStockRequest) ProtoMessage() {}
 
 func (x *GetAlbumsInStockRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -787,20 +845,17 @@ func (x *GetAlbumsInStockRequest) GetLimit() int32 {
 
 // Сообщение для ответа с альбомами в наличии
 type GetAlbumsInStockResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Albums        []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Список альбомов в наличии
 	unknownFields protoimpl.UnknownFields
-
-	Albums []*Album `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Список альбомов в наличии
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAlbumsInStockResponse) Reset() {
 	*x = GetAlbumsInStockResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAlbumsInStockResponse) String() string {
@@ -811,7 +866,7 @@ func (*GetAlbumsInStockResponse) ProtoMessage() {}
 
 func (x *GetAlbumsInStockResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -835,29 +890,34 @@ func (x *GetAlbumsInStockResponse) GetAlbums() []*Album {
 
 // Основное сообщение Альбом
 type Album struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                               // Уникальный идентификатор
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                         // Название альбома
+	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`                                       // Исполнитель
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                                       // Цена
+	Year          int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`                                          // Год выпуска
+	Genre         string                 `protobuf:"bytes,6,opt,name=genre,proto3" json:"genre,omitempty"`                                         // Жанр
+	Condition     string                 `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`                                 // Состояние пластинки
+	InStock       bool                   `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`                     // В наличии (stock_quantity > 0)
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                // Дата создания (строка для простоты)
+	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`               // Дата обновления
+	StockQuantity int32                  `protobuf:"varint,11,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`  // Экземпляров на складе
+	AverageRating float64                `protobuf:"fixed64,12,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"` // Средняя оценка покупателей (0 - отзывов нет)
+	ReviewCount   int32                  `protobuf:"varint,13,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`        // Число отзывов
+	LabelId       string                 `protobuf:"bytes,14,opt,name=label_id,json=labelId,proto3" json:"label_id,omitempty"`                     // Лейбл (пусто - не назначен)
+	CatalogNumber string                 `protobuf:"bytes,15,opt,name=catalog_number,json=catalogNumber,proto3" json:"catalog_number,omitempty"`   // Каталожный номер издания на лейбле
+	Tracks        []*Track               `protobuf:"bytes,16,rep,name=tracks,proto3" json:"tracks,omitempty"`                                      // Треки по порядку (только если запрошены: include_tracks, ?include=tracks)
+	Description   string                 `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`                            // Описание для страницы товара (markdown, безопасный HTML)
+	CoverUrl      string                 `protobuf:"bytes,18,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`                  // Адрес обложки (пусто - не загружена)
 	unknownFields protoimpl.UnknownFields
-
-	Id        string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                 // Уникальный идентификатор
-	Title     string  `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                           // Название альбома
-	Artist    string  `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`                         // Исполнитель
-	Price     float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                         // Цена
-	Year      int32   `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`                            // Год выпуска
-	Genre     string  `protobuf:"bytes,6,opt,name=genre,proto3" json:"genre,omitempty"`                           // Жанр
-	Condition string  `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`                   // Состояние пластинки
-	InStock   bool    `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`       // В наличии
-	CreatedAt string  `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`  // Дата создания (строка для простоты)
-	UpdatedAt string  `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Дата обновления
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Album) Reset() {
 	*x = Album{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_catalog_proto_msgTypes[14]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Album) String() string {
@@ -868,7 +928,7 @@ func (*Album) ProtoMessage() {}
 
 func (x *Album) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_proto_msgTypes[14]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -953,154 +1013,251 @@ func (x *Album) GetUpdatedAt() string {
 	return ""
 }
 
-var File_catalog_proto protoreflect.FileDescriptor
+func (x *Album) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *Album) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Album) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *Album) GetLabelId() string {
+	if x != nil {
+		return x.LabelId
+	}
+	return ""
+}
+
+func (x *Album) GetCatalogNumber() string {
+	if x != nil {
+		return x.CatalogNumber
+	}
+	return ""
+}
+
+func (x *Album) GetTracks() []*Track {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *Album) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
 
-var file_catalog_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x22, 0x40, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41,
-	0x6c, 0x62, 0x75, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x5c, 0x0a, 0x11, 0x47, 0x65,
-	0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x26, 0x0a, 0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52,
-	0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41,
-	0x6c, 0x62, 0x75, 0x6d, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x3c, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x42, 0x79, 0x49, 0x44, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
-	0x2e, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x22, 0xbb, 0x01,
-	0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72,
-	0x74, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05,
-	0x67, 0x65, 0x6e, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x65, 0x6e,
-	0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x22, 0x3b, 0x0a, 0x13, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x6c, 0x62, 0x75,
-	0x6d, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x22, 0xcb, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
-	0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x65, 0x6e, 0x72, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69,
-	0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
-	0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x22, 0x3b, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
-	0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63,
-	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x05, 0x61, 0x6c,
-	0x62, 0x75, 0x6d, 0x22, 0x24, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6c, 0x62,
-	0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x49, 0x0a, 0x13, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x4b, 0x0a, 0x1b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
-	0x62, 0x75, 0x6d, 0x73, 0x42, 0x79, 0x41, 0x72, 0x74, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x22, 0x46, 0x0a, 0x1c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x73, 0x42, 0x79, 0x41, 0x72, 0x74, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x26, 0x0a, 0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x6c, 0x62, 0x75,
-	0x6d, 0x52, 0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x22, 0x2f, 0x0a, 0x17, 0x47, 0x65, 0x74,
-	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x49, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x42, 0x0a, 0x18, 0x47, 0x65,
-	0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x49, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
-	0x2e, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x06, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x22, 0xfc,
-	0x01, 0x0a, 0x05, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x79, 0x65, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72,
-	0x12, 0x14, 0x0a, 0x05, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d,
-	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x32, 0xbd, 0x04,
-	0x0a, 0x0e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x12, 0x19, 0x2e,
-	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c,
-	0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x42, 0x79, 0x49, 0x44, 0x12, 0x1c, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x47,
-	0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74,
-	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x48, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x12, 0x1b, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
-	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c,
-	0x62, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x1b, 0x2e, 0x63, 0x61, 0x74,
-	0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
-	0x67, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
-	0x6c, 0x62, 0x75, 0x6d, 0x12, 0x1b, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x63, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x42,
-	0x79, 0x41, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x24, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
-	0x67, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x42, 0x79,
-	0x41, 0x72, 0x74, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
-	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
-	0x62, 0x75, 0x6d, 0x73, 0x42, 0x79, 0x41, 0x72, 0x74, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d,
-	0x73, 0x49, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x20, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c,
-	0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x49, 0x6e, 0x53, 0x74,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x63, 0x61, 0x74,
-	0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x73, 0x49, 0x6e,
-	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x21, 0x5a,
-	0x1f, 0x67, 0x6f, 0x2d, 0x6d, 0x75, 0x73, 0x69, 0x63, 0x2d, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Album) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
 }
 
+// Трек альбома
+type Track struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position        int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // Порядковый номер на альбоме, с 1
+	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // 0 - длительность неизвестна
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Track) Reset() {
+	*x = Track{}
+	mi := &file_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Track) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Track) ProtoMessage() {}
+
+func (x *Track) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Track.ProtoReflect.Descriptor instead.
+func (*Track) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Track) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Track) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Track) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Track) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+var File_catalog_proto protoreflect.FileDescriptor
+
+const file_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\rcatalog.proto\x12\acatalog\"l\n" +
+	"\x10GetAlbumsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"}\n" +
+	"\x11GetAlbumsResponse\x12&\n" +
+	"\x06albums\x18\x01 \x03(\v2\x0e.catalog.AlbumR\x06albums\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"L\n" +
+	"\x13GetAlbumByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0einclude_tracks\x18\x02 \x01(\bR\rincludeTracks\"<\n" +
+	"\x14GetAlbumByIDResponse\x12$\n" +
+	"\x05album\x18\x01 \x01(\v2\x0e.catalog.AlbumR\x05album\"\xf5\x02\n" +
+	"\x12CreateAlbumRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06artist\x18\x02 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04year\x18\x04 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05genre\x18\x05 \x01(\tR\x05genre\x12\x1c\n" +
+	"\tcondition\x18\x06 \x01(\tR\tcondition\x12\x19\n" +
+	"\bin_stock\x18\a \x01(\bR\ainStock\x120\n" +
+	"\x14confirm_price_change\x18\b \x01(\bR\x12confirmPriceChange\x12*\n" +
+	"\x0estock_quantity\x18\t \x01(\x05H\x00R\rstockQuantity\x88\x01\x01\x12%\n" +
+	"\x0ecatalog_number\x18\n" +
+	" \x01(\tR\rcatalogNumber\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescriptionB\x11\n" +
+	"\x0f_stock_quantity\";\n" +
+	"\x13CreateAlbumResponse\x12$\n" +
+	"\x05album\x18\x01 \x01(\v2\x0e.catalog.AlbumR\x05album\"\xb2\x03\n" +
+	"\x12UpdateAlbumRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04year\x18\x05 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05genre\x18\x06 \x01(\tR\x05genre\x12\x1c\n" +
+	"\tcondition\x18\a \x01(\tR\tcondition\x12\x19\n" +
+	"\bin_stock\x18\b \x01(\bR\ainStock\x120\n" +
+	"\x14confirm_price_change\x18\t \x01(\bR\x12confirmPriceChange\x12*\n" +
+	"\x0estock_quantity\x18\n" +
+	" \x01(\x05H\x00R\rstockQuantity\x88\x01\x01\x12*\n" +
+	"\x0ecatalog_number\x18\v \x01(\tH\x01R\rcatalogNumber\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\f \x01(\tH\x02R\vdescription\x88\x01\x01B\x11\n" +
+	"\x0f_stock_quantityB\x11\n" +
+	"\x0f_catalog_numberB\x0e\n" +
+	"\f_description\";\n" +
+	"\x13UpdateAlbumResponse\x12$\n" +
+	"\x05album\x18\x01 \x01(\v2\x0e.catalog.AlbumR\x05album\"$\n" +
+	"\x12DeleteAlbumRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x13DeleteAlbumResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"K\n" +
+	"\x1bSearchAlbumsByArtistRequest\x12\x16\n" +
+	"\x06artist\x18\x01 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"F\n" +
+	"\x1cSearchAlbumsByArtistResponse\x12&\n" +
+	"\x06albums\x18\x01 \x03(\v2\x0e.catalog.AlbumR\x06albums\"/\n" +
+	"\x17GetAlbumsInStockRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"B\n" +
+	"\x18GetAlbumsInStockResponse\x12&\n" +
+	"\x06albums\x18\x01 \x03(\v2\x0e.catalog.AlbumR\x06albums\"\x96\x04\n" +
+	"\x05Album\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04year\x18\x05 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05genre\x18\x06 \x01(\tR\x05genre\x12\x1c\n" +
+	"\tcondition\x18\a \x01(\tR\tcondition\x12\x19\n" +
+	"\bin_stock\x18\b \x01(\bR\ainStock\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x12%\n" +
+	"\x0estock_quantity\x18\v \x01(\x05R\rstockQuantity\x12%\n" +
+	"\x0eaverage_rating\x18\f \x01(\x01R\raverageRating\x12!\n" +
+	"\freview_count\x18\r \x01(\x05R\vreviewCount\x12\x19\n" +
+	"\blabel_id\x18\x0e \x01(\tR\alabelId\x12%\n" +
+	"\x0ecatalog_number\x18\x0f \x01(\tR\rcatalogNumber\x12&\n" +
+	"\x06tracks\x18\x10 \x03(\v2\x0e.catalog.TrackR\x06tracks\x12 \n" +
+	"\vdescription\x18\x11 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\x12 \x01(\tR\bcoverUrl\"t\n" +
+	"\x05Track\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds2\xbd\x04\n" +
+	"\x0eCatalogService\x12B\n" +
+	"\tGetAlbums\x12\x19.catalog.GetAlbumsRequest\x1a\x1a.catalog.GetAlbumsResponse\x12K\n" +
+	"\fGetAlbumByID\x12\x1c.catalog.GetAlbumByIDRequest\x1a\x1d.catalog.GetAlbumByIDResponse\x12H\n" +
+	"\vCreateAlbum\x12\x1b.catalog.CreateAlbumRequest\x1a\x1c.catalog.CreateAlbumResponse\x12H\n" +
+	"\vUpdateAlbum\x12\x1b.catalog.UpdateAlbumRequest\x1a\x1c.catalog.UpdateAlbumResponse\x12H\n" +
+	"\vDeleteAlbum\x12\x1b.catalog.DeleteAlbumRequest\x1a\x1c.catalog.DeleteAlbumResponse\x12c\n" +
+	"\x14SearchAlbumsByArtist\x12$.catalog.SearchAlbumsByArtistRequest\x1a%.catalog.SearchAlbumsByArtistResponse\x12W\n" +
+	"\x10GetAlbumsInStock\x12 .catalog.GetAlbumsInStockRequest\x1a!.catalog.GetAlbumsInStockResponseB!Z\x1fgo-music-shop/api/proto/catalogb\x06proto3"
+
 var (
 	file_catalog_proto_rawDescOnce sync.Once
-	file_catalog_proto_rawDescData = file_catalog_proto_rawDesc
+	file_catalog_proto_rawDescData []byte
 )
 
 func file_catalog_proto_rawDescGZIP() []byte {
 	file_catalog_proto_rawDescOnce.Do(func() {
-		file_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(file_catalog_proto_rawDescData)
+		file_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)))
 	})
 	return file_catalog_proto_rawDescData
 }
 
-var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
-var file_catalog_proto_goTypes = []interface{}{
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_catalog_proto_goTypes = []any{
 	(*GetAlbumsRequest)(nil),             // 0: catalog.GetAlbumsRequest
 	(*GetAlbumsResponse)(nil),            // 1: catalog.GetAlbumsResponse
 	(*GetAlbumByIDRequest)(nil),          // 2: catalog.GetAlbumByIDRequest
@@ -1116,6 +1273,7 @@ var file_catalog_proto_goTypes = []interface{}{
 	(*GetAlbumsInStockRequest)(nil),      // 12: catalog.GetAlbumsInStockRequest
 	(*GetAlbumsInStockResponse)(nil),     // 13: catalog.GetAlbumsInStockResponse
 	(*Album)(nil),                        // 14: catalog.Album
+	(*Track)(nil),                        // 15: catalog.Track
 }
 var file_catalog_proto_depIdxs = []int32{
 	14, // 0: catalog.GetAlbumsResponse.albums:type_name -> catalog.Album
@@ -1124,25 +1282,26 @@ var file_catalog_proto_depIdxs = []int32{
 	14, // 3: catalog.UpdateAlbumResponse.album:type_name -> catalog.Album
 	14, // 4: catalog.SearchAlbumsByArtistResponse.albums:type_name -> catalog.Album
 	14, // 5: catalog.GetAlbumsInStockResponse.albums:type_name -> catalog.Album
-	0,  // 6: catalog.CatalogService.GetAlbums:input_type -> catalog.GetAlbumsRequest
-	2,  // 7: catalog.CatalogService.GetAlbumByID:input_type -> catalog.GetAlbumByIDRequest
-	4,  // 8: catalog.CatalogService.CreateAlbum:input_type -> catalog.CreateAlbumRequest
-	6,  // 9: catalog.CatalogService.UpdateAlbum:input_type -> catalog.UpdateAlbumRequest
-	8,  // 10: catalog.CatalogService.DeleteAlbum:input_type -> catalog.DeleteAlbumRequest
-	10, // 11: catalog.CatalogService.SearchAlbumsByArtist:input_type -> catalog.SearchAlbumsByArtistRequest
-	12, // 12: catalog.CatalogService.GetAlbumsInStock:input_type -> catalog.GetAlbumsInStockRequest
-	1,  // 13: catalog.CatalogService.GetAlbums:output_type -> catalog.GetAlbumsResponse
-	3,  // 14: catalog.CatalogService.GetAlbumByID:output_type -> catalog.GetAlbumByIDResponse
-	5,  // 15: catalog.CatalogService.CreateAlbum:output_type -> catalog.CreateAlbumResponse
-	7,  // 16: catalog.CatalogService.UpdateAlbum:output_type -> catalog.UpdateAlbumResponse
-	9,  // 17: catalog.CatalogService.DeleteAlbum:output_type -> catalog.DeleteAlbumResponse
-	11, // 18: catalog.CatalogService.SearchAlbumsByArtist:output_type -> catalog.SearchAlbumsByArtistResponse
-	13, // 19: catalog.CatalogService.GetAlbumsInStock:output_type -> catalog.GetAlbumsInStockResponse
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	15, // 6: catalog.Album.tracks:type_name -> catalog.Track
+	0,  // 7: catalog.CatalogService.GetAlbums:input_type -> catalog.GetAlbumsRequest
+	2,  // 8: catalog.CatalogService.GetAlbumByID:input_type -> catalog.GetAlbumByIDRequest
+	4,  // 9: catalog.CatalogService.CreateAlbum:input_type -> catalog.CreateAlbumRequest
+	6,  // 10: catalog.CatalogService.UpdateAlbum:input_type -> catalog.UpdateAlbumRequest
+	8,  // 11: catalog.CatalogService.DeleteAlbum:input_type -> catalog.DeleteAlbumRequest
+	10, // 12: catalog.CatalogService.SearchAlbumsByArtist:input_type -> catalog.SearchAlbumsByArtistRequest
+	12, // 13: catalog.CatalogService.GetAlbumsInStock:input_type -> catalog.GetAlbumsInStockRequest
+	1,  // 14: catalog.CatalogService.GetAlbums:output_type -> catalog.GetAlbumsResponse
+	3,  // 15: catalog.CatalogService.GetAlbumByID:output_type -> catalog.GetAlbumByIDResponse
+	5,  // 16: catalog.CatalogService.CreateAlbum:output_type -> catalog.CreateAlbumResponse
+	7,  // 17: catalog.CatalogService.UpdateAlbum:output_type -> catalog.UpdateAlbumResponse
+	9,  // 18: catalog.CatalogService.DeleteAlbum:output_type -> catalog.DeleteAlbumResponse
+	11, // 19: catalog.CatalogService.SearchAlbumsByArtist:output_type -> catalog.SearchAlbumsByArtistResponse
+	13, // 20: catalog.CatalogService.GetAlbumsInStock:output_type -> catalog.GetAlbumsInStockResponse
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_catalog_proto_init() }
@@ -1150,195 +1309,15 @@ func file_catalog_proto_init() {
 	if File_catalog_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_catalog_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumByIDRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumByIDResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAlbumRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAlbumResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAlbumRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAlbumResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAlbumRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAlbumResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchAlbumsByArtistRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchAlbumsByArtistResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumsInStockRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAlbumsInStockResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_catalog_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Album); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
+	file_catalog_proto_msgTypes[4].OneofWrappers = []any{}
+	file_catalog_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_catalog_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1347,7 +1326,6 @@ func file_catalog_proto_init() {
 		MessageInfos:      file_catalog_proto_msgTypes,
 	}.Build()
 	File_catalog_proto = out.File
-	file_catalog_proto_rawDesc = nil
 	file_catalog_proto_goTypes = nil
 	file_catalog_proto_depIdxs = nil
 }
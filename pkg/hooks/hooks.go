@@ -0,0 +1,117 @@
+// Пакет in-process хуков каталога: код, который встраивает сервис как библиотеку,
+// подписывается на изменения альбомов без правок сервисного слоя и без шины событий
+package hooks
+
+import (
+	"go-music-shop/internal/domain/models"
+	"log"
+	"runtime/debug"
+	"slices"
+	"sync"
+)
+
+// ChangeType - вид изменения альбома
+type ChangeType string
+
+const (
+	AlbumCreated ChangeType = "created"
+	AlbumUpdated ChangeType = "updated"
+	AlbumDeleted ChangeType = "deleted"
+)
+
+// AlbumChange - изменение альбома: Old == nil при создании, New == nil при удалении
+type AlbumChange struct {
+	Old *domain.Album
+	New *domain.Album
+}
+
+// Type - вид изменения
+func (c AlbumChange) Type() ChangeType {
+	switch {
+	case c.Old == nil:
+		return AlbumCreated
+	case c.New == nil:
+		return AlbumDeleted
+	default:
+		return AlbumUpdated
+	}
+}
+
+// AlbumID - id измененного альбома
+func (c AlbumChange) AlbumID() string {
+	if c.New != nil {
+		return c.New.ID
+	}
+	return c.Old.ID
+}
+
+// hook - зарегистрированный обработчик
+type hook struct {
+	id int
+	fn func(AlbumChange)
+}
+
+// Registry - обработчики изменений альбомов
+// Подключается к сервису как подписчик на изменения каталога (AlbumService.Subscribe)
+type Registry struct {
+	mu     sync.RWMutex
+	nextID int
+	hooks  []hook // В порядке регистрации
+}
+
+// NewRegistry - создает пустой реестр хуков
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default - реестр, к которому подключены сервисы из cmd/
+var Default = NewRegistry()
+
+// OnAlbumChanged - регистрирует обработчик в реестре по умолчанию
+func OnAlbumChanged(fn func(AlbumChange)) (remove func()) {
+	return Default.OnAlbumChanged(fn)
+}
+
+// OnAlbumChanged - регистрирует обработчик изменений альбомов; возвращает функцию, которая его удаляет
+// Обработчики вызываются синхронно после записи, в порядке регистрации: долгую работу
+// (сеть, очереди) обработчик должен сам отправлять в горутину
+func (r *Registry) OnAlbumChanged(fn func(AlbumChange)) (remove func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	id := r.nextID
+	r.hooks = append(r.hooks, hook{id: id, fn: fn})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.hooks = slices.DeleteFunc(r.hooks, func(h hook) bool { return h.id == id })
+		})
+	}
+}
+
+// AlbumChanged - вызывает обработчики (реализует service.CatalogListener)
+// Паника в обработчике логируется и не мешает остальным обработчикам и ответу клиенту
+func (r *Registry) AlbumChanged(old, updated *domain.Album) {
+	r.mu.RLock()
+	hooks := slices.Clone(r.hooks) // Обработчик может удалить себя или добавить новый
+	r.mu.RUnlock()
+
+	change := AlbumChange{Old: old, New: updated}
+	for _, h := range hooks {
+		call(h.fn, change)
+	}
+}
+
+// call - вызывает обработчик, перехватывая панику
+func call(fn func(AlbumChange), change AlbumChange) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("album hook panic on %s %s: %v\n%s", change.Type(), change.AlbumID(), p, debug.Stack())
+		}
+	}()
+	fn(change)
+}
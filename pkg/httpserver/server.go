@@ -0,0 +1,80 @@
+// Пакет для запуска HTTP сервера (HTTP или HTTPS с HTTP/2)
+package httpserver
+
+import (
+	"go-music-shop/internal/config"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Serve - обслуживает соединения слушателя по HTTP или HTTPS в зависимости от конфигурации
+// HTTP/2 включается net/http автоматически для TLS соединений
+func Serve(server *http.Server, ln net.Listener, cfg config.TLSConfig) error {
+	switch {
+	case len(cfg.AutocertDomains) > 0:
+		// Сертификаты Let's Encrypt получаются и обновляются автоматически
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+			Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+		}
+		server.TLSConfig = manager.TLSConfig()
+
+		// Проверка владения доменом (HTTP-01) требует HTTP на 80 порту
+		redirectPort := cfg.RedirectPort
+		if redirectPort == "" {
+			redirectPort = "80"
+		}
+		go serveRedirect(redirectPort, manager.HTTPHandler(redirectHandler(server.Addr)))
+
+		log.Printf("HTTPS enabled with Let's Encrypt for %v", cfg.AutocertDomains)
+		return server.ServeTLS(ln, "", "")
+
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		if cfg.RedirectPort != "" {
+			go serveRedirect(cfg.RedirectPort, redirectHandler(server.Addr))
+		}
+
+		log.Printf("HTTPS enabled with certificate %s", cfg.CertFile)
+		return server.ServeTLS(ln, cfg.CertFile, cfg.KeyFile)
+
+	default:
+		return server.Serve(ln)
+	}
+}
+
+// serveRedirect - запускает HTTP сервер, который перенаправляет все запросы на HTTPS
+func serveRedirect(port string, handler http.Handler) {
+	redirectServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+
+	log.Printf("HTTP->HTTPS redirect listening on port %s", port)
+	if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("HTTP redirect server error: %v", err)
+	}
+}
+
+// redirectHandler - перенаправляет запрос на тот же путь по HTTPS (301)
+func redirectHandler(tlsAddr string) http.Handler {
+	_, tlsPort, _ := net.SplitHostPort(tlsAddr)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if tlsPort != "" && tlsPort != "443" {
+			host = net.JoinHostPort(host, tlsPort)
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
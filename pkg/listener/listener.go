@@ -0,0 +1,76 @@
+// Пакет для создания сетевых слушателей (TCP, unix socket, systemd socket activation)
+package listener
+
+import (
+	"fmt"
+	"go-music-shop/internal/config"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// sdListenFDsStart - первый файловый дескриптор, который передает systemd (SD_LISTEN_FDS_START)
+const sdListenFDsStart = 3
+
+// Listen - создает слушатель по конфигурации:
+// systemd socket activation, unix socket или TCP на переданном адресе
+func Listen(cfg config.ListenConfig, tcpAddr string) (net.Listener, error) {
+	switch {
+	case cfg.SystemdActivation:
+		return systemdListener()
+	case cfg.UnixSocket != "":
+		return unixListener(cfg.UnixSocket, cfg.UnixSocketMode)
+	default:
+		return net.Listen("tcp", tcpAddr)
+	}
+}
+
+// unixListener - слушает unix socket (например, за локальным nginx)
+func unixListener(path string, mode uint32) (net.Listener, error) {
+	// Удаляем сокет, оставшийся от предыдущего запуска, иначе bind вернет "address already in use"
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("removing stale unix socket error: %w", err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on unix socket error: %w", err)
+	}
+
+	if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("setting unix socket permissions error: %w", err)
+	}
+
+	return ln, nil
+}
+
+// systemdListener - берет уже открытый сокет, переданный systemd (аналог sd_listen_fds)
+func systemdListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, fmt.Errorf("no sockets passed by systemd (LISTEN_PID mismatch)")
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("no sockets passed by systemd (LISTEN_FDS=%q)", os.Getenv("LISTEN_FDS"))
+	}
+
+	// Переменные предназначены только нам - не передаем их дочерним процессам
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	// Используем первый переданный сокет
+	syscall.CloseOnExec(sdListenFDsStart)
+	file := os.NewFile(uintptr(sdListenFDsStart), "systemd-socket")
+	defer file.Close() // FileListener дублирует дескриптор
+
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("using systemd socket error: %w", err)
+	}
+	return ln, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-music-shop/internal/config"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9" // Импортируем Redis клиент
@@ -73,6 +74,151 @@ func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return value, nil
 }
 
+// GetBytes - чтение из кэша без преобразования в строку (меньше копирований)
+func (r *RedisClient) GetBytes(ctx context.Context, key string) ([]byte, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+
+	if err == redis.Nil {
+		return nil, nil // Ключ не найден - нормально для кэша
+	} else if err != nil {
+		return nil, fmt.Errorf("getting from Redis error: %w", err)
+	}
+	return value, nil
+}
+
+// MGetBytes - чтение нескольких ключей за один запрос; для отсутствующих ключей - nil
+func (r *RedisClient) MGetBytes(ctx context.Context, keys ...string) ([][]byte, error) {
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("getting from Redis error: %w", err)
+	}
+
+	result := make([][]byte, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			result[i] = []byte(s)
+		}
+	}
+	return result, nil
+}
+
+// HIncrBy - увеличивает счетчик поля в хэше и возвращает новое значение
+func (r *RedisClient) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	value, err := r.client.HIncrBy(ctx, key, field, incr).Result()
+	if err != nil {
+		return 0, fmt.Errorf("incrementing Redis hash error: %w", err)
+	}
+	return value, nil
+}
+
+// HSet - записывает несколько полей хэша
+func (r *RedisClient) HSet(ctx context.Context, key string, values map[string]any) error {
+	if err := r.client.HSet(ctx, key, values).Err(); err != nil {
+		return fmt.Errorf("saving Redis hash error: %w", err)
+	}
+	return nil
+}
+
+// HDel - удаляет поля хэша
+func (r *RedisClient) HDel(ctx context.Context, key string, fields ...string) error {
+	if err := r.client.HDel(ctx, key, fields...).Err(); err != nil {
+		return fmt.Errorf("deleting Redis hash fields error: %w", err)
+	}
+	return nil
+}
+
+// ZAddLex - добавляет строки в sorted set с одинаковым весом (для поиска по префиксу через ZRANGEBYLEX)
+func (r *RedisClient) ZAddLex(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	zs := make([]redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = redis.Z{Score: 0, Member: member}
+	}
+	if err := r.client.ZAdd(ctx, key, zs...).Err(); err != nil {
+		return fmt.Errorf("adding to Redis sorted set error: %w", err)
+	}
+	return nil
+}
+
+// ZRem - удаляет строки из sorted set
+func (r *RedisClient) ZRem(ctx context.Context, key string, members ...string) error {
+	args := make([]any, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+	if err := r.client.ZRem(ctx, key, args...).Err(); err != nil {
+		return fmt.Errorf("removing from Redis sorted set error: %w", err)
+	}
+	return nil
+}
+
+// ZRangeByLex - возвращает до count строк sorted set в лексикографическом диапазоне [min, max]
+func (r *RedisClient) ZRangeByLex(ctx context.Context, key, min, max string, count int64) ([]string, error) {
+	members, err := r.client.ZRangeByLex(ctx, key, &redis.ZRangeBy{Min: min, Max: max, Count: count}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading Redis sorted set error: %w", err)
+	}
+	return members, nil
+}
+
+// HGetAll - возвращает все поля хэша
+func (r *RedisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	values, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("getting Redis hash error: %w", err)
+	}
+	return values, nil
+}
+
+// Rename - переименовывает ключ; возвращает false, если ключа не было
+func (r *RedisClient) Rename(ctx context.Context, key, newKey string) (bool, error) {
+	err := r.client.Rename(ctx, key, newKey).Err()
+	if err != nil && err.Error() == "ERR no such key" {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("renaming Redis key error: %w", err)
+	}
+	return true, nil
+}
+
+// ScanKeys - возвращает все ключи по шаблону (через SCAN, не блокируя Redis как KEYS)
+func (r *RedisClient) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := r.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scanning Redis keys error: %w", err)
+	}
+	return keys, nil
+}
+
+// MemoryUsage - сколько байт памяти Redis занимает ключ (MEMORY USAGE); 0 - ключа нет
+func (r *RedisClient) MemoryUsage(ctx context.Context, key string) (int64, error) {
+	n, err := r.client.MemoryUsage(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting Redis memory usage error: %w", err)
+	}
+	return n, nil
+}
+
+// Exists - проверяет есть ли ключ в кэше
+func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("checking key in Redis error: %w", err)
+	}
+	return n > 0, nil
+}
+
 // Delete - удаление из кэша
 func (r *RedisClient) Delete(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
@@ -82,6 +228,34 @@ func (r *RedisClient) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// Ping - проверка что Redis отвечает
+func (r *RedisClient) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("pinging Redis error: %w", err)
+	}
+	return nil
+}
+
+// ServerVersion - версия сервера Redis (из INFO server)
+func (r *RedisClient) ServerVersion(ctx context.Context) (string, error) {
+	info, err := r.client.Info(ctx, "server").Result()
+	if err != nil {
+		return "", fmt.Errorf("getting Redis info error: %w", err)
+	}
+
+	for _, line := range strings.Split(info, "\r\n") {
+		if version, ok := strings.CutPrefix(line, "redis_version:"); ok {
+			return version, nil
+		}
+	}
+	return "unknown", nil
+}
+
+// PoolStats - статистика пула подключений к Redis (для мониторинга)
+func (r *RedisClient) PoolStats() *redis.PoolStats {
+	return r.client.PoolStats()
+}
+
 // Close - закрытие подключения
 func (r *RedisClient) Close() error {
 	// Закрываем подключение к Redis
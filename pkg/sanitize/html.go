@@ -0,0 +1,134 @@
+// Package sanitize - очистка пользовательского текста (описания альбомов) перед сохранением
+package sanitize
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags - теги, которые остаются в тексте; у всех, кроме ссылок, атрибуты удаляются
+var allowedTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Hr: true,
+	atom.Strong: true, atom.B: true, atom.Em: true, atom.I: true, atom.U: true, atom.S: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Blockquote: true,
+	atom.H3: true, atom.H4: true, atom.Code: true, atom.Pre: true, atom.A: true,
+}
+
+// droppedTags - теги, которые удаляются вместе с содержимым: скрипты, стили, встраиваемые объекты
+// и все теги, содержимое которых браузер читает как сырой текст (иначе оно попало бы в результат как есть)
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Title: true,
+	atom.Svg: true, atom.Math: true, atom.Select: true,
+	atom.Xmp: true, atom.Noembed: true, atom.Noframes: true, atom.Plaintext: true,
+}
+
+// allowedSchemes - схемы ссылок (javascript: и data: не допускаются)
+var allowedSchemes = []string{"http", "https", "mailto"}
+
+// HTML - оставляет в тексте только безопасное подмножество HTML
+// Текст между тегами не меняется, поэтому markdown ("**жирный**", "> цитата") проходит как есть;
+// запрещенные теги удаляются (их текст остается), незакрытые разрешенные закрываются в конце
+func HTML(s string) string {
+	var b strings.Builder
+	var open []atom.Atom // Открытые разрешенные теги
+	dropDepth := 0       // Вложенность внутри удаляемых тегов
+
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			break // io.EOF: токенизатор читает из строки
+		}
+		// Raw копируется до Token: Token раскодирует сущности прямо в буфере токенизатора,
+		// и "&lt;script&gt;" в Raw превратился бы в настоящий тег
+		raw := string(z.Raw())
+		token := z.Token()
+
+		switch tt {
+		case nethtml.TextToken:
+			if dropDepth == 0 {
+				b.WriteString(raw) // Исходный текст: сущности и markdown не трогаем
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if droppedTags[token.DataAtom] {
+				if tt == nethtml.StartTagToken {
+					dropDepth++
+				}
+				continue
+			}
+			if dropDepth > 0 || !allowedTags[token.DataAtom] {
+				continue
+			}
+			b.WriteString(startTag(token))
+			if tt == nethtml.StartTagToken && !isVoid(token.DataAtom) {
+				open = append(open, token.DataAtom)
+			}
+		case nethtml.EndTagToken:
+			if droppedTags[token.DataAtom] {
+				dropDepth = max(dropDepth-1, 0)
+				continue
+			}
+			if dropDepth > 0 || !allowedTags[token.DataAtom] {
+				continue
+			}
+			// Закрываем только открытый тег; вложенные в него незакрытые закрываются вместе с ним
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.DataAtom {
+					for j := len(open) - 1; j >= i; j-- {
+						b.WriteString("</" + open[j].String() + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+		// Комментарии и doctype удаляются
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i].String() + ">")
+	}
+	return b.String()
+}
+
+// startTag - открывающий тег без атрибутов; у ссылки остается только безопасный href
+func startTag(token nethtml.Token) string {
+	name := token.DataAtom.String()
+	if token.DataAtom != atom.A {
+		return "<" + name + ">"
+	}
+
+	for _, attr := range token.Attr {
+		if attr.Namespace == "" && attr.Key == "href" && safeURL(attr.Val) {
+			return `<a href="` + html.EscapeString(attr.Val) + `" rel="nofollow noopener">`
+		}
+	}
+	return "<a>"
+}
+
+// safeURL - ссылка с разрешенной схемой (относительные ссылки допускаются)
+func safeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		return !strings.HasPrefix(strings.TrimSpace(raw), "//") // //evil.example - ссылка на чужой сайт
+	}
+	for _, scheme := range allowedSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVoid - тег без закрывающей пары
+func isVoid(a atom.Atom) bool {
+	return a == atom.Br || a == atom.Hr
+}
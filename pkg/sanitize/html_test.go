@@ -0,0 +1,23 @@
+package sanitize
+
+import "testing"
+
+// TestHTMLKeepsEscapedTags - экранированные теги остаются текстом и не становятся разметкой
+func TestHTMLKeepsEscapedTags(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"&lt;script&gt;alert(1)&lt;/script&gt;", "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"&lt;img src=x onerror=alert(1)&gt;", "&lt;img src=x onerror=alert(1)&gt;"},
+		{"<p>Mono &amp; stereo &lt;b&gt;</p>", "<p>Mono &amp; stereo &lt;b&gt;</p>"},
+		{"<p>**Original** pressing</p><script>alert(1)</script>", "<p>**Original** pressing</p>"},
+		{`<a href="javascript:alert(1)" onclick="x">link</a>`, "<a>link</a>"},
+		{"<b>unclosed", "<b>unclosed</b>"},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("HTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Load - записывает демо-каталог в базу одной транзакцией
+// Уже существующие записи (по id) не изменяются, поэтому повторный запуск безопасен
+func Load(ctx context.Context, db *sql.DB, dataset *Dataset) (labels, albums int, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, label := range dataset.Labels {
+		result, err := tx.ExecContext(ctx, `INSERT INTO labels (id, name, country, founded_year, created_at)
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+			label.ID, label.Name, label.Country, label.FoundedYear, label.CreatedAt)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to insert label: %w", err)
+		}
+		labels += rowsAffected(result)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO albums
+		(id, title, artist, price, year, genre, condition, stock_quantity, created_at, updated_at, label_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
+		ON CONFLICT (id) DO NOTHING`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prepare album insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, album := range dataset.Albums {
+		result, err := stmt.ExecContext(ctx, album.ID, album.Title, album.Artist, album.Price, album.Year,
+			album.Genre, album.Condition, album.StockQuantity, album.CreatedAt, album.UpdatedAt, album.LabelID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to insert album: %w", err)
+		}
+		albums += rowsAffected(result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return labels, albums, nil
+}
+
+// rowsAffected - сколько строк вставлено (0 - запись уже была)
+func rowsAffected(result sql.Result) int {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,217 @@
+// Пакет для генерации детерминированного демо-каталога (демо, нагрузочные тесты, воспроизведение ошибок)
+// Один и тот же Config всегда дает один и тот же набор данных, включая id
+package seed
+
+import (
+	"fmt"
+	"go-music-shop/internal/domain/models"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// Config - параметры демо-каталога
+type Config struct {
+	Seed        uint64             // Зерно генератора: одинаковое зерно - одинаковые данные
+	Albums      int                // Сколько альбомов
+	Artists     int                // Сколько исполнителей
+	Labels      int                // Сколько лейблов (0 - без лейблов)
+	Genres      map[string]float64 // Жанр -> вес (доли нормализуются)
+	PriceMedian float64            // Медианная цена
+	PriceSpread float64            // Разброс цен (сигма логнормального распределения)
+	InStock     float64            // Доля альбомов в наличии, 0..1
+}
+
+// DefaultConfig - небольшой джазовый магазин
+func DefaultConfig() Config {
+	return Config{
+		Seed:    1,
+		Albums:  500,
+		Artists: 80,
+		Labels:  12,
+		Genres: map[string]float64{
+			"Jazz": 50, "Bebop": 12, "Hard Bop": 12, "Cool Jazz": 8, "Free Jazz": 5,
+			"Soul Jazz": 5, "Blues": 5, "Latin Jazz": 3,
+		},
+		PriceMedian: 30,
+		PriceSpread: 0.6,
+		InStock:     0.75,
+	}
+}
+
+// Album - альбом демо-каталога с лейблом
+type Album struct {
+	domain.Album
+	LabelID string // "" - без лейбла
+}
+
+// Dataset - сгенерированный каталог
+type Dataset struct {
+	Labels []domain.Label
+	Albums []Album
+}
+
+// Generate - генерирует демо-каталог
+func Generate(cfg Config) (*Dataset, error) {
+	if cfg.Albums < 0 || cfg.Artists <= 0 || cfg.Labels < 0 {
+		return nil, fmt.Errorf("albums, artists and labels must not be negative (at least one artist)")
+	}
+	if cfg.InStock < 0 || cfg.InStock > 1 {
+		return nil, fmt.Errorf("in-stock ratio must be between 0 and 1")
+	}
+	if cfg.PriceMedian <= 0 || cfg.PriceSpread < 0 {
+		return nil, fmt.Errorf("price median must be positive and spread not negative")
+	}
+	genres, weights, err := genreWeights(cfg.Genres)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+	// Фиксированная точка отсчета: время создания не зависит от момента запуска
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	dataset := &Dataset{}
+	for i := range cfg.Labels {
+		dataset.Labels = append(dataset.Labels, domain.Label{
+			ID:          newID(rng),
+			Name:        uniqueName(labelNames, i),
+			Country:     labelCountries[rng.IntN(len(labelCountries))],
+			FoundedYear: 1930 + rng.IntN(60),
+			CreatedAt:   base,
+		})
+	}
+
+	// Все сочетания имени и фамилии в случайном порядке: исполнители не повторяются
+	var names []string
+	for _, first := range firstNames {
+		for _, last := range lastNames {
+			names = append(names, first+" "+last)
+		}
+	}
+	rng.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	artists := make([]string, cfg.Artists)
+	for i := range artists {
+		artists[i] = uniqueName(names, i)
+	}
+
+	maxYear := time.Now().Year()
+	for range cfg.Albums {
+		// Популярные исполнители выпускают больше: индекс смещен к началу списка
+		artist := artists[int(math.Pow(rng.Float64(), 2)*float64(len(artists)))]
+		createdAt := base.Add(time.Duration(rng.Int64N(int64(4 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+
+		album := Album{Album: domain.Album{
+			ID:            newID(rng),
+			Title:         titleAdjectives[rng.IntN(len(titleAdjectives))] + " " + titleNouns[rng.IntN(len(titleNouns))],
+			Artist:        artist,
+			Price:         price(rng, cfg.PriceMedian, cfg.PriceSpread),
+			Year:          min(1950+rng.IntN(30)+rng.IntN(40), maxYear),
+			Genre:         pick(rng, genres, weights),
+			Condition:     conditions[rng.IntN(len(conditions))],
+			StockQuantity: stockQuantity(rng.Float64(), cfg.InStock),
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}}
+		if len(dataset.Labels) > 0 && rng.Float64() < 0.9 {
+			album.LabelID = dataset.Labels[rng.IntN(len(dataset.Labels))].ID
+		}
+		dataset.Albums = append(dataset.Albums, album)
+	}
+
+	return dataset, nil
+}
+
+// maxSeedStock - сколько экземпляров одного альбома может быть на складе демо-магазина
+const maxSeedStock = 3
+
+// stockQuantity - количество на складе по случайному числу r из [0, 1): в наличии доля inStock альбомов,
+// у них от 1 до maxSeedStock экземпляров (одно случайное число, чтобы при том же зерне остальные данные не менялись)
+func stockQuantity(r, inStock float64) int {
+	if r >= inStock {
+		return 0
+	}
+	return 1 + int(r/inStock*maxSeedStock)
+}
+
+// genreWeights - жанры в стабильном порядке (обход map случаен) и накопленные веса
+func genreWeights(genres map[string]float64) ([]string, []float64, error) {
+	names := make([]string, 0, len(genres))
+	for name, weight := range genres {
+		if weight < 0 {
+			return nil, nil, fmt.Errorf("genre %q has negative weight", name)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("at least one genre is required")
+	}
+	sort.Strings(names)
+
+	cumulative := make([]float64, len(names))
+	var total float64
+	for i, name := range names {
+		total += genres[name]
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil, nil, fmt.Errorf("genre weights sum to zero")
+	}
+	return names, cumulative, nil
+}
+
+// pick - выбирает элемент по накопленным весам
+func pick(rng *rand.Rand, names []string, cumulative []float64) string {
+	x := rng.Float64() * cumulative[len(cumulative)-1]
+	i := sort.SearchFloat64s(cumulative, x)
+	return names[min(i, len(names)-1)]
+}
+
+// price - логнормальная цена, округленная до .99 (как на ценниках магазина)
+func price(rng *rand.Rand, median, spread float64) float64 {
+	p := median * math.Exp(rng.NormFloat64()*spread)
+	return math.Max(math.Floor(p), 1) + 0.99
+}
+
+// newID - детерминированный id в формате UUID v4
+func newID(rng *rand.Rand) string {
+	hi, lo := rng.Uint64(), rng.Uint64()
+	hi = hi&^0xf000 | 0x4000     // Версия 4
+	lo = lo&^(0xc<<60) | 0x8<<60 // Вариант RFC 4122
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, hi>>16&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff)
+}
+
+// uniqueName - имя из списка, с номером после того как список закончился
+func uniqueName(names []string, i int) string {
+	if i < len(names) {
+		return names[i]
+	}
+	return fmt.Sprintf("%s %d", names[i%len(names)], i/len(names)+1)
+}
+
+var (
+	conditions     = []string{"mint", "very good", "very good", "good", "good", "fair", "poor"}
+	labelCountries = []string{"US", "US", "US", "GB", "DE", "FR", "JP"}
+	labelNames     = []string{
+		"Blue Lantern", "Impulse Park", "Prestige Hall", "Riverside Street", "Verve Lane", "Contemporary West",
+		"Savoy Square", "Atlantic Pier", "Candid Room", "Pacific Coast", "Black Saint", "Enja House",
+	}
+	firstNames = []string{
+		"Miles", "Ella", "Charlie", "Billie", "Sonny", "Nina", "Art", "Sarah", "Dexter", "Carmen",
+		"Wayne", "Abbey", "Lee", "Betty", "Horace", "Dinah", "Cannonball", "Shirley", "Bud", "Anita",
+	}
+	lastNames = []string{
+		"Monroe", "Holloway", "Fairfax", "Whitaker", "Ellington", "Crawford", "Delaney", "Booker",
+		"Sutton", "Marsh", "Pemberton", "Lytle", "Haywood", "Cobb", "Garland", "Quill",
+	}
+	titleAdjectives = []string{
+		"Blue", "Midnight", "Moanin'", "Soul", "Round", "Kind", "Giant", "Speak", "Maiden", "Silver",
+		"Cool", "Late", "Lonely", "Golden", "Smokin'", "Easy", "Night", "Bright", "Quiet", "Saxophone",
+	}
+	titleNouns = []string{
+		"Train", "Steps", "Voyage", "Colossus", "Sessions", "Nights", "Serenade", "Ballads", "Groove",
+		"Standards", "Horizon", "Boulevard", "Reverie", "Suite", "Walk", "Blues", "Dreams", "Journey",
+	}
+)
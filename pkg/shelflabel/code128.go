@@ -0,0 +1,89 @@
+package shelflabel
+
+import "fmt"
+
+// code128Patterns - ширины полос и промежутков символов Code128 (значения 0-105), начиная с полосы
+var code128Patterns = [106]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232",
+}
+
+const (
+	code128CodeC  = 99
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = "2331112"
+)
+
+// Code128 - кодирует строку (печатные символы ASCII) в штрихкод Code128
+// Возвращает ширины элементов в модулях, начиная с полосы; полосы и промежутки чередуются
+// Числа кодируются набором C (две цифры на символ) - штрихкод короче и читается надежнее
+func Code128(data string) ([]int, error) {
+	if data == "" {
+		return nil, fmt.Errorf("barcode data cannot be empty")
+	}
+
+	numeric := true
+	for _, r := range data {
+		if r < ' ' || r > '~' {
+			return nil, fmt.Errorf("barcode data contains unsupported character %q", r)
+		}
+		if r < '0' || r > '9' {
+			numeric = false
+		}
+	}
+
+	var values []int
+	switch {
+	case numeric && len(data)%2 == 0:
+		values = append(values, code128StartC)
+		values = appendDigitPairs(values, data)
+	case numeric && len(data) > 1:
+		// Нечетное число цифр: первую кодируем набором B, остальные парами
+		values = append(values, code128StartB, int(data[0]-' '), code128CodeC)
+		values = appendDigitPairs(values, data[1:])
+	default:
+		values = append(values, code128StartB)
+		for i := 0; i < len(data); i++ {
+			values = append(values, int(data[i]-' '))
+		}
+	}
+
+	// Контрольный символ: сумма значений, умноженных на позицию (старт - с весом 1), по модулю 103
+	checksum := values[0]
+	for i, v := range values[1:] {
+		checksum += v * (i + 1)
+	}
+	values = append(values, checksum%103)
+
+	var widths []int
+	for _, v := range values {
+		widths = appendPattern(widths, code128Patterns[v])
+	}
+	return appendPattern(widths, code128Stop), nil
+}
+
+// appendDigitPairs - добавляет значения набора C для четного числа цифр
+func appendDigitPairs(values []int, digits string) []int {
+	for i := 0; i+1 < len(digits); i += 2 {
+		values = append(values, int(digits[i]-'0')*10+int(digits[i+1]-'0'))
+	}
+	return values
+}
+
+// appendPattern - добавляет ширины элементов символа
+func appendPattern(widths []int, pattern string) []int {
+	for _, c := range pattern {
+		widths = append(widths, int(c-'0'))
+	}
+	return widths
+}
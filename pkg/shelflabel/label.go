@@ -0,0 +1,123 @@
+// Пакет для печати ценников на полки: PDF с ценой, описанием альбома и штрихкодом Code128
+package shelflabel
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// mm - пунктов PDF в миллиметре
+const mm = 72 / 25.4
+
+// Template - оформление этикетки магазина
+type Template struct {
+	StoreName     string  // Название магазина вверху этикетки ("" - не печатать)
+	WidthMM       float64 // Размер этикетки (одна этикетка - одна страница, как у принтеров этикеток)
+	HeightMM      float64
+	ShowCondition bool // Печатать состояние пластинки рядом с ценой
+}
+
+// Label - данные одной этикетки
+type Label struct {
+	Artist    string
+	Title     string
+	Price     string // Уже отформатированная цена с валютой
+	Condition string
+	Code      string // Содержимое штрихкода (id альбома)
+}
+
+// Render - печатает этикетки в PDF, по одной на страницу
+func Render(w io.Writer, tmpl Template, labels []Label) error {
+	if tmpl.WidthMM < 30 || tmpl.HeightMM < 20 {
+		return fmt.Errorf("label must be at least 30x20 mm, got %gx%g mm", tmpl.WidthMM, tmpl.HeightMM)
+	}
+	if len(labels) == 0 {
+		return fmt.Errorf("no labels to render")
+	}
+
+	doc := &document{width: tmpl.WidthMM * mm, height: tmpl.HeightMM * mm}
+	for _, label := range labels {
+		if err := renderLabel(doc, tmpl, label); err != nil {
+			return fmt.Errorf("label %s: %w", label.Code, err)
+		}
+	}
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// renderLabel - одна этикетка: текст сверху вниз, штрихкод внизу
+func renderLabel(doc *document, tmpl Template, label Label) error {
+	bars, err := Code128(label.Code)
+	if err != nil {
+		return err
+	}
+
+	page := doc.newPage()
+	margin := 2 * mm
+	x, width := margin, doc.width-2*margin
+	y := doc.height - margin
+
+	line := func(font string, size float64, s string) {
+		y -= size
+		text(page, font, size, x, y, fitText(s, size, width))
+		y -= size * 0.25
+	}
+
+	if tmpl.StoreName != "" {
+		line("F1", 6, tmpl.StoreName)
+	}
+	line("F2", 9, label.Artist)
+	line("F1", 8, label.Title)
+	line("F2", 14, label.Price)
+	if tmpl.ShowCondition && label.Condition != "" {
+		// Состояние - справа на строке цены
+		size := 7.0
+		condition := fitText(label.Condition, size, width/2)
+		text(page, "F1", size, x+width-textWidth(condition, size), y+size*0.25, condition)
+	}
+
+	// Штрихкод занимает оставшееся место; под ним - его содержимое для ручного ввода
+	const quietZone = 10 // Поля без полос по краям, в модулях
+	const codeSize = 6.0
+	codeY := margin
+	barsY := codeY + codeSize + 1
+	barsHeight := y - barsY - 1*mm
+	if barsHeight < 4*mm {
+		return fmt.Errorf("label is too small for a barcode")
+	}
+
+	modules := 2 * quietZone
+	for _, w := range bars {
+		modules += w
+	}
+	module := width / float64(modules)
+
+	bx := x + quietZone*module
+	for i, w := range bars {
+		if i%2 == 0 { // Четные элементы - полосы, нечетные - промежутки
+			rect(page, bx, barsY, float64(w)*module, barsHeight)
+		}
+		bx += float64(w) * module
+	}
+
+	code := fitText(label.Code, codeSize, width)
+	text(page, "F1", codeSize, x+(width-textWidth(code, codeSize))/2, codeY, code)
+	return nil
+}
+
+// textWidth - примерная ширина строки Helvetica в пунктах (средняя ширина символа - 0.55 кегля)
+func textWidth(s string, size float64) float64 {
+	return float64(utf8.RuneCountInString(s)) * size * 0.55
+}
+
+// fitText - обрезает строку с многоточием, чтобы она поместилась в ширину
+func fitText(s string, size, width float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	n := max(int(width/(size*0.55))-1, 0)
+	return string(runes[:min(n, len(runes))]) + "…"
+}
@@ -0,0 +1,94 @@
+package shelflabel
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// document - минимальный PDF: страницы одного размера, текст шрифтом Helvetica и закрашенные прямоугольники
+// Для этикеток этого достаточно, и не нужна внешняя библиотека
+type document struct {
+	width, height float64 // Размер страницы в пунктах (1/72 дюйма)
+	pages         []*bytes.Buffer
+}
+
+// newPage - начинает новую страницу
+func (d *document) newPage() *bytes.Buffer {
+	page := &bytes.Buffer{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// text - выводит строку; x, y - левый нижний угол базовой линии в пунктах
+func text(page *bytes.Buffer, font string, size, x, y float64, s string) {
+	fmt.Fprintf(page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapeText(s))
+}
+
+// rect - закрашенный черным прямоугольник
+func rect(page *bytes.Buffer, x, y, w, h float64) {
+	fmt.Fprintf(page, "%.3f %.3f %.3f %.3f re f\n", x, y, w, h)
+}
+
+// WriteTo - записывает документ в формате PDF 1.4
+func (d *document) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// 1 - каталог, 2 - дерево страниц, 3 и 4 - шрифты, далее по два объекта на страницу
+	const firstPage = 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			d.width, d.height, firstPage+i*2+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// winAnsiExtra - символы WinAnsi вне Latin-1, которые встречаются в названиях и ценах
+var winAnsiExtra = map[rune]byte{'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '–': 0x96, '—': 0x97}
+
+// escapeText - строка для PDF в кодировке WinAnsi
+// Остальных символов (кириллица, японский) в стандартных шрифтах PDF нет - заменяем их на "?"
+func escapeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= ' ' && r <= '~', r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		case winAnsiExtra[r] != 0:
+			b.WriteByte(winAnsiExtra[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
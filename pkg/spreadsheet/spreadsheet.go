@@ -0,0 +1,280 @@
+// Пакет для чтения простых таблиц: CSV (с любым из распространенных разделителей) и первый лист XLSX
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnsupportedFormat - файл не похож ни на CSV, ни на XLSX
+var ErrUnsupportedFormat = errors.New("unsupported spreadsheet format, expected .csv or .xlsx")
+
+// Read - строки таблицы из файла; формат определяется по содержимому (XLSX - zip архив)
+// Строки дополняются пустыми ячейками до ширины самой длинной; пустые строки сохраняются,
+// чтобы номер строки в результате совпадал с номером строки в файле
+func Read(data []byte) ([][]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		rows, err = readXLSX(data)
+	} else {
+		rows, err = readCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return normalize(rows), nil
+}
+
+// readCSV - читает CSV; разделитель (",", ";" или табуляция) определяется по первой строке:
+// Excel в русской локали сохраняет CSV через ";", Google Sheets выгружает TSV
+func readCSV(data []byte) ([][]string, error) {
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%w: csv must be UTF-8", ErrUnsupportedFormat)
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff")) // BOM, который добавляет Excel
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter, best := ',', bytes.Count(firstLine, []byte(","))
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(d))); n > best {
+			delimiter, best = d, n
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Число ячеек в строках может отличаться
+	reader.LazyQuotes = true
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv error: %w", err)
+		}
+
+		// Пустые строки csv.Reader пропускает - ставим запись на строку файла, с которой она начинается
+		line, _ := reader.FieldPos(0)
+		for len(rows) < line-1 {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
+
+// Части XLSX (Office Open XML), которые нужны для чтения значений первого листа
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"` // Строка с форматированием - из нескольких частей
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxSheet struct {
+		Rows []struct {
+			Num   int `xml:"r,attr"` // Номер строки (пустые строки в файл не пишутся)
+			Cells []struct {
+				Ref    string   `xml:"r,attr"` // Адрес ячейки, например B2
+				Type   string   `xml:"t,attr"` // s - общая строка, inlineStr, str, b, n
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// String - текст ячейки (простой или из частей с форматированием)
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+// readXLSX - значения первого листа книги XLSX
+// Формулы не вычисляются - берется сохраненное в файле значение; даты остаются числами Excel
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(files, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		for row.Num > 0 && len(rows) < row.Num-1 {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				if col, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("reading xlsx error: invalid shared string in cell %s", cell.Ref)
+				}
+				values[col] = shared.Items[idx].String()
+			case "inlineStr":
+				values[col] = cell.Inline.String()
+			default:
+				values[col] = cell.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// firstSheetPath - путь к первому листу книги внутри архива
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("reading xlsx error: workbook has no sheets")
+	}
+
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") { // Путь от корня архива
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("reading xlsx error: first sheet not found")
+}
+
+// decodeXLSXPart - разбирает XML файл из архива
+func decodeXLSXPart(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: %s is missing", ErrUnsupportedFormat, name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("reading xlsx error: %w", err)
+	}
+	defer r.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(r, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("reading xlsx %s error: %w", name, err)
+	}
+	return nil
+}
+
+// columnIndex - номер колонки (с нуля) по адресу ячейки: A1 -> 0, AB12 -> 27
+func columnIndex(ref string) (int, error) {
+	col := 0
+	for i, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			col = col*26 + int(r-'A') + 1
+			continue
+		}
+		if i == 0 {
+			break
+		}
+		return col - 1, nil
+	}
+	return 0, fmt.Errorf("reading xlsx error: invalid cell reference %q", ref)
+}
+
+// normalize - убирает пробелы по краям ячеек и выравнивает ширину строк
+func normalize(rows [][]string) [][]string {
+	width := 0
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		width = max(width, len(row))
+	}
+
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// IsEmpty - пустая ли строка таблицы
+func IsEmpty(row []string) bool {
+	for _, value := range row {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"go-music-shop/internal/config"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Storage - хранилище в бакете S3 или совместимом сервисе (MinIO)
+// Запросы подписываются AWS Signature V4; SDK не нужен - используются только PUT, GET, DELETE и ListObjectsV2
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool   // Адрес вида endpoint/bucket/key (MinIO) вместо bucket.endpoint/key
+	publicURL string // Публичный адрес бакета (CDN); пусто - адрес объекта в S3
+	client    *http.Client
+}
+
+// NewS3Storage - создает хранилище по настройкам S3
+func NewS3Storage(cfg config.S3Config) (*S3Storage, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	return &S3Storage{
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.PathStyle,
+		publicURL: strings.TrimRight(cfg.PublicURL, "/"),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Put - сохраняет объект; содержимое читается в память целиком, потому что подпись включает его хэш
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading object error: %w", err)
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get - открывает объект на чтение (вызывающий должен закрыть его)
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete - удаляет объект (S3 не считает ошибкой удаление отсутствующего объекта)
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listBucketResult - ответ ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List - возвращает объекты с ключами, начинающимися с prefix, отсортированные по ключу
+// S3 отдает не больше 1000 ключей за запрос, поэтому страницы запрашиваются до конца
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	token := ""
+
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding S3 list response error: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			objects = append(objects, ObjectInfo{Key: object.Key, Size: object.Size, LastModified: object.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// PublicURL - адрес, по которому объект доступен покупателям
+// Бакет (или префикс) должен быть открыт на чтение, либо перед ним стоит CDN (S3_PUBLIC_URL)
+func (s *S3Storage) PublicURL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + s3Escape(key, false)
+	}
+	return s.objectURL(key, nil).String()
+}
+
+// objectURL - адрес объекта (key пустой - адрес бакета)
+func (s *S3Storage) objectURL(key string, query url.Values) *url.URL {
+	u := *s.endpoint
+	path := s.endpoint.Path + "/"
+	if s.pathStyle {
+		path += s.bucket + "/"
+	} else {
+		u.Host = s.bucket + "." + s.endpoint.Host
+	}
+	path += key
+
+	u.Path = path
+	u.RawPath = s3Escape(path, false) // Отправляем ровно тот путь, который подписан
+	u.RawQuery = canonicalQuery(query)
+	return &u
+}
+
+// newRequest - подписанный запрос к объекту key
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	if key != "" && (strings.HasPrefix(key, "/") || strings.Contains(key, "..")) {
+		return nil, fmt.Errorf("invalid storage key %q", key)
+	}
+
+	u := s.objectURL(key, query)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 request error: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+
+	s.sign(req, u, body, time.Now().UTC())
+	return req, nil
+}
+
+// do - выполняет запрос; ответы кроме 2xx превращаются в ошибки (404 - ErrNotFound)
+func (s *S3Storage) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request error: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, req.URL.Path)
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("S3 %s %s returned status %d: %s", req.Method, req.URL.Path, resp.StatusCode, bytes.TrimSpace(message))
+}
+
+// sign - подписывает запрос AWS Signature V4 (заголовок Authorization)
+func (s *S3Storage) sign(req *http.Request, u *url.URL, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		u.RawPath,
+		u.RawQuery,
+		"host:" + u.Host + "\n" + "x-amz-content-sha256:" + payloadHash + "\n" + "x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery - параметры запроса в порядке ключей, закодированные по правилам подписи
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape - URI-кодирование из спецификации подписи: без изменений остаются только A-Z, a-z, 0-9, "-", "_", ".", "~"
+// (и "/" в пути, если escapeSlash не задан)
+func s3Escape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// sha256Hex - SHA-256 в шестнадцатеричном виде
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 - HMAC-SHA256 строки data
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,151 @@
+// Пакет для хранения файлов (снимки каталога, выгрузки и т.п.)
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound - объекта с таким ключом нет
+var ErrNotFound = errors.New("object not found")
+
+// ObjectInfo - информация об объекте в хранилище
+type ObjectInfo struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// ObjectStorage - интерфейс объектного хранилища (ключ -> содержимое)
+// Ключи - пути через "/", например "snapshots/20250101T000000Z.ndjson.gz"
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FileStorage - хранилище в каталоге локальной файловой системы
+// Подходит для одного сервера или общего тома (NFS, volume в docker)
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage - создает хранилище в каталоге dir (каталог создается при необходимости)
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating storage directory error: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// Put - сохраняет объект; запись идет во временный файл, чтобы не оставить обрезанный объект
+func (s *FileStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("creating storage directory error: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating storage file error: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // После Rename файла уже нет - ошибка игнорируется
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing storage file error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing storage file error: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("saving storage file error: %w", err)
+	}
+	return nil
+}
+
+// Get - открывает объект на чтение (вызывающий должен закрыть его)
+func (s *FileStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening storage file error: %w", err)
+	}
+	return file, nil
+}
+
+// List - возвращает объекты с ключами, начинающимися с prefix, отсортированные по ключу
+func (s *FileStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing storage error: %w", err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete - удаляет объект; отсутствующий объект - не ошибка (как в S3)
+func (s *FileStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("deleting storage file error: %w", err)
+	}
+	return nil
+}
+
+// path - путь к файлу объекта; ключи с ".." и абсолютные пути запрещены
+func (s *FileStorage) path(key string) (string, error) {
+	if key == "" || !fs.ValidPath(key) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
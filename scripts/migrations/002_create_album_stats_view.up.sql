@@ -0,0 +1,17 @@
+-- Сводная статистика каталога, пересчитывается по расписанию (REFRESH MATERIALIZED VIEW),
+-- чтобы эндпоинт /albums/stats не агрегировал всю таблицу на каждый запрос
+CREATE MATERIALIZED VIEW IF NOT EXISTS album_stats AS
+SELECT
+    1 AS id,
+    COUNT(*) AS total_albums,
+    COUNT(*) FILTER (WHERE in_stock) AS in_stock_albums,
+    COUNT(DISTINCT artist) AS total_artists,
+    COALESCE(AVG(price), 0) AS avg_price,
+    COALESCE(MIN(price), 0) AS min_price,
+    COALESCE(MAX(price), 0) AS max_price,
+    COALESCE(SUM(price) FILTER (WHERE in_stock), 0) AS stock_value,
+    NOW() AS refreshed_at
+FROM albums;
+
+-- Уникальный индекс нужен для REFRESH MATERIALIZED VIEW CONCURRENTLY (без блокировки чтения)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_stats_id ON album_stats(id);
@@ -0,0 +1,8 @@
+-- Версия схемы БД: сервис при старте сверяет ее с ожидаемой версией
+-- Каждая новая миграция должна добавлять сюда свой номер
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO schema_migrations (version) VALUES (1), (2), (3) ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+-- Просмотры страниц альбомов по дням
+-- Счетчики копятся в Redis и периодически сбрасываются сюда пачками
+CREATE TABLE IF NOT EXISTS album_views (
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (album_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_views_day ON album_views(day);
+
+INSERT INTO schema_migrations (version) VALUES (4) ON CONFLICT DO NOTHING;
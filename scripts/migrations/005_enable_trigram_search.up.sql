@@ -0,0 +1,7 @@
+-- Нечеткий поиск по исполнителю и названию (подсказки "Возможно, вы имели в виду")
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_albums_artist_trgm ON albums USING gin (artist gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_albums_title_trgm ON albums USING gin (title gin_trgm_ops);
+
+INSERT INTO schema_migrations (version) VALUES (5) ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- Закупочная цена альбома (только для админки и отчетов, в публичный API не отдается)
+-- NULL - закупочная цена еще не указана
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS cost_price DECIMAL(10,2) CHECK (cost_price >= 0);
+
+INSERT INTO schema_migrations (version) VALUES (6) ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- Место хранения пластинки на складе (стеллаж/ячейка), например "A-03-2"
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS bin_code VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_albums_bin_code ON albums(bin_code);
+
+INSERT INTO schema_migrations (version) VALUES (7) ON CONFLICT DO NOTHING;
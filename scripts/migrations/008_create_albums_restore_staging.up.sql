@@ -0,0 +1,18 @@
+-- Промежуточная таблица для восстановления каталога из снимка:
+-- снимок сначала загружается сюда, сравнивается с albums и только потом применяется
+CREATE TABLE IF NOT EXISTS albums_restore_staging (
+    id VARCHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    artist VARCHAR(255) NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    year INTEGER NOT NULL,
+    genre VARCHAR(100),
+    condition VARCHAR(50) NOT NULL,
+    in_stock BOOLEAN,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    cost_price DECIMAL(10,2),
+    bin_code VARCHAR(32)
+);
+
+INSERT INTO schema_migrations (version) VALUES (8) ON CONFLICT DO NOTHING;
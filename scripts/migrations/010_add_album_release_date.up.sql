@@ -0,0 +1,6 @@
+-- Дата выхода альбома (переиздания, предзаказы) - для календаря релизов
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS release_date DATE;
+
+CREATE INDEX IF NOT EXISTS idx_albums_release_date ON albums(release_date) WHERE release_date IS NOT NULL;
+
+INSERT INTO schema_migrations (version) VALUES (10) ON CONFLICT DO NOTHING;
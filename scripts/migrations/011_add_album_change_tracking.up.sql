@@ -0,0 +1,43 @@
+-- Отслеживание изменений каталога для синхронизации (офлайн-каталог мобильного приложения)
+-- Каждая вставка, изменение и удаление альбома получает номер из общей последовательности:
+-- клиент запоминает последний полученный номер и запрашивает только то, что изменилось после него
+CREATE SEQUENCE IF NOT EXISTS album_change_seq;
+
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT nextval('album_change_seq');
+
+CREATE INDEX IF NOT EXISTS idx_albums_change_seq ON albums(change_seq);
+
+-- Удаленные альбомы (tombstones) - чтобы клиент узнал об удалении
+CREATE TABLE IF NOT EXISTS album_tombstones (
+    seq BIGINT PRIMARY KEY DEFAULT nextval('album_change_seq'),
+    album_id VARCHAR(36) NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Новый номер при изменении: триггер ловит и изменения в обход приложения (восстановление из снимка)
+-- Строки, которые перезаписываются без изменений, номер не получают
+CREATE OR REPLACE FUNCTION albums_track_change() RETURNS trigger AS $$
+BEGIN
+    IF ROW(NEW.*) IS DISTINCT FROM ROW(OLD.*) THEN
+        NEW.change_seq := nextval('album_change_seq');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS albums_track_change ON albums;
+CREATE TRIGGER albums_track_change BEFORE UPDATE ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_track_change();
+
+CREATE OR REPLACE FUNCTION albums_record_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO album_tombstones (album_id) VALUES (OLD.id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS albums_record_tombstone ON albums;
+CREATE TRIGGER albums_record_tombstone AFTER DELETE ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_record_tombstone();
+
+INSERT INTO schema_migrations (version) VALUES (11) ON CONFLICT DO NOTHING;
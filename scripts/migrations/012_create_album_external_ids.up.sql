@@ -0,0 +1,14 @@
+-- Идентификаторы альбомов во внешних системах (Discogs, MusicBrainz, Shopify, кассовая система)
+-- Интеграции обращаются к записям по своим id, не зная наших
+CREATE TABLE IF NOT EXISTS album_external_ids (
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    system VARCHAR(32) NOT NULL,
+    external_id VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (album_id, system)
+);
+
+-- Один внешний id указывает ровно на один альбом
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_external_ids_lookup ON album_external_ids(system, external_id);
+
+INSERT INTO schema_migrations (version) VALUES (12) ON CONFLICT DO NOTHING;
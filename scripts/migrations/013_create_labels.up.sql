@@ -0,0 +1,17 @@
+-- Лейблы звукозаписи (Blue Note, Impulse!, Prestige...) для просмотра каталога по лейблам
+CREATE TABLE IF NOT EXISTS labels (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(2), -- Код страны ISO 3166-1 alpha-2
+    founded_year INTEGER CHECK (founded_year >= 1800),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_name ON labels(LOWER(name));
+
+-- Лейбл альбома; при удалении лейбла альбомы остаются без лейбла
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS label_id VARCHAR(36) REFERENCES labels(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_albums_label_id ON albums(label_id);
+
+INSERT INTO schema_migrations (version) VALUES (13) ON CONFLICT DO NOTHING;
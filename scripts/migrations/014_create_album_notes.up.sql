@@ -0,0 +1,14 @@
+-- Внутренние заметки сотрудников к альбомам (происхождение пластинки, оценка состояния)
+-- Видны только в админке, в публичные ответы и protobuf не попадают
+CREATE TABLE IF NOT EXISTS album_notes (
+    id BIGSERIAL PRIMARY KEY,
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    mentions TEXT[] NOT NULL DEFAULT '{}', -- Упомянутые через @имя сотрудники
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_notes_album_id ON album_notes(album_id, created_at);
+
+INSERT INTO schema_migrations (version) VALUES (14) ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- Индекс для списка альбомов в порядке по умолчанию (сначала новые) и постраничного чтения по курсору:
+-- WHERE (created_at, id) < ($1, $2) ORDER BY created_at DESC, id DESC читает только нужную страницу
+CREATE INDEX IF NOT EXISTS idx_albums_created_at_id ON albums(created_at DESC, id DESC);
+
+INSERT INTO schema_migrations (version) VALUES (15) ON CONFLICT DO NOTHING;
@@ -0,0 +1,38 @@
+-- Количество экземпляров на складе вместо флага наличия: in_stock в API вычисляется как stock_quantity > 0
+-- Альбомы, которые были в наличии, получают один экземпляр - точное количество вносится инвентаризацией
+BEGIN;
+
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS stock_quantity INTEGER NOT NULL DEFAULT 0
+    CONSTRAINT albums_stock_quantity_check CHECK (stock_quantity >= 0);
+
+UPDATE albums SET stock_quantity = 1 WHERE in_stock;
+
+-- Статистика каталога считалась по in_stock - пересоздаем ее по количеству
+DROP MATERIALIZED VIEW IF EXISTS album_stats;
+
+ALTER TABLE albums DROP COLUMN in_stock;
+
+CREATE MATERIALIZED VIEW album_stats AS
+SELECT
+    1 AS id,
+    COUNT(*) AS total_albums,
+    COUNT(*) FILTER (WHERE stock_quantity > 0) AS in_stock_albums,
+    COUNT(DISTINCT artist) AS total_artists,
+    COALESCE(AVG(price), 0) AS avg_price,
+    COALESCE(MIN(price), 0) AS min_price,
+    COALESCE(MAX(price), 0) AS max_price,
+    COALESCE(SUM(price * stock_quantity), 0) AS stock_value,
+    NOW() AS refreshed_at
+FROM albums;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_stats_id ON album_stats(id);
+
+-- Список альбомов в наличии (/albums/stock) в порядке по умолчанию
+CREATE INDEX IF NOT EXISTS idx_albums_in_stock ON albums(created_at DESC) WHERE stock_quantity > 0;
+
+ALTER TABLE albums_restore_staging DROP COLUMN IF EXISTS in_stock;
+ALTER TABLE albums_restore_staging ADD COLUMN IF NOT EXISTS stock_quantity INTEGER;
+
+INSERT INTO schema_migrations (version) VALUES (16) ON CONFLICT DO NOTHING;
+
+COMMIT;
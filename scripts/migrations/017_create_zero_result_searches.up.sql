@@ -0,0 +1,11 @@
+-- Поиски без результатов по дням: какие пластинки ищут покупатели, но которых нет в каталоге
+-- Запрос хранится нормализованным (нижний регистр, одиночные пробелы), чтобы одинаковые запросы складывались
+CREATE TABLE IF NOT EXISTS zero_result_searches (
+    day DATE NOT NULL,
+    query VARCHAR(200) NOT NULL,
+    searches INTEGER NOT NULL DEFAULT 0,
+    last_searched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (day, query)
+);
+
+INSERT INTO schema_migrations (version) VALUES (17) ON CONFLICT DO NOTHING;
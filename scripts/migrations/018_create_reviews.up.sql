@@ -0,0 +1,20 @@
+-- Отзывы покупателей к альбомам: один отзыв от покупателя на альбом, оценка от 1 до 5
+CREATE TABLE IF NOT EXISTS reviews (
+    id BIGSERIAL PRIMARY KEY,
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    customer_id VARCHAR(100) NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT reviews_album_customer_key UNIQUE (album_id, customer_id)
+);
+
+-- Отзывы альбома от новых к старым
+CREATE INDEX IF NOT EXISTS idx_reviews_album ON reviews(album_id, created_at DESC);
+
+-- Средняя оценка и число отзывов хранятся в альбоме и пересчитываются при каждом изменении отзывов,
+-- чтобы чтение альбома (и его кэш) не требовало агрегации
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS average_rating NUMERIC(3, 2) NOT NULL DEFAULT 0;
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS review_count INTEGER NOT NULL DEFAULT 0;
+
+INSERT INTO schema_migrations (version) VALUES (18) ON CONFLICT DO NOTHING;
@@ -0,0 +1,23 @@
+-- Очередь штрихкодов, отсканированных при приемке, но не привязанных ни к одному альбому
+-- Фоновая задача ищет их в Discogs, сотрудники заводят по найденным данным новые альбомы
+CREATE TABLE IF NOT EXISTS unknown_barcodes (
+    barcode VARCHAR(100) PRIMARY KEY,
+    condition VARCHAR(20),
+    price NUMERIC(10, 2),
+    scans INTEGER NOT NULL DEFAULT 0,
+    first_scanned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_scanned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CONSTRAINT unknown_barcodes_status_check CHECK (status IN ('pending', 'found', 'not_found')),
+    discogs_release_id BIGINT,
+    title VARCHAR(255),
+    artist VARCHAR(255),
+    year INTEGER,
+    genre VARCHAR(100),
+    looked_up_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Очередь на поиск - от давно отсканированных к новым
+CREATE INDEX IF NOT EXISTS idx_unknown_barcodes_status ON unknown_barcodes(status, first_scanned_at);
+
+INSERT INTO schema_migrations (version) VALUES (19) ON CONFLICT DO NOTHING;
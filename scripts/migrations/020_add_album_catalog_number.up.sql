@@ -0,0 +1,8 @@
+-- Каталожный номер издания на лейбле (Blue Note BLP 1577, Impulse! A-77): коллекционеры ищут по нему
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS catalog_number VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Поиск по номеру без учета регистра, пробелов и дефисов: "BLP 1577", "blp-1577" и "BLP1577" совпадают
+CREATE INDEX IF NOT EXISTS idx_albums_catalog_number_trgm
+    ON albums USING gin (regexp_replace(LOWER(catalog_number), '[^a-z0-9]', '', 'g') gin_trgm_ops);
+
+INSERT INTO schema_migrations (version) VALUES (20) ON CONFLICT DO NOTHING;
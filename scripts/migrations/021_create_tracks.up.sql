@@ -0,0 +1,11 @@
+-- Список треков альбома (порядок на пластинке и длительность)
+CREATE TABLE IF NOT EXISTS tracks (
+    id BIGSERIAL PRIMARY KEY,
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    title VARCHAR(255) NOT NULL,
+    duration_seconds INTEGER NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0), -- 0 - неизвестна
+    UNIQUE (album_id, position)
+);
+
+INSERT INTO schema_migrations (version) VALUES (21) ON CONFLICT DO NOTHING;
@@ -0,0 +1,25 @@
+-- Издания альбома: оригинальный тираж, переиздание на LP, CD и т.д.
+-- Цена и количество на складе ведутся по изданиям; albums.price и albums.stock_quantity -
+-- сводные значения (минимальная цена и сумма количеств), их пересчитывает приложение
+CREATE TABLE IF NOT EXISTS album_variants (
+    id BIGSERIAL PRIMARY KEY,
+    album_id VARCHAR(36) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('lp', '2lp', '10in', '7in', 'cd', 'cassette')),
+    pressing_year INTEGER NOT NULL DEFAULT 0,  -- 0 - неизвестен
+    weight_grams INTEGER NOT NULL DEFAULT 0 CHECK (weight_grams >= 0), -- 0 - неизвестен
+    speed_rpm INTEGER NOT NULL DEFAULT 0 CHECK (speed_rpm IN (0, 33, 45, 78)), -- 0 - не винил или неизвестна
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    stock_quantity INTEGER NOT NULL DEFAULT 0 CHECK (stock_quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_variants_album_id ON album_variants(album_id);
+
+-- Существующие альбомы получают по одному изданию LP с их ценой и количеством
+INSERT INTO album_variants (album_id, format, pressing_year, price, stock_quantity)
+SELECT a.id, 'lp', a.year, a.price, a.stock_quantity
+FROM albums a
+WHERE NOT EXISTS (SELECT 1 FROM album_variants v WHERE v.album_id = a.id);
+
+INSERT INTO schema_migrations (version) VALUES (22) ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+-- Названия и примечания альбома на разных языках: {"ja": {"title": "...", "notes": "..."}, "ja-latn": {...}}
+-- Японские издания ищут и в оригинальной записи, и в транслитерации
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS localized JSONB NOT NULL DEFAULT '{}';
+
+-- Поиск по переведенным названиям (все названия одной строкой)
+CREATE INDEX IF NOT EXISTS idx_albums_localized_titles_trgm
+    ON albums USING gin ((jsonb_path_query_array(localized, '$.*.title')::text) gin_trgm_ops);
+
+INSERT INTO schema_migrations (version) VALUES (23) ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- Описание альбома для страницы товара (markdown или очищенный HTML) и внутренние заметки о состоянии экземпляра
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS condition_notes TEXT NOT NULL DEFAULT '';
+
+-- Слова поиска ищутся и в описании
+CREATE INDEX IF NOT EXISTS idx_albums_description_trgm ON albums USING gin (description gin_trgm_ops);
+
+INSERT INTO schema_migrations (version) VALUES (24) ON CONFLICT DO NOTHING;
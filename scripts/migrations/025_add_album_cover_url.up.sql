@@ -0,0 +1,4 @@
+-- Адрес обложки альбома в объектном хранилище (S3/MinIO); пусто - обложка не загружена
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS cover_url TEXT NOT NULL DEFAULT '';
+
+INSERT INTO schema_migrations (version) VALUES (25) ON CONFLICT DO NOTHING;
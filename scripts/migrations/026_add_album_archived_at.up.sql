@@ -0,0 +1,28 @@
+-- Архивирование альбомов (мягкое удаление): строка остается в базе вместе с треками, изданиями и отзывами,
+-- но альбом не виден в каталоге, поиске и синхронизации, а изменения в нем отклоняются как для несуществующего
+BEGIN;
+
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Статистика каталога считается только по видимым альбомам
+DROP MATERIALIZED VIEW IF EXISTS album_stats;
+
+CREATE MATERIALIZED VIEW album_stats AS
+SELECT
+    1 AS id,
+    COUNT(*) AS total_albums,
+    COUNT(*) FILTER (WHERE stock_quantity > 0) AS in_stock_albums,
+    COUNT(DISTINCT artist) AS total_artists,
+    COALESCE(AVG(price), 0) AS avg_price,
+    COALESCE(MIN(price), 0) AS min_price,
+    COALESCE(MAX(price), 0) AS max_price,
+    COALESCE(SUM(price * stock_quantity), 0) AS stock_value,
+    NOW() AS refreshed_at
+FROM albums
+WHERE archived_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_stats_id ON album_stats(id);
+
+INSERT INTO schema_migrations (version) VALUES (26) ON CONFLICT DO NOTHING;
+
+COMMIT;